@@ -10,6 +10,7 @@ package main
 import (
 	"context"
 	"flag"
+	"strings"
 	"time"
 
 	"github.com/intel/oim/pkg/log"
@@ -22,14 +23,21 @@ var (
 	printVersion      = flag.Bool("version", false, "output version information and exit")
 	endpoint          = flag.String("endpoint", "tcp://:8999", "OIM controller endpoint for net.Listen")
 	spdk              = flag.String("spdk", "/var/tmp/vhost.sock", "SPDK VHost RPC socket path")
-	vhost             = flag.String("vhost-scsi-controller", "vhost.0", "SPDK VirtIO SCSI controller name")
-	vhostDev          = flag.String("vm-vhost-device", "", "the PCI address of the SCSI controller in a VM ([domain:]bus:device.function), partial address allowed (:.3)")
+	vhost             = flag.String("vhost-scsi-controller", "vhost.0", "SPDK VirtIO SCSI controller name, a comma-separated list for more than one controller")
+	maxSCSITargets    = flag.Uint("vhost-scsi-targets", 8, "number of SCSI targets per SPDK VirtIO SCSI controller, must match SPDK_VHOST_SCSI_CTRLR_MAX_DEVS of the SPDK build because SPDK does not report it")
+	vhostDev          = flag.String("vm-vhost-device", "", "the PCI address of the SCSI controller in a VM ([domain:]bus:device.function), partial address allowed (:.3), a comma-separated list with one address per controller")
 	controllerID      = flag.String("controllerid", "", "unique id for this controller instance")
 	controllerAddress = flag.String("controller-address", "ipv4:///oim-controller:8999", "external gRPC name for use with grpc.Dial that corresponds to the endpoint")
 	registry          = flag.String("registry", "", "gRPC name that connects to the OIM registry, empty disables registration")
 	ca                = flag.String("ca", "", "the required CA's .crt file which is used for verifying connections to the registry")
 	key               = flag.String("key", "", "the base name of the required .key and .crt files that authenticate and authorize the registry client")
-	registryDelay     = flag.Duration("registry-delay", time.Minute, "determines how long the controller waits before registering at the OIM registry")
+	registryDelay     = flag.Duration("registry-delay", time.Minute, "determines how long the controller waits before registering at the OIM registry again, also the maximum delay between retries after a failure")
+	reconcileInterval = flag.Duration("reconcile-interval", 5*time.Minute, "how often to look for orphaned BDevs and SCSI targets, 0 disables the periodic check")
+	orphanGracePeriod = flag.Duration("orphan-grace-period", 10*time.Minute, "minimum time that something must be an orphan before it gets removed")
+	deleteOrphans     = flag.Bool("delete-orphans", false, "remove orphans automatically instead of just logging them")
+	notifyInterval    = flag.Duration("notification-interval", 5*time.Second, "how often to ask SPDK for deleted BDevs, 0 disables watching SPDK notifications")
+	quiescePeriod     = flag.Duration("quiesce-period", 5*time.Second, "how long a volume must be without I/O before QuiesceVolume removes it, 0 disables the check")
+	cephKeyringDir    = flag.String("ceph-keyring-dir", "", "directory with Ceph keyring files that MapVolume requests may refer to by name, empty disables that")
 	_                 = log.InitSimpleFlags()
 )
 
@@ -59,13 +67,30 @@ func main() {
 	options := []oimcontroller.Option{
 		oimcontroller.WithControllerID(*controllerID),
 		oimcontroller.WithSPDK(*spdk),
-		oimcontroller.WithVHostController(*vhost),
-		oimcontroller.WithVHostDev(*vhostDev),
+		oimcontroller.WithMaxSCSITargets(uint32(*maxSCSITargets)),
 		oimcontroller.WithControllerAddress(*controllerAddress),
 		oimcontroller.WithRegistry(*registry),
 		oimcontroller.WithRegistryDelay(*registryDelay),
+		oimcontroller.WithReconcileInterval(*reconcileInterval),
+		oimcontroller.WithOrphanGracePeriod(*orphanGracePeriod),
+		oimcontroller.WithDeleteOrphans(*deleteOrphans),
+		oimcontroller.WithNotificationInterval(*notifyInterval),
+		oimcontroller.WithQuiescePeriod(*quiescePeriod),
+		oimcontroller.WithCephKeyringDir(*cephKeyringDir),
 		oimcontroller.WithCreds(transportCreds),
 	}
+	vhosts := strings.Split(*vhost, ",")
+	vhostDevs := strings.Split(*vhostDev, ",")
+	if len(vhosts) != len(vhostDevs) {
+		logger.Fatalf("%d VHost SCSI controllers, but %d PCI addresses", len(vhosts), len(vhostDevs))
+	}
+	options = append(options,
+		oimcontroller.WithVHostController(vhosts[0]),
+		oimcontroller.WithVHostDev(vhostDevs[0]),
+	)
+	for i := 1; i < len(vhosts); i++ {
+		options = append(options, oimcontroller.WithAdditionalVHostController(vhosts[i], vhostDevs[i]))
+	}
 	controller, err := oimcontroller.New(options...)
 	if err != nil {
 		logger.Fatalf("Failed to initialize server: %s\n", err)
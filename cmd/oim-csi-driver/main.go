@@ -29,6 +29,7 @@ var (
 	controllerID       = flag.String("controller-id", "", "The ID under which the OIM controller can be found in the registry.")
 	emulate            = flag.String("emulate", "", "name of CSI driver to emulate for node operations")
 	csiversion         = flag.String("csiversion", "1.0", "CSI version that is to be implemented by the driver (1.0 or 0.3)")
+	lvolStore          = flag.String("lvol-store", "", "name of an existing SPDK lvol store. If set, volumes are created as logical volumes inside it, which enables snapshots and cloning.")
 	_                  = log.InitSimpleFlags()
 )
 
@@ -60,11 +61,13 @@ func main() {
 		oimcsidriver.WithRegistryCreds(*ca, *key),
 		oimcsidriver.WithEmulation(*emulate),
 		oimcsidriver.WithCSIVersion(*csiversion),
+		oimcsidriver.WithLVolStore(*lvolStore),
 	}
 	driver, err := oimcsidriver.New(options...)
 	if err != nil {
 		logger.Fatalf("Failed to initialize driver: %s\n", err)
 	}
+	defer driver.Close()
 	if err := driver.Run(context.Background()); err != nil {
 		logger.Fatal(err)
 	}
@@ -13,11 +13,14 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/oim-controller"
 	"github.com/intel/oim/pkg/spec/oim/v0"
 )
 
@@ -37,6 +40,14 @@ var (
 	set   = flag.Bool("set", false, "sets or updates a registry value, deletes it when value is empty")
 	path  = flag.String("path", "", "the complete path of a value (set, delete, get of single value) or a path prefix (get multiple values)")
 	value = flag.String("value", "", "the value to set or update")
+
+	controllerID = flag.String("controllerid", "", "the OIM controller which is to be inspected via the registry (list-volumes, get-volume)")
+	listVolumes  = flag.Bool("list-volumes", false, "list all volumes of the OIM controller")
+	getVolume    = flag.String("get-volume", "", "show the volume with this ID")
+
+	handover           = flag.String("handover", "", "comma-separated list of volume IDs which are to be moved from -controllerid to -target-controllerid")
+	targetControllerID = flag.String("target-controllerid", "", "the OIM controller which takes over the volumes (handover)")
+	host               = flag.String("host", "", "the host whose volumes are handed over, defaults to -controllerid (handover)")
 )
 
 func main() {
@@ -113,7 +124,64 @@ func main() {
 		for _, entry := range reply.Values {
 			fmt.Printf("%s=%s\n", entry.Path, entry.Value)
 		}
+	} else if *listVolumes || *getVolume != "" {
+		if *controllerID == "" {
+			logger.Fatal("-controllerid must be set")
+		}
+		controller := oim.NewControllerClient(conn)
+		ctx := metadata.AppendToOutgoingContext(ctx, "controllerid", *controllerID)
+		var volumes []*oim.Volume
+		if *listVolumes {
+			reply, err := controller.ListVolumes(ctx, &oim.ListVolumesRequest{})
+			if err != nil {
+				logger.Fatalw("listing volumes", "error", err)
+			}
+			volumes = reply.GetVolumes()
+		} else {
+			reply, err := controller.GetVolume(ctx, &oim.GetVolumeRequest{VolumeId: *getVolume})
+			if err != nil {
+				logger.Fatalw("getting volume", "error", err, "volume", *getVolume)
+			}
+			volumes = append(volumes, reply.GetVolume())
+		}
+		printVolumes(volumes)
+	} else if *handover != "" {
+		if *controllerID == "" || *targetControllerID == "" {
+			logger.Fatal("-controllerid and -target-controllerid must be set")
+		}
+		hostID := *host
+		if hostID == "" {
+			hostID = *controllerID
+		}
+		if err := oimcontroller.Handover(ctx, conn, hostID, *controllerID, *targetControllerID, strings.Split(*handover, ",")...); err != nil {
+			logger.Fatalw("handover", "error", err)
+		}
 	} else {
-		logger.Fatal("either --get or --set must be chosen")
+		logger.Fatal("one of --get, --set, --list-volumes, --get-volume or --handover must be chosen")
+	}
+}
+
+// printVolumes writes a table with one volume per line to stdout.
+func printVolumes(volumes []*oim.Volume) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "VOLUME\tBDEV\tTYPE\tSIZE\tENCRYPTED\tCLAIMED\tPCI\tSCSI")
+	for _, volume := range volumes {
+		pci, scsi := "-", "-"
+		if volume.GetPciAddress() != nil {
+			pci = oimcommon.PrettyPCIAddress(volume.GetPciAddress())
+		}
+		if volume.GetScsiDisk() != nil {
+			scsi = fmt.Sprintf("%d:%d", volume.GetScsiDisk().GetTarget(), volume.GetScsiDisk().GetLun())
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%v\t%v\t%s\t%s\n",
+			volume.GetVolumeId(),
+			volume.GetBdevName(),
+			volume.GetBackingType(),
+			volume.GetSize_(),
+			volume.GetEncrypted(),
+			volume.GetClaimed(),
+			pci,
+			scsi)
 	}
+	w.Flush()
 }
@@ -18,6 +18,22 @@ const (
 
 	// RegistryPCI is the special registry path element with the PCI address of an accelerator card.
 	RegistryPCI = "pci"
+
+	// RegistryLeases is the first path element of all volume
+	// leases. It cannot be a host name and thus does not
+	// conflict with controller IDs that are host names.
+	RegistryLeases = "_leases"
+
+	// RegistryHandovers is the first path element of the
+	// handovers that are in progress, followed by the target
+	// controller and volume ID. The value is the source
+	// controller.
+	RegistryHandovers = "_handovers"
+
+	// RegistryController is the special registry path element
+	// which redirects a host to the controller with the ID stored
+	// in the value.
+	RegistryController = "controllerid"
 )
 
 // SplitRegistryPath separates the path into elements.
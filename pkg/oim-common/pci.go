@@ -64,6 +64,18 @@ func CompletePCIAddress(addr, def oim.PCIAddress) oim.PCIAddress {
 	return addr
 }
 
+// CompatiblePCIAddresses returns true if the two addresses are the
+// same in all fields that are known in both.
+func CompatiblePCIAddresses(a, b oim.PCIAddress) bool {
+	same := func(x, y uint32) bool {
+		return x == 0xFFFF || y == 0xFFFF || x == y
+	}
+	return same(a.Domain, b.Domain) &&
+		same(a.Bus, b.Bus) &&
+		same(a.Device, b.Device) &&
+		same(a.Function, b.Function)
+}
+
 // PrettyPCIAddress formats a PCI address in extended BDF format.
 func PrettyPCIAddress(p *oim.PCIAddress) string {
 	if p == nil {
@@ -92,6 +92,22 @@ func TestCompletePCIAddress(t *testing.T) {
 	}
 }
 
+func TestCompatiblePCIAddresses(t *testing.T) {
+	cases := []struct {
+		a, b       oim.PCIAddress
+		compatible bool
+	}{
+		{oim.PCIAddress{Domain: 0xFFFF, Bus: 0xFFFF, Device: 0xFFFF, Function: 0xFFFF}, oim.PCIAddress{Domain: 1, Bus: 2, Device: 3, Function: 4}, true},
+		{oim.PCIAddress{Domain: 1, Bus: 0xFFFF, Device: 3, Function: 0xFFFF}, oim.PCIAddress{Domain: 0xFFFF, Bus: 2, Device: 3, Function: 4}, true},
+		{oim.PCIAddress{Domain: 1, Bus: 2, Device: 3, Function: 4}, oim.PCIAddress{Domain: 1, Bus: 2, Device: 5, Function: 4}, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.compatible, CompatiblePCIAddresses(c.a, c.b), "%v %v", c.a, c.b)
+		assert.Equal(t, c.compatible, CompatiblePCIAddresses(c.b, c.a), "%v %v", c.b, c.a)
+	}
+}
+
 func TestPrettyPCIAddress(t *testing.T) {
 	cases := []struct {
 		address *oim.PCIAddress
@@ -74,7 +74,8 @@ func (s *NonBlockingGRPCServer) Start(ctx context.Context, services ...RegisterS
 	s.addr = listener.Addr()
 
 	logger := log.FromContext(ctx)
-	formatter := CompletePayloadFormatter{}
+	// Requests may contain secrets like encryption keys.
+	formatter := StripSecretsFormatter{}
 
 	// interceptor := grpc_middleware.ChainUnaryServer(
 	// 	otgrpc.OpenTracingServerInterceptor(
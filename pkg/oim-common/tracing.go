@@ -9,8 +9,10 @@ package oimcommon
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"google.golang.org/grpc"
 
@@ -47,13 +49,46 @@ func (c CompletePayloadFormatter) Sprint(payload interface{}) string {
 	return result
 }
 
-// StripSecretsFormatter removes secret fields from a CSI 0.3 or CSI 1.0 message
-// using the protosanitizer package.
+// StripSecretsFormatter removes secret fields from a CSI 0.3, CSI 1.0
+// or OIM message.
 type StripSecretsFormatter struct{}
 
-// Sprint strips messages for CSI >= 1.0.
+// Sprint strips messages for CSI >= 1.0 using the protosanitizer
+// package, then removes fields which contain secrets according to
+// the naming conventions of CSI 0.3 and OIM.
 func (s StripSecretsFormatter) Sprint(payload interface{}) string {
-	return protosanitizer.StripSecrets(payload).String()
+	stripped := protosanitizer.StripSecrets(payload).String()
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(stripped), &parsed); err != nil {
+		// Probably an error message from protosanitizer.
+		return stripped
+	}
+	stripSecretFields(parsed)
+	b, err := json.Marshal(parsed)
+	if err != nil {
+		return fmt.Sprintf("<<json.Marshal %T: %s>>", payload, err)
+	}
+	return string(b)
+}
+
+// stripSecretFields replaces the value of fields called "secret"
+// or "key" (OIM) or ending in "_secrets" (CSI 0.3). This also
+// catches map entries with these names, which is harmless.
+func stripSecretFields(parsed interface{}) {
+	switch parsed := parsed.(type) {
+	case map[string]interface{}:
+		for name, value := range parsed {
+			if name == "secret" || name == "key" || strings.HasSuffix(name, "_secrets") {
+				parsed[name] = "***stripped***"
+			} else {
+				stripSecretFields(value)
+			}
+		}
+	case []interface{}:
+		for _, value := range parsed {
+			stripSecretFields(value)
+		}
+	}
 }
 
 // NullPayloadFormatter just produces "nil" or "<filtered>".
@@ -0,0 +1,49 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+func TestStripSecrets(t *testing.T) {
+	for _, payload := range []interface{}{
+		&oim.MapVolumeRequest{
+			VolumeId: "vol",
+			Params: &oim.MapVolumeRequest_Ceph{
+				Ceph: &oim.CephParams{
+					Pool:   "rbd",
+					Secret: "my-secret-value",
+				},
+			},
+			Crypto: &oim.CryptoParams{
+				Key: "my-secret-value",
+			},
+		},
+		&csi.NodeStageVolumeRequest{
+			VolumeId: "vol",
+			Secrets:  map[string]string{"encryptionKey": "my-secret-value"},
+		},
+		&csi0.NodeStageVolumeRequest{
+			VolumeId:         "vol",
+			NodeStageSecrets: map[string]string{"encryptionKey": "my-secret-value"},
+		},
+	} {
+		stripped := StripSecretsFormatter{}.Sprint(payload)
+		assert.True(t, strings.Contains(stripped, `"vol"`), "%T: volume ID should be visible: %s", payload, stripped)
+		assert.False(t, strings.Contains(stripped, "my-secret-value"), "%T: secret should be stripped: %s", payload, stripped)
+	}
+	assert.Equal(t, "null", StripSecretsFormatter{}.Sprint(nil))
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// rbdDefaultBlockSize is used when CephParams leave the block size
+// unset. SPDK requires a multiple of it.
+const rbdDefaultBlockSize = 512
+
+// cephConfigPrefixes are the Ceph options that CephParams.Config
+// may set. Everything else could replace the monitors, key or
+// keyring derived from the other fields or make librados access
+// arbitrary files on the controller host.
+var cephConfigPrefixes = []string{"rbd_", "rados_", "objecter_"}
+
+// cephConfigPathSuffixes reject options with an allowed prefix which
+// nevertheless name files or directories, like
+// rbd_persistent_cache_path.
+var cephConfigPathSuffixes = []string{"_path", "_file", "_dir", "_socket"}
+
+// mapCeph creates a BDev for the RBD image. The secret must not
+// end up in errors or log messages.
+func (c *Controller) mapCeph(ctx context.Context, volumeID string, cephParams *oim.CephParams) error {
+	if c.SPDK == nil {
+		return errors.New("not connected to SPDK")
+	}
+	blockSize := int64(cephParams.GetBlockSize())
+	if blockSize == 0 {
+		blockSize = rbdDefaultBlockSize
+	}
+	if blockSize%rbdDefaultBlockSize != 0 {
+		return status.Errorf(codes.InvalidArgument, "block size %d is not a multiple of %d", blockSize, rbdDefaultBlockSize)
+	}
+	config, err := c.cephConfig(cephParams)
+	if err != nil {
+		return err
+	}
+	request := spdk.ConstructRBDBDevArgs{
+		BlockSize: blockSize,
+		Name:      volumeID,
+		UserID:    cephParams.GetUserId(),
+		PoolName:  cephParams.GetPool(),
+		RBDName:   cephParams.GetImage(),
+		Config:    config,
+	}
+	_, err = spdk.ConstructRBDBDev(ctx, c.SPDK, request)
+	return errors.Wrapf(err, "ConstructRBDBDev %q for RBD pool %q and image %q, monitors %q", volumeID, cephParams.GetPool(), cephParams.GetImage(), cephParams.GetMonitors())
+}
+
+// cephConfig returns the options that SPDK passes to librados. A nil
+// map makes SPDK read the default ceph.conf instead.
+func (c *Controller) cephConfig(cephParams *oim.CephParams) (map[string]string, error) {
+	if cephParams.GetSecret() != "" && cephParams.GetKeyring() != "" {
+		return nil, status.Error(codes.InvalidArgument, "secret and keyring are mutually exclusive")
+	}
+	config := map[string]string{}
+	if cephParams.GetMonitors() != "" {
+		config["mon_host"] = cephParams.GetMonitors()
+	}
+	if cephParams.GetSecret() != "" {
+		config["key"] = cephParams.GetSecret()
+	}
+	if cephParams.GetKeyring() != "" {
+		keyring, err := c.cephKeyring(cephParams.GetKeyring())
+		if err != nil {
+			return nil, err
+		}
+		config["keyring"] = keyring
+	}
+	for key, value := range cephParams.GetConfig() {
+		if !cephConfigAllowed(key) {
+			return nil, status.Errorf(codes.InvalidArgument, "Ceph config option %q not allowed", key)
+		}
+		config[key] = value
+	}
+	if len(config) == 0 {
+		return nil, nil
+	}
+	return config, nil
+}
+
+// cephConfigAllowed checks an option name. Ceph treats spaces,
+// dashes and underscores in option names the same.
+func cephConfigAllowed(key string) bool {
+	key = strings.ToLower(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.TrimSpace(key)))
+	for _, suffix := range cephConfigPathSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return false
+		}
+	}
+	for _, prefix := range cephConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// cephKeyring finds the keyring file with the given name in the
+// configured directory.
+func (c *Controller) cephKeyring(name string) (string, error) {
+	if c.cephKeyringDir == "" {
+		return "", status.Errorf(codes.FailedPrecondition, "keyring %q: no keyring directory configured", name)
+	}
+	if name == "." || name == ".." || strings.ContainsRune(name, filepath.Separator) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid keyring name %q", name)
+	}
+	path := filepath.Join(c.cephKeyringDir, name)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return "", status.Errorf(codes.NotFound, "keyring %q not found", name)
+		}
+		return "", errors.Wrapf(err, "keyring %q", name)
+	}
+	return path, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/oim-controller"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ceph parameters", func() {
+	var keyringDir string
+
+	sim := simulate(nil)
+
+	BeforeEach(func() {
+		keyringDir = filepath.Join(sim.tmpDir, "keyrings")
+		err := os.Mkdir(keyringDir, 0700)
+		Expect(err).NotTo(HaveOccurred())
+		err = ioutil.WriteFile(filepath.Join(keyringDir, "client.kubernetes.keyring"), []byte("[client.kubernetes]\n"), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		sim.c.Close()
+		sim.startController(oimcontroller.WithCephKeyringDir(keyringDir))
+	})
+
+	mapCeph := func(volumeID string, params *oim.CephParams) (spdk.ConstructRBDBDevArgs, error) {
+		_, err := sim.c.MapVolume(context.Background(), &oim.MapVolumeRequest{
+			VolumeId: volumeID,
+			Params: &oim.MapVolumeRequest_Ceph{
+				Ceph: params,
+			},
+		})
+		return sim.simulated.RBDArgs(volumeID), err
+	}
+
+	It("should pass secret and monitors", func() {
+		args, err := mapCeph("volume-0", &oim.CephParams{
+			UserId:   "kubernetes",
+			Secret:   "my-secret",
+			Monitors: "192.168.7.2:6789",
+			Pool:     "rbd",
+			Image:    "image-0",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal(spdk.ConstructRBDBDevArgs{
+			BlockSize: 512,
+			Name:      "volume-0",
+			UserID:    "kubernetes",
+			PoolName:  "rbd",
+			RBDName:   "image-0",
+			Config: map[string]string{
+				"mon_host": "192.168.7.2:6789",
+				"key":      "my-secret",
+			},
+		}))
+	})
+
+	It("should apply block size, keyring and config overrides", func() {
+		args, err := mapCeph("volume-0", &oim.CephParams{
+			Monitors:  "192.168.7.2:6789",
+			Pool:      "rbd",
+			Image:     "image-0",
+			BlockSize: 4096,
+			Keyring:   "client.kubernetes.keyring",
+			Config: map[string]string{
+				"rbd_cache":                  "false",
+				"rados osd op timeout":       "30",
+				"objecter-inflight-op-bytes": "1048576",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args.BlockSize).To(Equal(int64(4096)))
+		Expect(args.Config).To(Equal(map[string]string{
+			"mon_host":                   "192.168.7.2:6789",
+			"keyring":                    filepath.Join(keyringDir, "client.kubernetes.keyring"),
+			"rbd_cache":                  "false",
+			"rados osd op timeout":       "30",
+			"objecter-inflight-op-bytes": "1048576",
+		}))
+	})
+
+	It("should fall back to ceph.conf", func() {
+		args, err := mapCeph("volume-0", &oim.CephParams{
+			Pool:  "rbd",
+			Image: "image-0",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args.Config).To(BeNil())
+	})
+
+	It("should reject invalid parameters", func() {
+		params := []struct {
+			params *oim.CephParams
+			code   codes.Code
+		}{
+			{&oim.CephParams{Pool: "rbd", Image: "image-0", BlockSize: 1000}, codes.InvalidArgument},
+			{&oim.CephParams{Pool: "rbd", Image: "image-0", Secret: "my-secret", Keyring: "client.kubernetes.keyring"}, codes.InvalidArgument},
+			{&oim.CephParams{Pool: "rbd", Image: "image-0", Keyring: "../keyrings/client.kubernetes.keyring"}, codes.InvalidArgument},
+			{&oim.CephParams{Pool: "rbd", Image: "image-0", Keyring: "no-such.keyring"}, codes.NotFound},
+		}
+		for _, p := range params {
+			_, err := mapCeph("volume-0", p.params)
+			Expect(status.Code(err)).To(Equal(p.code), "%+v", p.params)
+		}
+
+		By("rejecting Ceph options which override credentials or access files")
+		for _, key := range []string{"mon_host", "mon host", "key", "keyring", "keyfile", "Log-File", "admin_socket", "ms_type", "rbd_persistent_cache_path"} {
+			_, err := mapCeph("volume-0", &oim.CephParams{Pool: "rbd", Image: "image-0", Config: map[string]string{key: "/tmp/x"}})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument), "config option %q", key)
+		}
+	})
+})
@@ -0,0 +1,271 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spdk"
+)
+
+// saveConfig takes a snapshot of the BDevs and VHost controllers
+// after a successful change, for restoreConfig. BDevs which do not
+// survive an SPDK restart, i.e. Malloc and Null BDevs, and
+// everything built on top of them are left out because replaying
+// them would give guests empty disks. The Ceph and crypto keys are
+// removed, restoreConfig gets them from the recorded mappings.
+//
+// The snapshot is only kept in memory. After a restart of the
+// controller, those mappings and thus the keys are gone and the
+// controller no longer knows which volumes were mapped, so a
+// snapshot on disk could not be replayed.
+//
+// Failures are logged, the previous snapshot is kept in that case.
+func (c *Controller) saveConfig(ctx context.Context) {
+	c.configMutex.Lock()
+	defer c.configMutex.Unlock()
+	config, err := spdk.SaveConfig(ctx, c.SPDK, spdk.SubsystemBDev, spdk.SubsystemVHost)
+	if err != nil {
+		log.FromContext(ctx).Errorw("saving SPDK configuration", "error", err)
+		return
+	}
+	config, err = persistentConfig(config)
+	if err != nil {
+		log.FromContext(ctx).Errorw("saving SPDK configuration", "error", err)
+		return
+	}
+	c.config = config
+}
+
+// restoreConfig replays the last snapshot when SPDK has lost the
+// SCSI targets of all mapped volumes, which is what happens when
+// SPDK gets restarted without a configuration that recreates them.
+// Objects which still exist are left alone.
+func (c *Controller) restoreConfig(ctx context.Context) {
+	c.configMutex.Lock()
+	defer c.configMutex.Unlock()
+	if c.config == nil {
+		return
+	}
+	lost, err := c.lostMappings(ctx)
+	if err != nil {
+		log.FromContext(ctx).Errorw("checking for lost volume mappings", "error", err)
+		return
+	}
+	if !lost {
+		return
+	}
+	log.FromContext(ctx).Infow("SPDK lost all mapped volumes, restoring its configuration")
+	rbdKeys, cryptoKeys := c.mappingKeys()
+	config, err := withKeys(c.config, rbdKeys, cryptoKeys)
+	if err != nil {
+		log.FromContext(ctx).Errorw("restoring SPDK configuration", "error", err)
+		return
+	}
+	if err := spdk.LoadConfig(ctx, c.SPDK, config); err != nil {
+		log.FromContext(ctx).Errorw("restoring SPDK configuration", "error", err)
+		return
+	}
+	log.FromContext(ctx).Infow("restored SPDK configuration")
+}
+
+// lostMappings returns true if there are mapped volumes and none of
+// their BDevs is used by a SCSI target.
+func (c *Controller) lostMappings(ctx context.Context) (bool, error) {
+	c.handoverMutex.Lock()
+	mapped := map[string]bool{}
+	for bdevName := range c.mappedBDevs {
+		mapped[bdevName] = true
+	}
+	c.handoverMutex.Unlock()
+	if len(mapped) == 0 {
+		return false, nil
+	}
+
+	controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+	if err != nil {
+		return false, errors.Wrap(err, "GetVHostControllers")
+	}
+	for _, controller := range controllers {
+		scsi, ok := controller.BackendSpecific["scsi"].(spdk.SCSIControllerSpecific)
+		if !ok {
+			continue
+		}
+		for _, target := range scsi {
+			for _, lun := range target.LUNs {
+				if mapped[lun.BDevName] {
+					return false, nil
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+// volatileMethods create BDevs whose data is lost when SPDK stops.
+var volatileMethods = map[string]bool{
+	spdk.CurrentMethodName("construct_malloc_bdev"): true,
+	"bdev_null_create": true,
+}
+
+// configCallParams are the parameters of config calls which name
+// BDevs.
+type configCallParams struct {
+	Name         string   `json:"name"`
+	BaseBDevName string   `json:"base_bdev_name"`
+	BaseBDevs    []string `json:"base_bdevs"`
+	BDevName     string   `json:"bdev_name"`
+}
+
+// persistentConfig returns a copy of the configuration without
+// volatile BDevs, the calls which depend on them, and without keys.
+func persistentConfig(config *spdk.Config) (*spdk.Config, error) {
+	dropped := map[string]bool{}
+	return mapConfig(config, func(call spdk.ConfigCall, params configCallParams) (*spdk.ConfigCall, error) {
+		method := spdk.CurrentMethodName(call.Method)
+		volatile := volatileMethods[method] || dropped[params.BaseBDevName] || dropped[params.BDevName]
+		for _, name := range params.BaseBDevs {
+			volatile = volatile || dropped[name]
+		}
+		// QoS limits refer to their BDev by name.
+		if method == spdk.CurrentMethodName("set_bdev_qos_limit") {
+			volatile = volatile || dropped[params.Name]
+		} else if volatile && params.Name != "" {
+			dropped[params.Name] = true
+		}
+		if volatile {
+			return nil, nil
+		}
+		var err error
+		switch method {
+		case spdk.CurrentMethodName("construct_rbd_bdev"):
+			call.Params, err = setRBDKey(call.Params, "")
+		case spdk.CurrentMethodName("construct_crypto_bdev"):
+			call.Params, err = setCryptoKey(call.Params, "")
+		}
+		return &call, err
+	})
+}
+
+// withKeys returns a copy of a configuration from persistentConfig
+// with the Ceph and crypto keys filled in again, by BDev name.
+func withKeys(config *spdk.Config, rbdKeys, cryptoKeys map[string]string) (*spdk.Config, error) {
+	return mapConfig(config, func(call spdk.ConfigCall, params configCallParams) (*spdk.ConfigCall, error) {
+		var err error
+		switch spdk.CurrentMethodName(call.Method) {
+		case spdk.CurrentMethodName("construct_rbd_bdev"):
+			call.Params, err = setRBDKey(call.Params, rbdKeys[params.Name])
+		case spdk.CurrentMethodName("construct_crypto_bdev"):
+			call.Params, err = setCryptoKey(call.Params, cryptoKeys[params.Name])
+		}
+		return &call, err
+	})
+}
+
+// mapConfig returns a copy of the configuration with each call
+// replaced by the result of the callback, which returns nil to
+// drop the call.
+func mapConfig(config *spdk.Config, callback func(call spdk.ConfigCall, params configCallParams) (*spdk.ConfigCall, error)) (*spdk.Config, error) {
+	result := &spdk.Config{Subsystems: []spdk.SubsystemConfig{}}
+	for _, subsystem := range config.Subsystems {
+		calls := []spdk.ConfigCall{}
+		for _, call := range subsystem.Config {
+			var params configCallParams
+			if len(call.Params) > 0 {
+				if err := json.Unmarshal(call.Params, &params); err != nil {
+					return nil, errors.Wrapf(err, "decoding parameters of %s", call.Method)
+				}
+			}
+			mapped, err := callback(call, params)
+			if err != nil {
+				return nil, errors.Wrap(err, call.Method)
+			}
+			if mapped != nil {
+				calls = append(calls, *mapped)
+			}
+		}
+		result.Subsystems = append(result.Subsystems, spdk.SubsystemConfig{
+			Subsystem: subsystem.Subsystem,
+			Config:    calls,
+		})
+	}
+	return result, nil
+}
+
+// setRBDKey sets the Ceph key in the librados config of
+// construct_rbd_bdev parameters. An empty key removes it, for
+// example when the volume uses a keyring instead.
+func setRBDKey(params json.RawMessage, key string) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return nil, err
+	}
+	config := map[string]string{}
+	if len(fields["config"]) > 0 {
+		if err := json.Unmarshal(fields["config"], &config); err != nil {
+			return nil, err
+		}
+	}
+	if key == "" {
+		delete(config, "key")
+	} else {
+		config["key"] = key
+	}
+	if len(config) == 0 {
+		delete(fields, "config")
+	} else {
+		value, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		fields["config"] = value
+	}
+	return json.Marshal(fields)
+}
+
+// setCryptoKey replaces the key in construct_crypto_bdev
+// parameters. SPDK rejects an empty key, so the call fails instead
+// of creating a BDev with the wrong key when the key is unknown.
+func setCryptoKey(params json.RawMessage, key string) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	fields["key"] = value
+	return json.Marshal(fields)
+}
+
+// mappingKeys returns the Ceph keys of the mapped RBD BDevs and the
+// keys of the mapped crypto BDevs, by BDev name.
+func (c *Controller) mappingKeys() (rbdKeys, cryptoKeys map[string]string) {
+	c.handoverMutex.Lock()
+	defer c.handoverMutex.Unlock()
+	rbdKeys = map[string]string{}
+	cryptoKeys = map[string]string{}
+	for volumeID, request := range c.mappings {
+		if secret := request.GetCeph().GetSecret(); secret != "" {
+			rbdKeys[volumeID] = secret
+		}
+		for _, member := range request.GetRaid().GetMembers() {
+			if secret := member.GetCeph().GetSecret(); secret != "" {
+				rbdKeys[member.GetVolumeId()] = secret
+			}
+		}
+		if key := request.GetCrypto().GetKey(); key != "" {
+			cryptoKeys[cryptoBDevName(volumeID)] = key
+		}
+	}
+	return rbdKeys, cryptoKeys
+}
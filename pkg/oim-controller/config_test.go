@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SPDK configuration", func() {
+	sim := simulate(nil)
+
+	mapCeph := func(volumeID string, crypto *oim.CryptoParams) {
+		_, err := sim.c.MapVolume(context.Background(), &oim.MapVolumeRequest{
+			VolumeId: volumeID,
+			Params: &oim.MapVolumeRequest_Ceph{
+				Ceph: &oim.CephParams{
+					UserId:   "kubernetes",
+					Secret:   "my-secret",
+					Monitors: "192.168.7.2:6789",
+					Pool:     "rbd",
+					Image:    volumeID,
+				},
+			},
+			Crypto: crypto,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should restore mapped volumes after an SPDK restart", func() {
+		mapCeph("volume-0", &oim.CryptoParams{Key: "0123456789abcdef"})
+		mapCeph("volume-1", nil)
+		mapCeph("volume-2", nil)
+		_, err := sim.c.UnmapVolume(context.Background(), &oim.UnmapVolumeRequest{VolumeId: "volume-2"})
+		Expect(err).NotTo(HaveOccurred())
+		bdevs := sim.simulated.BDevNames()
+		targets := sim.simulated.Targets(simulatedVHost)
+		rbd := sim.simulated.RBDArgs("volume-0")
+		Expect(targets).To(HaveLen(2))
+
+		By("mapping a Malloc BDev, which must not come back empty")
+		sim.simulated.AddMallocBDev("malloc-0")
+		_, err = sim.c.MapVolume(context.Background(), &oim.MapVolumeRequest{
+			VolumeId: "malloc-0",
+			Params: &oim.MapVolumeRequest_Malloc{
+				Malloc: &oim.MallocParams{},
+			},
+			Crypto: &oim.CryptoParams{Key: "fedcba9876543210"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("restarting SPDK")
+		sim.simulated.Close()
+		sim.startSPDK()
+
+		By("waiting for the controller to restore the volumes")
+		Eventually(func() map[uint32]string {
+			return sim.simulated.Targets(simulatedVHost)
+		}, 10*time.Second).Should(Equal(targets))
+		Expect(sim.simulated.BDevNames()).To(Equal(bdevs))
+		Expect(sim.simulated.RBDArgs("volume-0")).To(Equal(rbd))
+		Expect(sim.simulated.BDev("crypto-volume-0").DriverSpecific.Crypto.BaseBDevName).To(Equal("volume-0"))
+		Expect(sim.simulated.BDevNames()).NotTo(ContainElement("malloc-0"))
+		Expect(sim.simulated.BDevNames()).NotTo(ContainElement("crypto-malloc-0"))
+	})
+
+	It("should leave SPDK alone when only the connection was lost", func() {
+		mapCeph("volume-0", nil)
+
+		By("dropping the connection once")
+		var (
+			mutex   sync.Mutex
+			dropped bool
+			methods []string
+		)
+		sim.simulated.SetHook(func(method string, params json.RawMessage) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !dropped {
+				dropped = true
+				return errors.New("drop connection")
+			}
+			methods = append(methods, method)
+			return nil
+		})
+		_, err := sim.c.ListVolumes(context.Background(), &oim.ListVolumesRequest{})
+		Expect(err).To(HaveOccurred())
+
+		By("waiting for the controller to reconnect")
+		Eventually(func() []string {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return append([]string(nil), methods...)
+		}, 10*time.Second).Should(ContainElement("get_bdevs"), "reconciliation after reconnect")
+		mutex.Lock()
+		defer mutex.Unlock()
+		Expect(methods).NotTo(ContainElement("construct_rbd_bdev"))
+		Expect(methods).NotTo(ContainElement("add_vhost_scsi_lun"))
+	})
+})
@@ -8,14 +8,19 @@ package oimcontroller
 
 import (
 	"context"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
 	"github.com/intel/oim/pkg/log"
@@ -34,21 +39,59 @@ type Controller struct {
 	controllerAddr  string
 	spdkPath        string
 	SPDK            *spdk.Client
+	ownSPDK         bool
 	vhostSCSI       string
 	vhostDev        *oim.PCIAddress
+	extraVHosts     []vhostController
+	maxSCSITargets  uint32
+	targetMutex     sync.Mutex
+	cephKeyringDir  string
+
+	reconcileInterval    time.Duration
+	orphanGracePeriod    time.Duration
+	deleteOrphans        bool
+	orphansMutex         sync.Mutex
+	orphans              map[string]*oim.Orphan
+	notificationInterval time.Duration
+	quiescePeriod        time.Duration
 
-	wg   sync.WaitGroup
-	stop chan<- interface{}
-}
-
-var (
 	// Volume IDs and BDev names are the keys.
 	//
 	// It's okay to get hash collisions when volume ID and BDev name
 	// are the same, then one goroutine will just block unnecessarily;
 	// should be rare.
-	volumeMutex = keymutex.NewHashed(-1)
-)
+	volumeMutex keymutex.KeyMutex
+
+	registrationMutex sync.Mutex
+	registration      RegistrationStatus
+
+	operationsMutex   sync.Mutex
+	operationEpoch    int64
+	operationCounter  uint64
+	operations        map[string]*operation
+	pendingOperations map[string]*operation
+
+	// SPDK does not record when a snapshot was created. The
+	// controller remembers that, indexed by BDev UUID. Snapshots
+	// created by someone else or before a restart get the time
+	// when they were seen for the first time.
+	snapshotTimesMutex sync.Mutex
+	snapshotTimes      map[string]int64
+
+	// config is the last snapshot of the SPDK configuration.
+	configMutex sync.Mutex
+	config      *spdk.Config
+
+	handoverMutex sync.Mutex
+	mappings      map[string]*oim.MapVolumeRequest
+	// mappedBDevs maps the names of the BDevs behind mapped
+	// volumes to the volume ID.
+	mappedBDevs map[string]string
+	quiesced    map[string]bool
+
+	wg   sync.WaitGroup
+	stop chan<- interface{}
+}
 
 // MapVolume ensures that there is a BDev for the volume and makes it
 // available as block device.
@@ -57,24 +100,93 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 	if volumeID == "" {
 		return nil, errors.New("empty volume ID")
 	}
+	// A retry may differ in whether it waits.
+	request := proto.Clone(in).(*oim.MapVolumeRequest)
+	request.Async = false
+	op, err := c.startOperation(ctx, "MapVolume", volumeID, request, func(ctx context.Context) (interface{}, error) {
+		return c.mapVolume(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if in.GetAsync() {
+		return &oim.MapVolumeReply{OperationId: op.id}, nil
+	}
+	if err := op.wait(ctx); err != nil {
+		return nil, err
+	}
+	if op.err != nil {
+		return nil, op.err
+	}
+	return op.result.(*oim.MapVolumeReply), nil
+}
+
+func (c *Controller) mapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*oim.MapVolumeReply, error) {
+	// Serialize by volume.
+	c.volumeMutex.LockKey(in.GetVolumeId())
+	defer c.volumeMutex.UnlockKey(in.GetVolumeId())
+	return c.mapLockedVolume(ctx, in)
+}
+
+// mapLockedVolume must be called while holding the volume mutex.
+func (c *Controller) mapLockedVolume(ctx context.Context, in *oim.MapVolumeRequest) (reply *oim.MapVolumeReply, finalErr error) {
+	volumeID := in.GetVolumeId()
 	if c.SPDK == nil {
 		return nil, errors.New("not connected to SPDK")
 	}
-	if c.vhostSCSI == "" {
+	vhosts := c.vhostControllers()
+	if len(vhosts) == 0 {
 		return nil, errors.New("no VHost SCSI controller configured")
 	}
-	if c.vhostDev == nil {
-		return nil, errors.New("no PCI BDF configured")
+	for _, vhost := range vhosts {
+		if vhost.dev == nil {
+			return nil, errors.Errorf("no PCI BDF configured for VHost SCSI controller %s", vhost.name)
+		}
 	}
 
-	// Serialize by volume.
-	volumeMutex.LockKey(volumeID)
-	defer volumeMutex.UnlockKey(volumeID)
+	// A volume which is being handed over must not come back.
+	if c.isQuiesced(volumeID) {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %s is quiesced for handover", volumeID)
+	}
+	var bdevNames []string
+	defer func() {
+		if finalErr == nil {
+			c.recordMapping(in, bdevNames...)
+			c.saveConfig(ctx)
+		}
+	}()
 
-	// Reuse or create BDev.
-	if _, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: volumeID}); err != nil {
-		// TODO: check error more carefully instead of assuming that it merely
-		// wasn't found.
+	// Fence off other controllers before touching the volume.
+	acquired, err := c.acquireLease(ctx, volumeID, in.GetReader(), in.GetForce())
+	if err != nil {
+		return nil, err
+	}
+	if acquired {
+		defer func() {
+			if finalErr != nil {
+				if err := c.releaseLease(ctx, volumeID); err != nil {
+					log.FromContext(ctx).Errorw("release lease after failed MapVolume", "volume", volumeID, "error", err)
+				}
+			}
+		}()
+	}
+
+	// Reuse or create BDev. Logical volumes are known to SPDK
+	// under a UUID, everything else under the volume ID.
+	bdevName := volumeID
+	if lvol, ok := in.Params.(*oim.MapVolumeRequest_Lvol); ok {
+		bdev, err := c.getLVol(ctx, lvol.Lvol.GetLvolStore(), volumeID)
+		if err != nil {
+			return nil, err
+		}
+		if bdev == nil {
+			return nil, errors.Errorf("no existing logical volume %s found", lvolAlias(lvol.Lvol.GetLvolStore(), volumeID))
+		}
+		bdevName = bdev.Name
+	} else if _, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: volumeID}); err != nil {
+		if !spdk.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "GetBDevs %s", volumeID)
+		}
 		switch x := in.Params.(type) {
 		case *oim.MapVolumeRequest_Malloc:
 			return nil, errors.Errorf("no existing MallocBDev with name %s found", volumeID)
@@ -82,6 +194,10 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 			if err := c.mapCeph(ctx, volumeID, x.Ceph); err != nil {
 				return nil, err
 			}
+		case *oim.MapVolumeRequest_Raid:
+			if err := c.mapRAID(ctx, volumeID, x.Raid, in.GetReader(), in.GetForce()); err != nil {
+				return nil, err
+			}
 		case nil:
 			return nil, errors.New("missing volume parameters")
 		default:
@@ -91,8 +207,29 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 		// BDev with the intended name already exists. Assume that it is the right one.
 		log.FromContext(ctx).Infof("reusing existing BDev %s", volumeID)
 	}
+	bdevNames = append(bdevNames, bdevName)
 
-	var err error
+	// An encrypted volume is exposed through the crypto BDev.
+	if crypto := in.GetCrypto(); crypto != nil {
+		name, err := c.mapCrypto(ctx, volumeID, bdevName, crypto)
+		if err != nil {
+			return nil, err
+		}
+		bdevName = name
+		bdevNames = append(bdevNames, bdevName)
+	}
+
+	// Limits are set each time, so they are also right when
+	// a previous call failed after creating the BDev.
+	if qos := in.GetQos(); qos != nil {
+		if err := c.setQoS(ctx, bdevName, qos); err != nil {
+			return nil, err
+		}
+	}
+
+	// Allocating a target must not race with other MapVolume calls.
+	c.targetMutex.Lock()
+	defer c.targetMutex.Unlock()
 
 	// If this BDev is active as LUN, do nothing because a previous MapVolume
 	// call must have succeeded (idempotency!).
@@ -100,17 +237,26 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 	if err != nil {
 		return nil, errors.Wrap(err, "GetVHostControllers")
 	}
+	used := map[string]map[uint32]bool{}
+	for _, vhost := range vhosts {
+		used[vhost.name] = map[uint32]bool{}
+	}
 	for _, controller := range controllers {
+		vhost, ours := c.vhostFor(controller.Controller)
+		if !ours {
+			continue
+		}
 		for key, value := range controller.BackendSpecific {
 			switch key {
 			case "scsi":
 				if scsi, ok := value.(spdk.SCSIControllerSpecific); ok {
 					for _, target := range scsi {
+						used[vhost.name][target.SCSIDevNum] = true
 						for _, lun := range target.LUNs {
-							if lun.BDevName == volumeID {
+							if lun.BDevName == bdevName {
 								// BDev already active.
 								return &oim.MapVolumeReply{
-									PciAddress: c.vhostDev,
+									PciAddress: vhost.dev,
 									ScsiDisk: &oim.SCSIDisk{
 										Target: target.SCSIDevNum,
 										Lun:    0,
@@ -124,50 +270,129 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 		}
 	}
 
-	// Create a new SCSI target with a LUN connected to this BDev. We iterate over all available
-	// targets and attempt to use them.
-	// TODO: we don't know the SPDK limit for targets. 8 is just the default.
+	// Place the volume on the least loaded controller. Ties are
+	// resolved in the order in which controllers were configured.
+	sort.SliceStable(vhosts, func(i, j int) bool {
+		return len(used[vhosts[i].name]) < len(used[vhosts[j].name])
+	})
+
+	// Create a new SCSI target with a LUN connected to this BDev
+	// in the first free target. Trying the next one is only
+	// necessary when someone else modified SPDK in the meantime.
 	// TODO: let vhost pick an unused one (https://github.com/spdk/spdk/issues/328)
-	for target := uint32(0); target < 8; target++ {
-		args := spdk.AddVHostSCSILUNArgs{
-			Controller:    c.vhostSCSI,
-			SCSITargetNum: target,
-			BDevName:      volumeID,
-		}
-		err = spdk.AddVHostSCSILUN(ctx, c.SPDK, args)
-		if err == nil {
-			// Success!
-			return &oim.MapVolumeReply{
-				PciAddress: c.vhostDev,
-				ScsiDisk: &oim.SCSIDisk{
-					Target: target,
-					Lun:    0,
-				},
-			}, nil
+	err = nil
+	for _, vhost := range vhosts {
+		for target := uint32(0); target < c.maxSCSITargets; target++ {
+			if used[vhost.name][target] {
+				continue
+			}
+			args := spdk.AddVHostSCSILUNArgs{
+				Controller:    vhost.name,
+				SCSITargetNum: target,
+				BDevName:      bdevName,
+			}
+			err = spdk.AddVHostSCSILUN(ctx, c.SPDK, args)
+			if err == nil {
+				// Success!
+				return &oim.MapVolumeReply{
+					PciAddress: vhost.dev,
+					ScsiDisk: &oim.SCSIDisk{
+						Target: target,
+						Lun:    0,
+					},
+				}, nil
+			}
 		}
 	}
 
 	// TODO: document that the BDev is not going to get deleted.
 	// To remove it, UnmapVolume must be called.
 
-	// Return the last SPDK error.
-	errorResult := errors.Wrap(err, "AddVHostSCSILUN failed for all LUNs, last error")
+	if err == nil {
+		return nil, status.Errorf(codes.ResourceExhausted, "all %d SCSI targets of %d VHost controller(s) are in use", c.maxSCSITargets, len(vhosts))
+	}
+	// Return the last SPDK error. SPDK rejects target numbers
+	// beyond its own limit, which happens when configured with
+	// too many targets.
+	errorResult := errors.Wrapf(err, "AddVHostSCSILUN failed for all free targets, check that SPDK supports %d targets, last error", c.maxSCSITargets)
 	return nil, errorResult
 }
 
-// UnmapVolume removes the block device for a BDev and (if not a local Malloc BDev) the BDev itself.
+// vhostController is a VHost SCSI controller in SPDK together with
+// the PCI address under which it is visible.
+type vhostController struct {
+	name string
+	dev  *oim.PCIAddress
+}
+
+// vhostControllers returns a new slice with all configured VHost
+// SCSI controllers.
+func (c *Controller) vhostControllers() []vhostController {
+	var vhosts []vhostController
+	if c.vhostSCSI != "" {
+		vhosts = append(vhosts, vhostController{c.vhostSCSI, c.vhostDev})
+	}
+	return append(vhosts, c.extraVHosts...)
+}
+
+// vhostFor finds the configured VHost SCSI controller for a
+// controller name reported by SPDK. A controller may have been
+// configured with the path of its socket, which SPDK accepts in
+// place of the name.
+func (c *Controller) vhostFor(name string) (vhostController, bool) {
+	for _, vhost := range c.vhostControllers() {
+		if vhost.name == name || filepath.Base(vhost.name) == name {
+			return vhost, true
+		}
+	}
+	return vhostController{}, false
+}
+
+// UnmapVolume removes the block device for a BDev, the crypto BDev (if any) and (if not a local Malloc BDev) the BDev itself.
 func (c *Controller) UnmapVolume(ctx context.Context, in *oim.UnmapVolumeRequest) (*oim.UnmapVolumeReply, error) {
 	volumeID := in.GetVolumeId()
 	if volumeID == "" {
 		return nil, errors.New("empty volume ID")
 	}
+	// A retry may differ in whether it waits.
+	request := proto.Clone(in).(*oim.UnmapVolumeRequest)
+	request.Async = false
+	op, err := c.startOperation(ctx, "UnmapVolume", volumeID, request, func(ctx context.Context) (interface{}, error) {
+		return c.unmapVolume(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if in.GetAsync() {
+		return &oim.UnmapVolumeReply{OperationId: op.id}, nil
+	}
+	if err := op.wait(ctx); err != nil {
+		return nil, err
+	}
+	if op.err != nil {
+		return nil, op.err
+	}
+	return op.result.(*oim.UnmapVolumeReply), nil
+}
+
+func (c *Controller) unmapVolume(ctx context.Context, in *oim.UnmapVolumeRequest) (*oim.UnmapVolumeReply, error) {
+	// Serialize by volume.
+	c.volumeMutex.LockKey(in.GetVolumeId())
+	defer c.volumeMutex.UnlockKey(in.GetVolumeId())
+	return c.unmapLockedVolume(ctx, in)
+}
+
+// unmapLockedVolume must be called while holding the volume mutex.
+func (c *Controller) unmapLockedVolume(ctx context.Context, in *oim.UnmapVolumeRequest) (*oim.UnmapVolumeReply, error) {
+	volumeID := in.GetVolumeId()
 	if c.SPDK == nil {
 		return nil, errors.New("not connected to SPDK")
 	}
 
-	// Serialize by volume.
-	volumeMutex.LockKey(volumeID)
-	defer volumeMutex.UnlockKey(volumeID)
+	bdevNames, err := c.volumeBDevNames(ctx, volumeID)
+	if err != nil {
+		return nil, err
+	}
 
 	controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
 	if err != nil {
@@ -182,7 +407,7 @@ func (c *Controller) UnmapVolume(ctx context.Context, in *oim.UnmapVolumeRequest
 				if scsi, ok := value.(spdk.SCSIControllerSpecific); ok {
 					for _, target := range scsi {
 						for _, lun := range target.LUNs {
-							if lun.BDevName == volumeID {
+							if bdevNames[lun.BDevName] {
 								// Found the right SCSI target.
 								removeArgs := spdk.RemoveVHostSCSITargetArgs{
 									Controller:    controller.Controller,
@@ -199,18 +424,119 @@ func (c *Controller) UnmapVolume(ctx context.Context, in *oim.UnmapVolumeRequest
 		}
 	}
 
+	// The crypto BDev must be removed before the BDev below it.
+	if err := c.unmapCrypto(ctx, volumeID); err != nil {
+		return nil, err
+	}
+
 	// Don't fail when the BDev is not found (idempotency).
-	// Check whether this is really a BDev created by MapVolume (i.e. everything except MallocBDevs).
-	// TODO: detect "not found" errors (https://github.com/spdk/spdk/issues/319)
-	if bdev, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: volumeID}); err == nil && len(bdev) > 0 && bdev[0].ProductName != "Malloc disk" {
-		if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: volumeID}); err != nil {
-			// TODO: detect "not found" error (https://github.com/spdk/spdk/issues/319)
+	// Check whether this is really a BDev created by MapVolume (i.e. everything except MallocBDevs
+	// and logical volumes).
+	bdev, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: volumeID})
+	if err != nil && !spdk.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "GetBDevs %s", volumeID)
+	}
+	if err == nil && len(bdev) > 0 &&
+		bdev[0].ProductName != "Malloc disk" && bdev[0].DriverSpecific.LVol == nil {
+		if raid := bdev[0].DriverSpecific.RAID; raid != nil {
+			// Also removes the member BDevs.
+			if err := c.unmapRAID(ctx, volumeID, raid); err != nil {
+				return nil, err
+			}
+		} else if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: volumeID}); err != nil && !spdk.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "DeleteBDev %s", volumeID)
 		}
 	}
+	// Only after the volume is really gone may another controller
+	// use it.
+	if err := c.releaseLease(ctx, volumeID); err != nil {
+		return nil, err
+	}
+	c.forgetMapping(volumeID)
+	c.saveConfig(ctx)
 
 	return &oim.UnmapVolumeReply{}, nil
 }
 
+// volumeBDevNames returns the names of all BDevs which might be
+// used for the volume: the volume ID itself, its crypto BDev and
+// logical volumes with that name in any lvol store.
+func (c *Controller) volumeBDevNames(ctx context.Context, volumeID string) (map[string]bool, error) {
+	bdevNames := map[string]bool{volumeID: true, cryptoBDevName(volumeID): true}
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetBDevs")
+	}
+	for _, bdev := range bdevs {
+		for _, alias := range bdev.Aliases {
+			if strings.HasSuffix(alias, "/"+volumeID) {
+				bdevNames[bdev.Name] = true
+			}
+		}
+	}
+	return bdevNames, nil
+}
+
+// SetVolumeQoS changes the rate limits of the BDev that was mapped
+// for the volume.
+func (c *Controller) SetVolumeQoS(ctx context.Context, in *oim.SetVolumeQoSRequest) (*oim.SetVolumeQoSReply, error) {
+	volumeID := in.GetVolumeId()
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
+	}
+	if in.GetQos() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing QoS limits")
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+
+	// Serialize by volume.
+	c.volumeMutex.LockKey(volumeID)
+	defer c.volumeMutex.UnlockKey(volumeID)
+
+	bdevNames, err := c.volumeBDevNames(ctx, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetVHostControllers")
+	}
+	for _, controller := range controllers {
+		if scsi, ok := controller.BackendSpecific["scsi"].(spdk.SCSIControllerSpecific); ok {
+			for _, target := range scsi {
+				for _, lun := range target.LUNs {
+					if bdevNames[lun.BDevName] {
+						if err := c.setQoS(ctx, lun.BDevName, in.GetQos()); err != nil {
+							return nil, err
+						}
+						c.saveConfig(ctx)
+						return &oim.SetVolumeQoSReply{}, nil
+					}
+				}
+			}
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "volume %s not mapped", volumeID)
+}
+
+func (c *Controller) setQoS(ctx context.Context, bdevName string, qos *oim.QoS) error {
+	args := spdk.SetBDevQoSLimitArgs{
+		Name: bdevName,
+		QoSLimits: spdk.QoSLimits{
+			RWIOsPerSec:    qos.GetRwIosPerSec(),
+			RWMBytesPerSec: qos.GetRwMbytesPerSec(),
+			RMBytesPerSec:  qos.GetRMbytesPerSec(),
+			WMBytesPerSec:  qos.GetWMbytesPerSec(),
+		},
+	}
+	if err := spdk.SetBDevQoSLimit(ctx, c.SPDK, args); err != nil {
+		return errors.Wrapf(err, "SetBDevQoSLimit %+v", args)
+	}
+	return nil
+}
+
 // ProvisionMallocBDev creates a new local Malloc BDev.
 func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionMallocBDevRequest) (*oim.ProvisionMallocBDevReply, error) {
 	bdevName := in.GetBdevName()
@@ -222,12 +548,15 @@ func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionM
 	}
 
 	// Serialize by BDev.
-	volumeMutex.LockKey(bdevName)
-	defer volumeMutex.UnlockKey(bdevName)
+	c.volumeMutex.LockKey(bdevName)
+	defer c.volumeMutex.UnlockKey(bdevName)
 
 	size := in.Size_
 	if size != 0 {
 		bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: bdevName})
+		if err != nil && !spdk.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "GetBDevs %s", bdevName)
+		}
 		if err != nil || len(bdevs) != 1 {
 			args := spdk.ConstructMallocBDevArgs{
 				ConstructBDevArgs: spdk.ConstructBDevArgs{
@@ -236,8 +565,10 @@ func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionM
 					Name:      bdevName,
 				},
 			}
-			// TODO: detect already existing BDev of the same name (https://github.com/spdk/spdk/issues/319)
 			if _, err := spdk.ConstructMallocBDev(ctx, c.SPDK, args); err != nil {
+				if spdk.IsAlreadyExists(err) {
+					return nil, status.Errorf(codes.AlreadyExists, "BDev %s exists already", bdevName)
+				}
 				return nil, errors.Wrap(err, "ConstructMallocBDev")
 			}
 		} else {
@@ -248,10 +579,11 @@ func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionM
 			}
 		}
 	} else {
-		if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: bdevName}); err != nil {
-			// TODO: detect error (https://github.com/spdk/spdk/issues/319)
+		if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: bdevName}); err != nil && !spdk.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "DeleteBDev %s", bdevName)
 		}
 	}
+	c.saveConfig(ctx)
 	return &oim.ProvisionMallocBDevReply{}, nil
 }
 
@@ -266,34 +598,17 @@ func (c *Controller) CheckMallocBDev(ctx context.Context, in *oim.CheckMallocBDe
 	}
 
 	// Serialize by BDev.
-	volumeMutex.LockKey(bdevName)
-	defer volumeMutex.UnlockKey(bdevName)
+	c.volumeMutex.LockKey(bdevName)
+	defer c.volumeMutex.UnlockKey(bdevName)
 
 	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: bdevName})
 	if err == nil && len(bdevs) == 1 {
 		return &oim.CheckMallocBDevReply{}, nil
 	}
-	// TODO: detect "not found" error (https://github.com/spdk/spdk/issues/319)
-	return nil, status.Error(codes.NotFound, "")
-}
-
-func (c *Controller) mapCeph(ctx context.Context, volumeID string, cephParams *oim.CephParams) error {
-	if c.SPDK == nil {
-		return errors.New("not connected to SPDK")
-	}
-	request := spdk.ConstructRBDBDevArgs{
-		BlockSize: 512,
-		Name:      volumeID,
-		UserID:    cephParams.UserId,
-		PoolName:  cephParams.Pool,
-		RBDName:   cephParams.Image,
-		Config: map[string]string{
-			"mon_host": cephParams.Monitors,
-			"key":      cephParams.Secret,
-		},
+	if err != nil && !spdk.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "GetBDevs %s", bdevName)
 	}
-	_, err := spdk.ConstructRBDBDev(ctx, c.SPDK, request)
-	return errors.Wrapf(err, "ConstructRBDBDev %q for RBD pool %q and image %q, monitors %q", volumeID, cephParams.Pool, cephParams.Image, cephParams.Monitors)
+	return nil, status.Error(codes.NotFound, "")
 }
 
 // Option is what New accepts to reconfigure the resulting controller.
@@ -308,7 +623,9 @@ func WithRegistry(address string) Option {
 	}
 }
 
-// WithRegistryDelay sets the interval between self-registration calls.
+// WithRegistryDelay sets the interval between self-registration
+// calls. It is also the upper limit for the delay between retries
+// after a failed registration.
 func WithRegistryDelay(delay time.Duration) Option {
 	return func(c *Controller) error {
 		c.registryDelay = delay
@@ -353,8 +670,23 @@ func WithSPDK(path string) Option {
 	}
 }
 
+// WithSPDKClient uses an existing connection to SPDK instead of
+// connecting to the path given with WithSPDK. The client remains
+// owned by the caller and does not get closed by Close. Such a
+// controller is meant to be used in-process, for example by the OIM
+// CSI driver, and then needs no transport credentials unless it
+// registers with the OIM Registry.
+func WithSPDKClient(client *spdk.Client) Option {
+	return func(c *Controller) error {
+		c.SPDK = client
+		return nil
+	}
+}
+
 // WithVHostController sets the name of the existing SCSI device to
-// which BDevs are to be attached.
+// which BDevs are to be attached. Together with WithVHostDev it
+// configures the first controller, WithAdditionalVHostController adds
+// more.
 func WithVHostController(vhost string) Option {
 	return func(c *Controller) error {
 		c.vhostSCSI = vhost
@@ -362,6 +694,21 @@ func WithVHostController(vhost string) Option {
 	}
 }
 
+// WithMaxSCSITargets sets the number of SCSI targets that each VHost
+// SCSI controller supports. SPDK does not report that number, so it
+// has to be configured to match SPDK_VHOST_SCSI_CTRLR_MAX_DEVS
+// when SPDK was built with a different value. By default (and with
+// zero) the limit of an unmodified SPDK is assumed.
+func WithMaxSCSITargets(max uint32) Option {
+	return func(c *Controller) error {
+		if max == 0 {
+			max = spdk.VHostSCSIMaxTargets
+		}
+		c.maxSCSITargets = max
+		return nil
+	}
+}
+
 // WithVHostDev sets the PCI address of the SCSI device. It takes a
 // PCI Bus/Device/Function string.
 func WithVHostDev(dev string) Option {
@@ -375,11 +722,96 @@ func WithVHostDev(dev string) Option {
 	}
 }
 
+// WithReconcileInterval sets how often the controller looks for
+// orphaned BDevs and SCSI targets. Zero disables the periodic check,
+// the one in Start always runs.
+func WithReconcileInterval(interval time.Duration) Option {
+	return func(c *Controller) error {
+		c.reconcileInterval = interval
+		return nil
+	}
+}
+
+// WithOrphanGracePeriod sets how long something must have been an
+// orphan before the periodic check removes it.
+func WithOrphanGracePeriod(gracePeriod time.Duration) Option {
+	return func(c *Controller) error {
+		c.orphanGracePeriod = gracePeriod
+		return nil
+	}
+}
+
+// WithNotificationInterval sets how often the controller asks SPDK
+// for BDevs that were deleted, to detect mapped volumes that lost
+// their BDev without waiting for the next periodic check. Zero
+// disables it, as does an SPDK without notification support.
+func WithNotificationInterval(interval time.Duration) Option {
+	return func(c *Controller) error {
+		c.notificationInterval = interval
+		return nil
+	}
+}
+
+// WithQuiescePeriod sets for how long QuiesceVolume checks that a
+// volume has no I/O before removing it. Zero disables the check.
+func WithQuiescePeriod(period time.Duration) Option {
+	return func(c *Controller) error {
+		c.quiescePeriod = period
+		return nil
+	}
+}
+
+// WithDeleteOrphans enables the automatic removal of orphans. Without
+// it, orphans are only logged and can be removed with CollectGarbage.
+func WithDeleteOrphans(enabled bool) Option {
+	return func(c *Controller) error {
+		c.deleteOrphans = enabled
+		return nil
+	}
+}
+
+// WithCephKeyringDir sets the directory with Ceph keyring files
+// that CephParams may refer to by name. Empty disables such
+// references.
+func WithCephKeyringDir(dir string) Option {
+	return func(c *Controller) error {
+		c.cephKeyringDir = dir
+		return nil
+	}
+}
+
+// WithAdditionalVHostController adds another existing SCSI device
+// with its PCI address in BDF notation. MapVolume attaches BDevs to
+// the controller which has the fewest SCSI targets in use.
+func WithAdditionalVHostController(vhost, dev string) Option {
+	return func(c *Controller) error {
+		d, err := oimcommon.ParseBDFString(dev)
+		if err != nil {
+			return err
+		}
+		c.extraVHosts = append(c.extraVHosts, vhostController{vhost, d})
+		return nil
+	}
+}
+
 // New constructs a new OIM controller instance.
 func New(options ...Option) (*Controller, error) {
 	c := Controller{
-		controllerID:  "unset-controller-id",
-		registryDelay: time.Minute,
+		controllerID:         "unset-controller-id",
+		registryDelay:        time.Minute,
+		reconcileInterval:    5 * time.Minute,
+		orphanGracePeriod:    10 * time.Minute,
+		notificationInterval: 5 * time.Second,
+		quiescePeriod:        5 * time.Second,
+		maxSCSITargets:       spdk.VHostSCSIMaxTargets,
+		operationEpoch:       time.Now().UnixNano(),
+		operations:           map[string]*operation{},
+		pendingOperations:    map[string]*operation{},
+		mappings:             map[string]*oim.MapVolumeRequest{},
+		mappedBDevs:          map[string]string{},
+		quiesced:             map[string]bool{},
+		snapshotTimes:        map[string]int64{},
+		volumeMutex:          keymutex.NewHashed(-1),
 	}
 	for _, op := range options {
 		err := op(&c)
@@ -388,93 +820,85 @@ func New(options ...Option) (*Controller, error) {
 		}
 	}
 
-	if c.spdkPath != "" {
-		client, err := spdk.New(c.spdkPath)
+	inProcess := c.SPDK != nil
+	if c.spdkPath != "" && !inProcess {
+		client, err := spdk.New(c.spdkPath, spdk.WithReconnectHandler(c.spdkReconnected))
 		if err != nil {
 			return nil, err
 		}
 		c.SPDK = client
+		c.ownSPDK = true
 	}
 
 	if c.registryAddress != "" && (c.controllerID == "" || c.controllerAddr == "") {
 		return nil, errors.New("need both controller ID and external controller address for registering  with the OIM registry")
 	}
 
-	if c.creds == nil {
+	if c.creds == nil && (!inProcess || c.registryAddress != "") {
 		return nil, errors.New("transport credentials missing")
 	}
 
 	return &c, nil
 }
 
-// Start begins the interaction with the OIM Registry, if one was configured.
+// Start cleans up after a previous instance of the controller, begins
+// the periodic reconciliation with SPDK, the watching of SPDK
+// notifications and the interaction with the OIM Registry, if those
+// were configured.
 func (c *Controller) Start() error {
-	if c.registryAddress == "" {
-		return nil
-	}
-
 	stop := make(chan interface{})
 	c.stop = stop
-	c.wg.Add(1)
-	go func() {
-		defer c.wg.Done()
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		// Register for the first time immediately.
-		again := time.After(0 * time.Second)
-		done := make(chan bool)
-		for {
-			select {
-			case <-stop:
-				return
-			case <-done:
-				// TODO (?): exponential backoff when registry is down
-				again = time.After(c.registryDelay)
-			case <-again:
-				// Run at most one call at a time by re-arming
-				// the time only after we are done.
-				go func() {
-					c.register(ctx)
-					done <- true
-				}()
-			}
+
+	if c.SPDK != nil {
+		// Orphans found now were probably left behind by a
+		// previous instance, but another instance might
+		// still be shutting down or something else might
+		// be using SPDK, so they also only get removed
+		// after the grace period.
+		ctx := context.Background()
+		c.reconcile(ctx, c.orphanGracePeriod)
+		if c.reconcileInterval > 0 {
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+				ticker := time.NewTicker(c.reconcileInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ticker.C:
+						c.reconcile(ctx, c.orphanGracePeriod)
+					}
+				}
+			}()
 		}
-	}()
+		if c.notificationInterval > 0 {
+			c.watchBDevs(stop)
+		}
+	}
 
-	return nil
-}
+	if c.registryAddress != "" {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			c.registrationLoop(stop)
+		}()
+	}
 
-func (c *Controller) register(ctx context.Context) {
-	// Dial anew, because a) when the registry is down
-	// and our address uses Unix domain sockets, dialing
-	// will fail permanently and b) we don't want to keep
-	// a permanent connection from each controller to
-	// the registry.
-	log.L().Infof("Registering OIM controller %s at address %s with OIM registry %s", c.controllerID, c.controllerAddr, c.registryAddress)
-	opts := oimcommon.ChooseDialOpts(c.registryAddress, grpc.WithTransportCredentials(c.creds))
-	conn, err := grpc.DialContext(ctx, c.registryAddress, opts...)
-	if err != nil {
-		log.L().Infow("connecting to OIM registry", "error", err)
-	}
-	defer conn.Close()
-	registry := oim.NewRegistryClient(conn)
-	registry.SetValue(ctx, &oim.SetValueRequest{
-		Value: &oim.Value{
-			Path:  c.controllerID + "/" + oimcommon.RegistryAddress,
-			Value: c.controllerAddr,
-		},
-	})
+	return nil
 }
 
 // Close ends the interaction with the OIM Registry, if one was configured,
-// and frees all resources.
+// and frees all resources. A controller which was registered removes
+// its address from the registry.
 func (c *Controller) Close() {
 	if c.stop != nil {
 		close(c.stop)
 		c.wg.Wait()
+		c.deregister()
 	}
-	if c.SPDK != nil {
+	if c.SPDK != nil && c.ownSPDK {
 		if err := c.SPDK.Close(); err != nil {
 			log.L().Errorw("close SPDK", "error", err)
 		}
@@ -482,8 +906,14 @@ func (c *Controller) Close() {
 }
 
 // Server returns a new gRPC server listening on the given endpoint.
+// Besides the OIM controller service it also provides the gRPC health
+// checking service.
 func (c *Controller) Server(endpoint string) (*oimcommon.NonBlockingGRPCServer, func(*grpc.Server)) {
-	return Server(endpoint, c, c.creds)
+	server, service := Server(endpoint, c, c.creds)
+	return server, func(s *grpc.Server) {
+		service(s)
+		grpc_health_v1.RegisterHealthServer(s, c)
+	}
 }
 
 // Server configures an arbitrary OIM controller implementation as a gRPC server.
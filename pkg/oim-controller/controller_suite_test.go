@@ -7,12 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package oimcontroller_test
 
 import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"google.golang.org/grpc/credentials"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/oim-controller"
+	"github.com/intel/oim/pkg/spdk/spdktest"
 )
 
 func init() {
@@ -23,3 +31,68 @@ func TestOIMController(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "OIM Controller Suite")
 }
+
+// simulatedVHost is the VHost SCSI controller of a simulation, at
+// PCI address 00:15.0.
+const simulatedVHost = "vhost.0"
+
+// simulation is an OIM controller which uses a simulated SPDK.
+type simulation struct {
+	tmpDir    string
+	path      string
+	simulated *spdktest.Server
+	c         *oimcontroller.Controller
+}
+
+// simulate sets up a new simulation before each spec in the current
+// container and removes it afterwards. The options are added to
+// those for the simulatedVHost.
+func simulate(spdkOptions []spdktest.Option, controllerOptions ...oimcontroller.Option) *simulation {
+	s := &simulation{}
+
+	BeforeEach(func() {
+		var err error
+		s.tmpDir, err = ioutil.TempDir("", "oim-controller")
+		Expect(err).NotTo(HaveOccurred())
+		s.path = filepath.Join(s.tmpDir, "spdk.sock")
+		s.startSPDK(spdkOptions...)
+		s.startController(controllerOptions...)
+	})
+
+	AfterEach(func() {
+		if s.c != nil {
+			s.c.Close()
+			s.c = nil
+		}
+		if s.simulated != nil {
+			s.simulated.Close()
+			s.simulated = nil
+		}
+		os.RemoveAll(s.tmpDir)
+	})
+
+	return s
+}
+
+// startSPDK starts a simulated SPDK, for example after closing the
+// previous one.
+func (s *simulation) startSPDK(options ...spdktest.Option) {
+	var err error
+	s.simulated, err = spdktest.New(s.path,
+		append([]spdktest.Option{spdktest.WithVHostSCSIControllers(simulatedVHost)}, options...)...)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+// startController creates an OIM controller, for example after
+// closing the previous one.
+func (s *simulation) startController(options ...oimcontroller.Option) {
+	var err error
+	s.c, err = oimcontroller.New(
+		append([]oimcontroller.Option{
+			oimcontroller.WithSPDK(s.path),
+			oimcontroller.WithCreds(credentials.NewTLS(&tls.Config{})),
+			oimcontroller.WithVHostDev("00:15.0"),
+			oimcontroller.WithVHostController(simulatedVHost),
+		}, options...)...)
+	Expect(err).NotTo(HaveOccurred())
+}
@@ -8,12 +8,19 @@ package oimcontroller_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/log/level"
@@ -21,6 +28,7 @@ import (
 	"github.com/intel/oim/pkg/oim-controller"
 	"github.com/intel/oim/pkg/oim-registry"
 	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spdk/spdktest"
 	"github.com/intel/oim/pkg/spec/oim/v0"
 	"github.com/intel/oim/test/pkg/qemu"
 	testspdk "github.com/intel/oim/test/pkg/spdk"
@@ -146,6 +154,466 @@ var _ = Describe("OIM Controller", func() {
 			db.Store(controllerID+"/"+oimcommon.RegistryAddress, "")
 			Consistently(getDB, 10*time.Second).Should(Equal(map[string]string{}))
 		})
+
+		It("should deregister", func() {
+			addr := "foo://bar"
+			controllerID := "host-0"
+			c, err := oimcontroller.New(
+				oimcontroller.WithRegistry(registryAddress),
+				oimcontroller.WithCreds(controllerCreds),
+				oimcontroller.WithControllerID(controllerID),
+				oimcontroller.WithControllerAddress(addr),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = c.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(getDB, 1*time.Second).Should(Equal(map[string]string{controllerID + "/" + oimcommon.RegistryAddress: addr}))
+			Eventually(func() bool {
+				return c.RegistrationStatus().Registered
+			}).Should(BeTrue())
+			c.Close()
+			Expect(getDB()).To(Equal(map[string]string{}))
+		})
+
+		Context("with PCI address", func() {
+			addr := "foo://bar"
+			controllerID := "host-0"
+			addrKey := controllerID + "/" + oimcommon.RegistryAddress
+			pciKey := controllerID + "/" + oimcommon.RegistryPCI
+
+			start := func() *oimcontroller.Controller {
+				c, err := oimcontroller.New(
+					oimcontroller.WithRegistry(registryAddress),
+					oimcontroller.WithCreds(controllerCreds),
+					oimcontroller.WithControllerID(controllerID),
+					oimcontroller.WithControllerAddress(addr),
+					oimcontroller.WithVHostDev("00:15.0"),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				err = c.Start()
+				Expect(err).NotTo(HaveOccurred())
+				return c
+			}
+
+			It("should publish it", func() {
+				c := start()
+				defer c.Close()
+				Eventually(getDB).Should(Equal(map[string]string{addrKey: addr, pciKey: "00:15.0"}))
+			})
+
+			It("should publish one per VHost controller", func() {
+				c, err := oimcontroller.New(
+					oimcontroller.WithRegistry(registryAddress),
+					oimcontroller.WithCreds(controllerCreds),
+					oimcontroller.WithControllerID(controllerID),
+					oimcontroller.WithControllerAddress(addr),
+					oimcontroller.WithVHostController("vhost.0"),
+					oimcontroller.WithVHostDev("00:15.0"),
+					oimcontroller.WithAdditionalVHostController("/var/tmp/vhost.1", "00:16.0"),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				err = c.Start()
+				Expect(err).NotTo(HaveOccurred())
+				defer c.Close()
+				Eventually(getDB).Should(Equal(map[string]string{
+					addrKey:             addr,
+					pciKey:              "00:15.0",
+					pciKey + "/vhost.1": "00:16.0",
+				}))
+			})
+
+			It("should complete it", func() {
+				db.Store(pciKey, "0001::.")
+				c := start()
+				defer c.Close()
+				Eventually(getDB).Should(Equal(map[string]string{addrKey: addr, pciKey: "0001:00:15.0"}))
+			})
+
+			It("should detect a conflict", func() {
+				db.Store(pciKey, "00:16.0")
+				c := start()
+				defer c.Close()
+				Eventually(func() error {
+					return c.RegistrationStatus().PCIConflict
+				}).Should(HaveOccurred())
+				Expect(c.RegistrationStatus().PCIConflict.Error()).To(ContainSubstring("configured to use 00:15.0"))
+				Expect(getDB()).To(Equal(map[string]string{addrKey: addr, pciKey: "00:16.0"}))
+			})
+		})
+
+		Context("fencing", func() {
+			var (
+				tmpDir      string
+				simulated   []*spdktest.Server
+				controllers []*oimcontroller.Controller
+			)
+
+			BeforeEach(func() {
+				var err error
+				tmpDir, err = ioutil.TempDir("", "oim-controller")
+				Expect(err).NotTo(HaveOccurred())
+				simulated = nil
+				controllers = nil
+				for i := 0; i < 2; i++ {
+					path := filepath.Join(tmpDir, fmt.Sprintf("spdk-%d.sock", i))
+					s, err := spdktest.New(path, spdktest.WithVHostSCSIControllers("vhost.0"))
+					Expect(err).NotTo(HaveOccurred())
+					simulated = append(simulated, s)
+					controllerID := fmt.Sprintf("host-%d", i)
+					creds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/controller."+controllerID+".key"), "component.registry")
+					Expect(err).NotTo(HaveOccurred())
+					c, err := oimcontroller.New(
+						oimcontroller.WithRegistry(registryAddress),
+						oimcontroller.WithCreds(creds),
+						oimcontroller.WithControllerID(controllerID),
+						oimcontroller.WithControllerAddress("foo://"+controllerID),
+						oimcontroller.WithSPDK(path),
+						oimcontroller.WithVHostController("vhost.0"),
+						oimcontroller.WithVHostDev("00:15.0"),
+						oimcontroller.WithQuiescePeriod(10*time.Millisecond),
+					)
+					Expect(err).NotTo(HaveOccurred())
+					controllers = append(controllers, c)
+				}
+			})
+
+			AfterEach(func() {
+				for _, c := range controllers {
+					c.Close()
+				}
+				for _, s := range simulated {
+					s.Close()
+				}
+				os.RemoveAll(tmpDir)
+			})
+
+			mapVolume := func(i int, force bool) error {
+				simulated[i].AddMallocBDev("my-volume")
+				_, err := controllers[i].MapVolume(context.Background(), &oim.MapVolumeRequest{
+					VolumeId: "my-volume",
+					Params: &oim.MapVolumeRequest_Malloc{
+						Malloc: &oim.MallocParams{},
+					},
+					Force: force,
+				})
+				return err
+			}
+			mapReader := func(i int) error {
+				simulated[i].AddMallocBDev("my-volume")
+				_, err := controllers[i].MapVolume(context.Background(), &oim.MapVolumeRequest{
+					VolumeId: "my-volume",
+					Params: &oim.MapVolumeRequest_Malloc{
+						Malloc: &oim.MallocParams{},
+					},
+					Reader: true,
+				})
+				return err
+			}
+			unmapVolume := func(i int) error {
+				_, err := controllers[i].UnmapVolume(context.Background(), &oim.UnmapVolumeRequest{VolumeId: "my-volume"})
+				return err
+			}
+			leaseKey := oimcommon.RegistryLeases + "/my-volume"
+
+			It("should allow only one writer", func() {
+				Expect(mapVolume(0, false)).To(Succeed())
+				Expect(mapVolume(0, false)).To(Succeed(), "map again")
+				Expect(getDB()).To(Equal(map[string]string{leaseKey: "host-0"}))
+				err := mapVolume(1, false)
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition), "second writer: %v", err)
+
+				By("unmapping")
+				Expect(unmapVolume(0)).To(Succeed())
+				Expect(getDB()).To(BeEmpty())
+				Expect(mapVolume(1, false)).To(Succeed())
+				Expect(getDB()).To(Equal(map[string]string{leaseKey: "host-1"}))
+			})
+
+			It("should allow several readers", func() {
+				Expect(mapReader(0)).To(Succeed())
+				Expect(mapReader(1)).To(Succeed())
+				Expect(getDB()).To(Equal(map[string]string{leaseKey: "shared:host-0,host-1"}))
+
+				By("unmapping one reader")
+				Expect(unmapVolume(0)).To(Succeed())
+				Expect(getDB()).To(Equal(map[string]string{leaseKey: "shared:host-1"}))
+				err := mapVolume(0, false)
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition), "writer: %v", err)
+
+				By("unmapping the last reader")
+				Expect(unmapVolume(1)).To(Succeed())
+				Expect(getDB()).To(BeEmpty())
+				Expect(mapVolume(0, false)).To(Succeed())
+				err = mapReader(1)
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition), "reader: %v", err)
+			})
+
+			It("should lease Ceph RAID members", func() {
+				ceph := func(volumeID string) *oim.CephParams {
+					return &oim.CephParams{Pool: "rbd", Image: volumeID}
+				}
+				simulated[0].AddMallocBDev("malloc-0")
+				_, err := controllers[0].MapVolume(context.Background(), &oim.MapVolumeRequest{
+					VolumeId: "my-raid",
+					Params: &oim.MapVolumeRequest_Raid{
+						Raid: &oim.RaidParams{
+							Members: []*oim.RaidMember{
+								{VolumeId: "malloc-0", Params: &oim.RaidMember_Malloc{Malloc: &oim.MallocParams{}}},
+								{VolumeId: "ceph-1", Params: &oim.RaidMember_Ceph{Ceph: ceph("ceph-1")}},
+							},
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getDB()).To(Equal(map[string]string{
+					oimcommon.RegistryLeases + "/my-raid": "host-0",
+					oimcommon.RegistryLeases + "/ceph-1":  "host-0",
+				}))
+
+				By("mapping the member elsewhere")
+				_, err = controllers[1].MapVolume(context.Background(), &oim.MapVolumeRequest{
+					VolumeId: "ceph-1",
+					Params:   &oim.MapVolumeRequest_Ceph{Ceph: ceph("ceph-1")},
+				})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition), "second writer: %v", err)
+
+				By("unmapping")
+				_, err = controllers[0].UnmapVolume(context.Background(), &oim.UnmapVolumeRequest{VolumeId: "my-raid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getDB()).To(BeEmpty())
+				Expect(simulated[0].BDevNames()).To(ConsistOf("malloc-0"))
+			})
+
+			It("should take over when forced", func() {
+				Expect(mapVolume(0, false)).To(Succeed())
+				Expect(mapVolume(1, true)).To(Succeed())
+				Expect(getDB()).To(Equal(map[string]string{leaseKey: "host-1"}))
+			})
+
+			Context("handover", func() {
+				var (
+					servers    []*oimcommon.NonBlockingGRPCServer
+					hostConn   *grpc.ClientConn
+					adminConn  *grpc.ClientConn
+					addrKeys   map[string]string
+					controller = "host-0/" + oimcommon.RegistryController
+				)
+
+				dial := func(key string) *grpc.ClientConn {
+					creds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/"+key+".key"), "component.registry")
+					Expect(err).NotTo(HaveOccurred())
+					opts := oimcommon.ChooseDialOpts(registryAddress, grpc.WithTransportCredentials(creds))
+					conn, err := grpc.Dial(registryAddress, opts...)
+					Expect(err).NotTo(HaveOccurred())
+					return conn
+				}
+
+				BeforeEach(func() {
+					servers = nil
+					addrKeys = map[string]string{}
+					for i, c := range controllers {
+						controllerID := fmt.Sprintf("host-%d", i)
+						addr := "unix://" + filepath.Join(tmpDir, controllerID+".sock")
+						server, service := c.Server(addr)
+						err := server.Start(ctx, service)
+						Expect(err).NotTo(HaveOccurred())
+						servers = append(servers, server)
+						key := controllerID + "/" + oimcommon.RegistryAddress
+						db.Store(key, addr)
+						addrKeys[key] = addr
+
+						// Both controllers have access to the same volume.
+						simulated[i].AddMallocBDev("my-volume")
+					}
+					hostConn = dial("host.host-0")
+					adminConn = dial("user.admin")
+				})
+
+				AfterEach(func() {
+					hostConn.Close()
+					adminConn.Close()
+					for _, server := range servers {
+						server.ForceStop(ctx)
+						server.Wait(ctx)
+					}
+				})
+
+				// hostMapVolume goes through the registry like
+				// the OIM CSI driver on host-0.
+				hostMapVolume := func() error {
+					ctx := metadata.AppendToOutgoingContext(context.Background(), "controllerid", "host-0")
+					_, err := oim.NewControllerClient(hostConn).MapVolume(ctx, &oim.MapVolumeRequest{
+						VolumeId: "my-volume",
+						Params: &oim.MapVolumeRequest_Malloc{
+							Malloc: &oim.MallocParams{},
+						},
+					})
+					return err
+				}
+
+				mapped := func(i int) bool {
+					for _, bdev := range simulated[i].Targets("vhost.0") {
+						if bdev == "my-volume" {
+							return true
+						}
+					}
+					return false
+				}
+
+				expectDB := func(entries map[string]string) {
+					for key, value := range addrKeys {
+						entries[key] = value
+					}
+					Expect(getDB()).To(Equal(entries))
+				}
+
+				It("should move volumes", func() {
+					Expect(hostMapVolume()).To(Succeed())
+					Expect(mapped(0)).To(BeTrue())
+					expectDB(map[string]string{leaseKey: "host-0"})
+
+					err := oimcontroller.Handover(ctx, adminConn, "host-0", "host-0", "host-1", "my-volume")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(mapped(0)).To(BeFalse())
+					Expect(mapped(1)).To(BeTrue())
+					expectDB(map[string]string{leaseKey: "host-1", controller: "host-1"})
+
+					By("rejecting MapVolume on the source")
+					err = mapVolume(0, false)
+					Expect(status.Code(err)).To(Equal(codes.FailedPrecondition), "quiesced: %v", err)
+
+					By("redirecting the host")
+					Expect(hostMapVolume()).To(Succeed())
+					Expect(mapped(0)).To(BeFalse())
+
+					By("moving back")
+					err = oimcontroller.Handover(ctx, adminConn, "host-0", "host-1", "host-0", "my-volume")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(mapped(0)).To(BeTrue())
+					Expect(mapped(1)).To(BeFalse())
+					expectDB(map[string]string{leaseKey: "host-0"})
+					Expect(hostMapVolume()).To(Succeed())
+				})
+
+				It("should roll back", func() {
+					Expect(hostMapVolume()).To(Succeed())
+					simulated[1].DeleteBDev("my-volume")
+
+					err := oimcontroller.Handover(ctx, adminConn, "host-0", "host-0", "host-1", "my-volume")
+					Expect(err).To(HaveOccurred())
+					Expect(mapped(0)).To(BeTrue())
+					Expect(mapped(1)).To(BeFalse())
+					expectDB(map[string]string{leaseKey: "host-0"})
+					Expect(hostMapVolume()).To(Succeed())
+				})
+
+				It("should move volumes back when one fails", func() {
+					Expect(hostMapVolume()).To(Succeed())
+					simulated[0].AddMallocBDev("other-volume")
+					_, err := controllers[0].MapVolume(context.Background(), &oim.MapVolumeRequest{
+						VolumeId: "other-volume",
+						Params: &oim.MapVolumeRequest_Malloc{
+							Malloc: &oim.MallocParams{},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					// Only my-volume can be mapped on host-1.
+					err = oimcontroller.Handover(ctx, adminConn, "host-0", "host-0", "host-1", "my-volume", "other-volume")
+					Expect(err).To(HaveOccurred())
+					Expect(mapped(0)).To(BeTrue())
+					Expect(mapped(1)).To(BeFalse())
+					Expect(simulated[0].Targets("vhost.0")).To(HaveLen(2))
+					expectDB(map[string]string{
+						leaseKey: "host-0",
+						oimcommon.RegistryLeases + "/other-volume": "host-0",
+					})
+					Expect(hostMapVolume()).To(Succeed())
+				})
+
+				It("should reject volumes in use", func() {
+					Expect(hostMapVolume()).To(Succeed())
+					simulated[0].SetHook(func(method string, params json.RawMessage) error {
+						if method == "get_bdevs_iostat" {
+							simulated[0].AddIO("my-volume", 0, 1)
+						}
+						return nil
+					})
+
+					err := oimcontroller.Handover(ctx, adminConn, "host-0", "host-0", "host-1", "my-volume")
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("is in use"))
+					Expect(mapped(0)).To(BeTrue())
+					Expect(mapped(1)).To(BeFalse())
+					expectDB(map[string]string{leaseKey: "host-0"})
+					Expect(mapVolume(0, false)).To(Succeed(), "not quiesced")
+				})
+
+				It("should not return secrets", func() {
+					_, err := controllers[0].MapVolume(context.Background(), &oim.MapVolumeRequest{
+						VolumeId: "ceph-volume",
+						Params: &oim.MapVolumeRequest_Ceph{
+							Ceph: &oim.CephParams{
+								UserId: "admin",
+								Secret: "my-secret",
+								Pool:   "rbd",
+								Image:  "ceph-volume",
+							},
+						},
+						Crypto: &oim.CryptoParams{Key: "0123456789abcdef"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					reply, err := controllers[0].QuiesceVolume(context.Background(), &oim.QuiesceVolumeRequest{VolumeId: "ceph-volume"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(reply.GetMapVolume()).To(Equal(&oim.MapVolumeRequest{
+						VolumeId: "ceph-volume",
+						Params: &oim.MapVolumeRequest_Ceph{
+							Ceph: &oim.CephParams{
+								UserId: "admin",
+								Pool:   "rbd",
+								Image:  "ceph-volume",
+							},
+						},
+						Crypto: &oim.CryptoParams{},
+					}))
+				})
+
+				It("should reject unknown volumes", func() {
+					err := oimcontroller.Handover(ctx, adminConn, "host-0", "host-0", "host-1", "my-volume")
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("not mapped by this controller"))
+					expectDB(map[string]string{})
+				})
+			})
+		})
+
+		It("should report failures", func() {
+			// The key is for host-0, so the registry rejects
+			// the address for host-1.
+			c, err := oimcontroller.New(
+				oimcontroller.WithRegistry(registryAddress),
+				oimcontroller.WithCreds(controllerCreds),
+				oimcontroller.WithControllerID("host-1"),
+				oimcontroller.WithControllerAddress("foo://bar"),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = c.Start()
+			Expect(err).NotTo(HaveOccurred())
+			defer c.Close()
+
+			// Retries happen much faster than the one
+			// minute registry delay.
+			Eventually(func() int {
+				return c.RegistrationStatus().Failures
+			}, 10*time.Second).Should(BeNumerically(">=", 2))
+			status := c.RegistrationStatus()
+			Expect(status.Registered).To(BeFalse())
+			Expect(status.LastError).To(HaveOccurred())
+			Expect(status.LastError.Error()).To(ContainSubstring("PermissionDenied"))
+			Expect(getDB()).To(Equal(map[string]string{}))
+		})
 	})
 
 	Describe("attaching a volume", func() {
@@ -163,11 +631,10 @@ var _ = Describe("OIM Controller", func() {
 		BeforeEach(func() {
 			var err error
 
-			err = testspdk.Init(testspdk.WithVHostSCSI())
+			// Without a real SPDK, the tests run against the
+			// simulated one.
+			err = testspdk.Init(testspdk.WithVHostSCSI(), testspdk.WithFake())
 			Expect(err).NotTo(HaveOccurred())
-			if testspdk.SPDK == nil {
-				Skip("No SPDK vhost.")
-			}
 
 			// TODO: add logging wrapper around service.
 			// Otherwise function calls into this
@@ -291,6 +758,12 @@ var _ = Describe("OIM Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(controllers2).To(Equal(controllers))
 
+			By("getting statistics")
+			_, err = c.GetVolumeStats(ctx, &oim.GetVolumeStatsRequest{VolumeId: volumeID})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = c.GetVolumeStats(ctx, &oim.GetVolumeStatsRequest{VolumeId: "no-such-volume"})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
 			By("unmapping")
 			remove := oim.UnmapVolumeRequest{
 				VolumeId: "controller-test",
@@ -303,8 +776,99 @@ var _ = Describe("OIM Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should encrypt", func() {
+			ctx := context.Background()
+			add := oim.MapVolumeRequest{
+				VolumeId: volumeID,
+				Params: &oim.MapVolumeRequest_Malloc{
+					Malloc: &oim.MallocParams{},
+				},
+				Crypto: &oim.CryptoParams{
+					Key: "too short",
+				},
+			}
+
+			By("rejecting an invalid key")
+			_, err := c.MapVolume(ctx, &add)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			By("mapping")
+			add.Crypto.Key = "0123456789abcdef"
+			_, err = c.MapVolume(ctx, &add)
+			if spdk.IsJSONError(err, spdk.ERROR_METHOD_NOT_FOUND) {
+				Skip("SPDK without crypto support.")
+			}
+			Expect(err).NotTo(HaveOccurred())
+			controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+			Expect(err).NotTo(HaveOccurred())
+			scsi := controllers[0].BackendSpecific["scsi"].(spdk.SCSIControllerSpecific)
+			Expect(scsi).To(HaveLen(1))
+			Expect(scsi[0].LUNs[0].BDevName).To(Equal("crypto-" + volumeID))
+
+			By("unmapping")
+			_, err = c.UnmapVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: volumeID})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: "crypto-" + volumeID})
+			Expect(err).To(HaveOccurred())
+			_, err = spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: volumeID})
+			Expect(err).NotTo(HaveOccurred(), "Malloc BDev kept")
+		})
+
+		It("should find and remove orphans", func() {
+			ctx := context.Background()
+
+			By("checking a clean SPDK")
+			list, err := c.ListOrphans(ctx, &oim.ListOrphansRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.GetOrphans()).To(BeEmpty())
+
+			By("ignoring a mapped volume")
+			mapVolume()
+			list, err = c.ListOrphans(ctx, &oim.ListOrphansRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.GetOrphans()).To(BeEmpty())
+			_, err = c.UnmapVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: volumeID})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("leaving behind a crypto BDev")
+			name := "crypto-" + volumeID
+			_, err = spdk.ConstructCryptoBDev(ctx, c.SPDK, spdk.ConstructCryptoBDevArgs{
+				BaseBDevName: bdevName,
+				Name:         name,
+				CryptoPMD:    spdk.CryptoAESNIMB,
+				Key:          "0123456789abcdef",
+			})
+			if spdk.IsJSONError(err, spdk.ERROR_METHOD_NOT_FOUND) {
+				Skip("SPDK without crypto support.")
+			}
+			Expect(err).NotTo(HaveOccurred())
+			list, err = c.ListOrphans(ctx, &oim.ListOrphansRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.GetOrphans()).To(HaveLen(1))
+			orphan := list.GetOrphans()[0]
+			Expect(orphan.GetKind()).To(Equal(oim.Orphan_BDEV))
+			Expect(orphan.GetName()).To(Equal(name))
+
+			By("keeping it during the grace period")
+			collected, err := c.CollectGarbage(ctx, &oim.CollectGarbageRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collected.GetRemoved()).To(BeEmpty())
+
+			By("removing it when forced")
+			collected, err = c.CollectGarbage(ctx, &oim.CollectGarbageRequest{Force: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collected.GetRemoved()).To(Equal([]*oim.Orphan{orphan}))
+			_, err = spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: name})
+			Expect(err).To(HaveOccurred())
+			_, err = spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: bdevName})
+			Expect(err).NotTo(HaveOccurred(), "Malloc BDev kept")
+		})
+
 		Context("with QEMU", func() {
 			BeforeEach(func() {
+				if testspdk.Fake != nil {
+					Skip("No SPDK vhost.")
+				}
 				err := qemu.Init()
 				Expect(err).NotTo(HaveOccurred())
 				if qemu.VM == nil {
@@ -339,4 +903,319 @@ var _ = Describe("OIM Controller", func() {
 			})
 		})
 	})
+
+	Describe("in-process", func() {
+		It("should work with an existing SPDK client", func() {
+			ctx := context.Background()
+			tmpDir, err := ioutil.TempDir("", "oim-controller")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tmpDir)
+			path := filepath.Join(tmpDir, "spdk.sock")
+			simulated, err := spdktest.New(path)
+			Expect(err).NotTo(HaveOccurred())
+			defer simulated.Close()
+			simulated.AddMallocBDev("volume-0")
+			client, err := spdk.New(path)
+			Expect(err).NotTo(HaveOccurred())
+			defer client.Close()
+
+			By("rejecting a registry without credentials")
+			_, err = oimcontroller.New(oimcontroller.WithSPDKClient(client),
+				oimcontroller.WithRegistry("dns:///registry"),
+				oimcontroller.WithControllerID("host-0"),
+				oimcontroller.WithControllerAddress("dns:///controller"))
+			Expect(err).To(HaveOccurred())
+
+			By("creating without credentials")
+			c, err := oimcontroller.New(oimcontroller.WithSPDKClient(client))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = c.GetVolumeStats(ctx, &oim.GetVolumeStatsRequest{VolumeId: "volume-0"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = c.MapVolume(ctx, &oim.MapVolumeRequest{
+				VolumeId: "volume-0",
+				Params:   &oim.MapVolumeRequest_Malloc{Malloc: &oim.MallocParams{}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("no VHost SCSI controller configured")))
+
+			By("keeping the client open")
+			c.Close()
+			_, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("logical volumes", func() {
+		var (
+			lvolStore = "controller-test-lvs"
+			baseBDev  = "controller-test-lvs-base"
+			c         *oimcontroller.Controller
+		)
+
+		BeforeEach(func() {
+			var err error
+			ctx := context.Background()
+
+			err = testspdk.Init(testspdk.WithVHostSCSI())
+			Expect(err).NotTo(HaveOccurred())
+			if testspdk.SPDK == nil {
+				Skip("No SPDK vhost.")
+			}
+
+			c, err = oimcontroller.New(oimcontroller.WithSPDK(testspdk.SPDKPath),
+				oimcontroller.WithCreds(controllerCreds),
+				oimcontroller.WithVHostDev(testspdk.VHostDev),
+				oimcontroller.WithVHostController(testspdk.VHostPath))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = spdk.ConstructMallocBDev(ctx, c.SPDK, spdk.ConstructMallocBDevArgs{
+				ConstructBDevArgs: spdk.ConstructBDevArgs{
+					NumBlocks: 64 * 1024 * 1024 / 512,
+					BlockSize: 512,
+					Name:      baseBDev,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = spdk.ConstructLVolStore(ctx, c.SPDK, spdk.ConstructLVolStoreArgs{
+				BDevName:  baseBDev,
+				LVSName:   lvolStore,
+				ClusterSz: 1024 * 1024,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			failed := []error{}
+
+			if testspdk.SPDK != nil {
+				ctx := context.Background()
+				// Removing the lvol store also removes all logical volumes.
+				if err := spdk.DestroyLVolStore(ctx, c.SPDK, spdk.DestroyLVolStoreArgs{LVSName: lvolStore}); err != nil {
+					failed = append(failed, fmt.Errorf("DestroyLVolStore: %s", err))
+				}
+				if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: baseBDev}); err != nil {
+					failed = append(failed, fmt.Errorf("DeleteBDev %s: %s", baseBDev, err))
+				}
+				c.Close()
+			}
+
+			if err := testspdk.Finalize(); err != nil {
+				failed = append(failed, fmt.Errorf("spdk.Finalize: %s", err))
+			}
+
+			Expect(failed).To(BeEmpty())
+		})
+
+		provision := func(volumeID string, size int64) int64 {
+			reply, err := c.ProvisionLVol(context.Background(), &oim.ProvisionLVolRequest{
+				LvolStore: lvolStore,
+				VolumeId:  volumeID,
+				Size_:     size,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return reply.GetSize_()
+		}
+
+		It("should have idempotent ProvisionLVol", func() {
+			Expect(provision("vol", 1)).To(Equal(int64(1024 * 1024)))
+			Expect(provision("vol", 1)).To(Equal(int64(1024 * 1024)))
+			_, err := c.ProvisionLVol(context.Background(), &oim.ProvisionLVolRequest{
+				LvolStore: lvolStore,
+				VolumeId:  "vol",
+				Size_:     2 * 1024 * 1024,
+			})
+			Expect(err).To(HaveOccurred())
+			provision("vol", 0)
+			provision("vol", 0)
+		})
+
+		It("should map and unmap", func() {
+			ctx := context.Background()
+			provision("vol", 1024*1024)
+
+			add := oim.MapVolumeRequest{
+				VolumeId: "vol",
+				Params: &oim.MapVolumeRequest_Lvol{
+					Lvol: &oim.LVolParams{
+						LvolStore: lvolStore,
+					},
+				},
+			}
+			_, err := c.MapVolume(ctx, &add)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = c.MapVolume(ctx, &add)
+			Expect(err).NotTo(HaveOccurred())
+			controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+			Expect(err).NotTo(HaveOccurred())
+			scsi := controllers[0].BackendSpecific["scsi"].(spdk.SCSIControllerSpecific)
+			Expect(scsi).To(HaveLen(1))
+
+			_, err = c.UnmapVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: "vol"})
+			Expect(err).NotTo(HaveOccurred())
+			controllers, err = spdk.GetVHostControllers(ctx, c.SPDK)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controllers[0].BackendSpecific["scsi"]).To(BeEmpty())
+
+			By("keeping the logical volume")
+			Expect(provision("vol", 1)).To(Equal(int64(1024 * 1024)))
+		})
+
+		It("should set QoS limits", func() {
+			ctx := context.Background()
+			provision("vol", 1024*1024)
+
+			limits := func() spdk.QoSLimits {
+				bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: lvolStore + "/vol"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bdevs).To(HaveLen(1))
+				return bdevs[0].RateLimits
+			}
+
+			setQoS := oim.SetVolumeQoSRequest{
+				VolumeId: "vol",
+				Qos:      &oim.QoS{RwIosPerSec: 20000},
+			}
+			_, err := c.SetVolumeQoS(ctx, &setQoS)
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			_, err = c.MapVolume(ctx, &oim.MapVolumeRequest{
+				VolumeId: "vol",
+				Params: &oim.MapVolumeRequest_Lvol{
+					Lvol: &oim.LVolParams{
+						LvolStore: lvolStore,
+					},
+				},
+				Qos: &oim.QoS{RwMbytesPerSec: 100},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(limits()).To(Equal(spdk.QoSLimits{RWMBytesPerSec: 100}))
+
+			By("changing limits")
+			_, err = c.SetVolumeQoS(ctx, &setQoS)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(limits()).To(Equal(spdk.QoSLimits{RWIOsPerSec: 20000}))
+
+			_, err = c.UnmapVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: "vol"})
+			Expect(err).NotTo(HaveOccurred())
+			provision("vol", 0)
+		})
+
+		It("should resize while mapped", func() {
+			ctx := context.Background()
+			provision("vol", 1024*1024)
+
+			_, err := c.MapVolume(ctx, &oim.MapVolumeRequest{
+				VolumeId: "vol",
+				Params: &oim.MapVolumeRequest_Lvol{
+					Lvol: &oim.LVolParams{
+						LvolStore: lvolStore,
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			resize := oim.ResizeVolumeRequest{
+				LvolStore: lvolStore,
+				VolumeId:  "vol",
+				Size_:     2 * 1024 * 1024,
+			}
+			reply, err := c.ResizeVolume(ctx, &resize)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetSize_()).To(Equal(int64(2 * 1024 * 1024)))
+
+			By("resizing again")
+			reply, err = c.ResizeVolume(ctx, &resize)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetSize_()).To(Equal(int64(2 * 1024 * 1024)))
+
+			By("not shrinking")
+			resize.Size_ = 1024 * 1024
+			reply, err = c.ResizeVolume(ctx, &resize)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetSize_()).To(Equal(int64(2 * 1024 * 1024)))
+
+			By("resizing a missing volume")
+			resize.VolumeId = "no-such-vol"
+			_, err = c.ResizeVolume(ctx, &resize)
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			_, err = c.UnmapVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: "vol"})
+			Expect(err).NotTo(HaveOccurred())
+			provision("vol", 0)
+		})
+
+		It("should create, list, clone and delete snapshots", func() {
+			ctx := context.Background()
+			provision("vol", 1024*1024)
+
+			By("creating a snapshot")
+			create := oim.CreateSnapshotRequest{
+				LvolStore:      lvolStore,
+				SourceVolumeId: "vol",
+				SnapshotId:     "snap",
+			}
+			reply, err := c.CreateSnapshot(ctx, &create)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetSnapshot().GetSnapshotId()).To(Equal("snap"))
+			Expect(reply.GetSnapshot().GetSourceVolumeId()).To(Equal("vol"))
+			Expect(reply.GetSnapshot().GetSize_()).To(Equal(int64(1024 * 1024)))
+			Expect(reply.GetSnapshot().GetCreationTime()).NotTo(BeZero())
+
+			By("creating it again")
+			reply2, err := c.CreateSnapshot(ctx, &create)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply2).To(Equal(reply))
+
+			By("creating it for a different volume")
+			provision("vol2", 1024*1024)
+			create.SourceVolumeId = "vol2"
+			_, err = c.CreateSnapshot(ctx, &create)
+			Expect(err).To(HaveOccurred())
+
+			By("listing snapshots")
+			list, err := c.ListSnapshots(ctx, &oim.ListSnapshotsRequest{LvolStore: lvolStore})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.GetSnapshots()).To(Equal([]*oim.Snapshot{reply.GetSnapshot()}))
+			list, err = c.ListSnapshots(ctx, &oim.ListSnapshotsRequest{LvolStore: lvolStore, SourceVolumeId: "vol2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.GetSnapshots()).To(BeEmpty())
+
+			By("cloning the snapshot")
+			clone, err := c.CloneVolume(ctx, &oim.CloneVolumeRequest{
+				LvolStore:  lvolStore,
+				VolumeId:   "clone",
+				SnapshotId: "snap",
+				Size_:      2 * 1024 * 1024,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clone.GetSize_()).To(Equal(int64(2 * 1024 * 1024)))
+
+			By("cloning a volume")
+			clone, err = c.CloneVolume(ctx, &oim.CloneVolumeRequest{
+				LvolStore:      lvolStore,
+				VolumeId:       "clone2",
+				SourceVolumeId: "vol",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clone.GetSize_()).To(Equal(int64(1024 * 1024)))
+
+			By("checking the source of the snapshot")
+			list, err = c.ListSnapshots(ctx, &oim.ListSnapshotsRequest{LvolStore: lvolStore, SnapshotId: "snap"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.GetSnapshots()).To(Equal([]*oim.Snapshot{reply.GetSnapshot()}))
+
+			By("deleting the snapshot")
+			_, err = c.DeleteSnapshot(ctx, &oim.DeleteSnapshotRequest{LvolStore: lvolStore, SnapshotId: "snap"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = c.DeleteSnapshot(ctx, &oim.DeleteSnapshotRequest{LvolStore: lvolStore, SnapshotId: "snap"})
+			Expect(err).NotTo(HaveOccurred())
+			list, err = c.ListSnapshots(ctx, &oim.ListSnapshotsRequest{LvolStore: lvolStore})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.GetSnapshots()).To(BeEmpty())
+
+			By("deleting volumes")
+			for _, volumeID := range []string{"vol", "vol2", "clone", "clone2"} {
+				provision(volumeID, 0)
+			}
+		})
+	})
 })
@@ -0,0 +1,82 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// cryptoBDevName returns the name of the crypto BDev which
+// encrypts the volume.
+func cryptoBDevName(volumeID string) string {
+	return "crypto-" + volumeID
+}
+
+// mapCrypto creates a crypto BDev on top of the base BDev, if it
+// does not exist yet, and returns its name. The key must not
+// end up in errors or log messages.
+func (c *Controller) mapCrypto(ctx context.Context, volumeID, baseBDevName string, params *oim.CryptoParams) (string, error) {
+	if len(params.GetKey()) != spdk.CryptoKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "crypto key must have %d bytes, got %d", spdk.CryptoKeyLength, len(params.GetKey()))
+	}
+	cryptoPMD := params.GetCryptoPmd()
+	if cryptoPMD == "" {
+		cryptoPMD = spdk.CryptoAESNIMB
+	}
+	name := cryptoBDevName(volumeID)
+
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: name})
+	switch {
+	case err == nil && len(bdevs) == 1:
+		crypto := bdevs[0].DriverSpecific.Crypto
+		if crypto == nil || crypto.BaseBDevName != baseBDevName {
+			return "", status.Errorf(codes.AlreadyExists, "BDev %s exists and does not encrypt %s", name, baseBDevName)
+		}
+		// Reuse it, as in MapVolume.
+		return name, nil
+	case err != nil && !spdk.IsNotFound(err):
+		return "", errors.Wrapf(err, "GetBDevs %s", name)
+	}
+
+	args := spdk.ConstructCryptoBDevArgs{
+		BaseBDevName: baseBDevName,
+		Name:         name,
+		CryptoPMD:    cryptoPMD,
+		Key:          params.GetKey(),
+	}
+	if _, err := spdk.ConstructCryptoBDev(ctx, c.SPDK, args); err != nil {
+		return "", errors.Wrapf(err, "ConstructCryptoBDev %s on top of %s with %s", name, baseBDevName, cryptoPMD)
+	}
+	return name, nil
+}
+
+// unmapCrypto removes the crypto BDev of the volume, if there is one.
+func (c *Controller) unmapCrypto(ctx context.Context, volumeID string) error {
+	name := cryptoBDevName(volumeID)
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: name})
+	if err != nil {
+		if spdk.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "GetBDevs %s", name)
+	}
+	if len(bdevs) != 1 || bdevs[0].DriverSpecific.Crypto == nil {
+		return nil
+	}
+	if err := spdk.DeleteCryptoBDev(ctx, c.SPDK, spdk.DeleteCryptoBDevArgs{Name: name}); err != nil {
+		return errors.Wrapf(err, "DeleteCryptoBDev %s", name)
+	}
+	return nil
+}
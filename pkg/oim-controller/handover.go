@@ -0,0 +1,282 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// isQuiesced checks whether MapVolume must reject the volume.
+func (c *Controller) isQuiesced(volumeID string) bool {
+	c.handoverMutex.Lock()
+	defer c.handoverMutex.Unlock()
+	return c.quiesced[volumeID]
+}
+
+// recordMapping remembers how the volume was mapped, for
+// QuiesceVolume, and which BDevs it uses, for watchBDevs. The
+// request may contain secrets and therefore only gets stored in
+// memory.
+func (c *Controller) recordMapping(in *oim.MapVolumeRequest, bdevNames ...string) {
+	request := proto.Clone(in).(*oim.MapVolumeRequest)
+	request.Async = false
+	request.Force = false
+	c.handoverMutex.Lock()
+	defer c.handoverMutex.Unlock()
+	c.mappings[in.GetVolumeId()] = request
+	for _, bdevName := range bdevNames {
+		c.mappedBDevs[bdevName] = in.GetVolumeId()
+	}
+}
+
+// forgetMapping is called once a volume is unmapped.
+func (c *Controller) forgetMapping(volumeID string) {
+	c.handoverMutex.Lock()
+	defer c.handoverMutex.Unlock()
+	delete(c.mappings, volumeID)
+	for bdevName, id := range c.mappedBDevs {
+		if id == volumeID {
+			delete(c.mappedBDevs, bdevName)
+		}
+	}
+}
+
+// QuiesceVolume implements oim.Controller.QuiesceVolume.
+func (c *Controller) QuiesceVolume(ctx context.Context, in *oim.QuiesceVolumeRequest) (*oim.QuiesceVolumeReply, error) {
+	volumeID := in.GetVolumeId()
+	if volumeID == "" {
+		return nil, errors.New("empty volume ID")
+	}
+	target := in.GetTargetControllerId()
+	if target != "" && c.registryAddress == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %s: handing over to controller %s needs self-registration", volumeID, target)
+	}
+
+	if target != "" && target == c.controllerID {
+		return nil, status.Errorf(codes.InvalidArgument, "volume %s: cannot hand over to the same controller", volumeID)
+	}
+
+	// From now on MapVolume fails for the volume...
+	c.handoverMutex.Lock()
+	c.quiesced[volumeID] = true
+	c.handoverMutex.Unlock()
+
+	// ... but one which is already running must finish first.
+	// Holding the lock until the end also keeps UnmapVolume out
+	// while the volume moves.
+	c.volumeMutex.LockKey(volumeID)
+	defer c.volumeMutex.UnlockKey(volumeID)
+	c.handoverMutex.Lock()
+	request := c.mappings[volumeID]
+	if request == nil {
+		delete(c.quiesced, volumeID)
+	}
+	c.handoverMutex.Unlock()
+	if request == nil {
+		return nil, status.Errorf(codes.NotFound, "volume %s: not mapped by this controller", volumeID)
+	}
+
+	if err := c.checkIdle(ctx, volumeID); err != nil {
+		c.resume(volumeID)
+		return nil, err
+	}
+
+	// The volume stays quiesced when this fails, it might be
+	// half-removed. ResumeVolume undoes that.
+	if _, err := c.unmapLockedVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: volumeID}); err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).Infow("quiesced volume", "volume", volumeID)
+
+	if target != "" {
+		if err := c.mapOnController(ctx, target, request); err != nil {
+			log.FromContext(ctx).Errorw("handover failed, mapping volume again", "volume", volumeID, "controller", target, "error", err)
+			c.resume(volumeID)
+			if _, err := c.mapLockedVolume(ctx, request); err != nil {
+				log.FromContext(ctx).Errorw("map volume again after failed handover", "volume", volumeID, "error", err)
+			}
+			return nil, err
+		}
+		log.FromContext(ctx).Infow("handed over volume", "volume", volumeID, "controller", target)
+	}
+	return &oim.QuiesceVolumeReply{MapVolume: withoutSecrets(request)}, nil
+}
+
+// checkIdle fails when the volume has I/O during the quiesce period.
+// Removing the volume does not flush anything on the host, so the
+// host must have stopped using it before.
+func (c *Controller) checkIdle(ctx context.Context, volumeID string) error {
+	if c.quiescePeriod <= 0 {
+		return nil
+	}
+	before, err := c.volumeIO(ctx, volumeID)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-time.After(c.quiescePeriod):
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	after, err := c.volumeIO(ctx, volumeID)
+	if err != nil {
+		return err
+	}
+	if after != before {
+		return status.Errorf(codes.FailedPrecondition, "volume %s is in use: %d I/O operations during the last %s", volumeID, after-before, c.quiescePeriod)
+	}
+	return nil
+}
+
+// volumeIO returns the number of I/O operations so far for all
+// BDevs of the volume.
+func (c *Controller) volumeIO(ctx context.Context, volumeID string) (uint64, error) {
+	stats, err := spdk.GetBDevsIOStat(ctx, c.SPDK, spdk.GetBDevsIOStatArgs{})
+	if err != nil {
+		return 0, errors.Wrap(err, "GetBDevsIOStat")
+	}
+	c.handoverMutex.Lock()
+	defer c.handoverMutex.Unlock()
+	var ops uint64
+	for _, stat := range stats.BDevs {
+		if c.mappedBDevs[stat.Name] == volumeID {
+			ops += stat.NumReadOps + stat.NumWriteOps + stat.NumUnmapOps
+		}
+	}
+	return ops, nil
+}
+
+// mapOnController sends the MapVolume request to another controller
+// through the OIM registry.
+func (c *Controller) mapOnController(ctx context.Context, controllerID string, request *oim.MapVolumeRequest) error {
+	conn, err := c.dialRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", controllerID)
+	if _, err := oim.NewControllerClient(conn).MapVolume(ctx, request); err != nil {
+		// Keep the status code for the caller of
+		// QuiesceVolume.
+		s := status.Convert(err)
+		return status.Errorf(s.Code(), "map volume %s on controller %s: %s", request.GetVolumeId(), controllerID, s.Message())
+	}
+	return nil
+}
+
+// withoutSecrets returns a copy of the request without Ceph secrets
+// and crypto keys.
+func withoutSecrets(in *oim.MapVolumeRequest) *oim.MapVolumeRequest {
+	request := proto.Clone(in).(*oim.MapVolumeRequest)
+	if ceph := request.GetCeph(); ceph != nil {
+		ceph.Secret = ""
+	}
+	for _, member := range request.GetRaid().GetMembers() {
+		if ceph := member.GetCeph(); ceph != nil {
+			ceph.Secret = ""
+		}
+	}
+	if crypto := request.GetCrypto(); crypto != nil {
+		crypto.Key = ""
+	}
+	return request
+}
+
+// resume allows MapVolume for the volume again.
+func (c *Controller) resume(volumeID string) {
+	c.handoverMutex.Lock()
+	defer c.handoverMutex.Unlock()
+	delete(c.quiesced, volumeID)
+}
+
+// ResumeVolume implements oim.Controller.ResumeVolume.
+func (c *Controller) ResumeVolume(ctx context.Context, in *oim.ResumeVolumeRequest) (*oim.ResumeVolumeReply, error) {
+	c.resume(in.GetVolumeId())
+	return &oim.ResumeVolumeReply{}, nil
+}
+
+// Handover moves volumes of a host from one controller to another.
+// It must be called with a connection to the OIM registry that is
+// authenticated as admin. Each volume gets quiesced on the source
+// controller, which then maps it on the target controller itself,
+// so secrets never pass through the admin. Once all volumes are
+// moved, the host gets associated with the target controller in the
+// registry. When something fails, the volumes that were already
+// moved are moved back, so the host keeps using the source
+// controller for all of them.
+func Handover(ctx context.Context, conn *grpc.ClientConn, hostID, from, to string, volumeIDs ...string) (finalErr error) {
+	controller := oim.NewControllerClient(conn)
+	var moved []string
+	defer func() {
+		if finalErr == nil {
+			return
+		}
+		for i := len(moved) - 1; i >= 0; i-- {
+			if err := handoverVolume(ctx, controller, moved[i], to, from); err != nil {
+				log.FromContext(ctx).Errorw("moving volume back after failed handover", "volume", moved[i], "controller", from, "error", err)
+			}
+		}
+	}()
+	for _, volumeID := range volumeIDs {
+		if err := handoverVolume(ctx, controller, volumeID, from, to); err != nil {
+			return err
+		}
+		moved = append(moved, volumeID)
+		log.FromContext(ctx).Infow("handed over volume", "volume", volumeID, "from", from, "to", to)
+	}
+
+	// The host is served by the controller with the same ID
+	// unless redirected.
+	target := to
+	if target == hostID {
+		target = ""
+	}
+	registry := oim.NewRegistryClient(conn)
+	path := hostID + "/" + oimcommon.RegistryController
+	if _, err := registry.SetValue(ctx, &oim.SetValueRequest{
+		Value: &oim.Value{
+			Path:  path,
+			Value: target,
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "set %s", path)
+	}
+	return nil
+}
+
+// handoverVolume moves one volume. When that fails, the volume stays
+// on the source controller.
+func handoverVolume(ctx context.Context, controller oim.ControllerClient, volumeID, from, to string) error {
+	// The volume might have been quiesced on the target by an
+	// earlier handover in the other direction.
+	toCtx := metadata.AppendToOutgoingContext(ctx, "controllerid", to)
+	if _, err := controller.ResumeVolume(toCtx, &oim.ResumeVolumeRequest{VolumeId: volumeID}); err != nil {
+		return errors.Wrapf(err, "resume volume %s on controller %s", volumeID, to)
+	}
+	fromCtx := metadata.AppendToOutgoingContext(ctx, "controllerid", from)
+	if _, err := controller.QuiesceVolume(fromCtx, &oim.QuiesceVolumeRequest{
+		VolumeId:           volumeID,
+		TargetControllerId: to,
+	}); err != nil {
+		return errors.Wrapf(err, "move volume %s from controller %s to %s", volumeID, from, to)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// controllerService is the name of the gRPC service that is checked
+// by Check in addition to the overall server health ("").
+const controllerService = "oim.v0.Controller"
+
+// Check implements grpc_health_v1.HealthServer.Check. The controller
+// is not serving while it waits for SPDK to come back.
+func (c *Controller) Check(ctx context.Context, in *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	service := in.GetService()
+	if service != "" && service != controllerService {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", service)
+	}
+	if c.SPDK != nil && !c.SPDK.Connected() {
+		return &grpc_health_v1.HealthCheckResponse{
+			Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		}, nil
+	}
+	return &grpc_health_v1.HealthCheckResponse{
+		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+	}, nil
+}
+
+// Watch implements grpc_health_v1.HealthServer.Watch. It is not
+// supported, clients have to poll with Check.
+func (c *Controller) Watch(in *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	return status.Error(codes.Unimplemented, "use Check")
+}
+
+// spdkReconnected gets called by the SPDK client once SPDK accepts
+// connections again. SPDK may have lost its state or restored it
+// from a saved configuration. A lost state gets restored from the
+// controller's own snapshot, then the controller checks for
+// orphans.
+func (c *Controller) spdkReconnected() {
+	ctx := context.Background()
+	c.restoreConfig(ctx)
+	log.FromContext(ctx).Infow("SPDK reconnected, checking for orphans")
+	c.reconcile(ctx, c.orphanGracePeriod)
+}
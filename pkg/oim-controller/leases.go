@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// acquireLease ensures that no other controller maps the volume at
+// the same time, except for other readers when shared is set. It
+// returns true if the lease was not held by this controller before.
+// Without self-registration there is no registry that could
+// arbitrate and volumes are not fenced.
+func (c *Controller) acquireLease(ctx context.Context, volumeID string, shared, force bool) (bool, error) {
+	if c.registryAddress == "" {
+		return false, nil
+	}
+	conn, err := c.dialRegistry(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	reply, err := oim.NewRegistryClient(conn).AcquireLease(ctx, &oim.AcquireLeaseRequest{
+		VolumeId: volumeID,
+		Force:    force,
+		Shared:   shared,
+	})
+	if err != nil {
+		// Keep the status code, FailedPrecondition must reach
+		// the caller of MapVolume.
+		s := status.Convert(err)
+		return false, status.Errorf(s.Code(), "acquire lease for volume %s: %s", volumeID, s.Message())
+	}
+	if reply.GetPreviousHolder() == c.controllerID {
+		return false, nil
+	}
+	for _, reader := range reply.GetPreviousReaders() {
+		if reader == c.controllerID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// releaseLease gives up the lease for the volume, if this controller
+// holds it.
+func (c *Controller) releaseLease(ctx context.Context, volumeID string) error {
+	if c.registryAddress == "" {
+		return nil
+	}
+	conn, err := c.dialRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := oim.NewRegistryClient(conn).ReleaseLease(ctx, &oim.ReleaseLeaseRequest{
+		VolumeId: volumeID,
+	}); err != nil {
+		s := status.Convert(err)
+		return status.Errorf(s.Code(), "release lease for volume %s: %s", volumeID, s.Message())
+	}
+	return nil
+}
@@ -0,0 +1,464 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// ProvisionLVol creates or deletes a logical volume.
+func (c *Controller) ProvisionLVol(ctx context.Context, in *oim.ProvisionLVolRequest) (*oim.ProvisionLVolReply, error) {
+	lvolStore := in.GetLvolStore()
+	volumeID := in.GetVolumeId()
+	if err := checkLVolArgs(lvolStore, volumeID); err != nil {
+		return nil, err
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+
+	// Serialize by volume.
+	c.volumeMutex.LockKey(volumeID)
+	defer c.volumeMutex.UnlockKey(volumeID)
+
+	bdev, err := c.getLVol(ctx, lvolStore, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	size := in.Size_
+	if size == 0 {
+		if bdev != nil {
+			if bdev.DriverSpecific.LVol.Snapshot {
+				return nil, status.Errorf(codes.FailedPrecondition, "%s is a snapshot", lvolAlias(lvolStore, volumeID))
+			}
+			if err := spdk.DestroyLVolBDev(ctx, c.SPDK, spdk.DestroyLVolBDevArgs{Name: bdev.Name}); err != nil {
+				return nil, errors.Wrap(err, "DestroyLVolBDev")
+			}
+		}
+		return &oim.ProvisionLVolReply{}, nil
+	}
+	if bdev != nil {
+		// Check that the existing volume is compatible.
+		actualSize := bdev.NumBlocks * bdev.BlockSize
+		if bdev.DriverSpecific.LVol.Snapshot {
+			return nil, status.Errorf(codes.AlreadyExists, "%s is a snapshot", lvolAlias(lvolStore, volumeID))
+		}
+		if actualSize < size {
+			return nil, status.Errorf(codes.AlreadyExists, "existing logical volume %s has smaller size %d", lvolAlias(lvolStore, volumeID), actualSize)
+		}
+		return &oim.ProvisionLVolReply{Size_: actualSize}, nil
+	}
+
+	args := spdk.ConstructLVolBDevArgs{
+		LVSName:  lvolStore,
+		LVolName: volumeID,
+		Size:     size,
+	}
+	name, err := spdk.ConstructLVolBDev(ctx, c.SPDK, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "ConstructLVolBDev")
+	}
+	actualSize, err := c.bdevSize(ctx, string(name))
+	if err != nil {
+		return nil, err
+	}
+	return &oim.ProvisionLVolReply{Size_: actualSize}, nil
+}
+
+// CreateSnapshot creates a snapshot of a logical volume.
+func (c *Controller) CreateSnapshot(ctx context.Context, in *oim.CreateSnapshotRequest) (*oim.CreateSnapshotReply, error) {
+	lvolStore := in.GetLvolStore()
+	snapshotID := in.GetSnapshotId()
+	sourceVolumeID := in.GetSourceVolumeId()
+	if err := checkLVolArgs(lvolStore, snapshotID); err != nil {
+		return nil, err
+	}
+	if sourceVolumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty source volume ID")
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+
+	// Serialize by snapshot.
+	c.volumeMutex.LockKey(snapshotID)
+	defer c.volumeMutex.UnlockKey(snapshotID)
+
+	lvols, err := c.getLVols(ctx, lvolStore)
+	if err != nil {
+		return nil, err
+	}
+	if existing, ok := lvols[snapshotID]; ok {
+		if !existing.DriverSpecific.LVol.Snapshot {
+			return nil, status.Errorf(codes.AlreadyExists, "%s is not a snapshot", lvolAlias(lvolStore, snapshotID))
+		}
+		if source := snapshotSource(lvols, snapshotID); source != sourceVolumeID {
+			return nil, status.Errorf(codes.AlreadyExists, "existing snapshot %s has source volume %q", lvolAlias(lvolStore, snapshotID), source)
+		}
+		return &oim.CreateSnapshotReply{Snapshot: c.snapshotInfo(lvols, snapshotID)}, nil
+	}
+	if source, ok := lvols[sourceVolumeID]; !ok || source.DriverSpecific.LVol.Snapshot {
+		return nil, status.Errorf(codes.NotFound, "logical volume %s not found", lvolAlias(lvolStore, sourceVolumeID))
+	}
+
+	args := spdk.SnapshotLVolBDevArgs{
+		LVolName:     lvolAlias(lvolStore, sourceVolumeID),
+		SnapshotName: snapshotID,
+	}
+	if _, err := spdk.SnapshotLVolBDev(ctx, c.SPDK, args); err != nil {
+		return nil, errors.Wrap(err, "SnapshotLVolBDev")
+	}
+	lvols, err = c.getLVols(ctx, lvolStore)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := lvols[snapshotID]; !ok {
+		return nil, errors.Errorf("new snapshot %s not found", lvolAlias(lvolStore, snapshotID))
+	}
+	return &oim.CreateSnapshotReply{Snapshot: c.snapshotInfo(lvols, snapshotID)}, nil
+}
+
+// DeleteSnapshot removes a snapshot. Volumes based on it remain
+// usable.
+func (c *Controller) DeleteSnapshot(ctx context.Context, in *oim.DeleteSnapshotRequest) (*oim.DeleteSnapshotReply, error) {
+	lvolStore := in.GetLvolStore()
+	snapshotID := in.GetSnapshotId()
+	if err := checkLVolArgs(lvolStore, snapshotID); err != nil {
+		return nil, err
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+
+	// Serialize by snapshot.
+	c.volumeMutex.LockKey(snapshotID)
+	defer c.volumeMutex.UnlockKey(snapshotID)
+
+	bdev, err := c.getLVol(ctx, lvolStore, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if bdev == nil {
+		// Already gone (idempotency).
+		return &oim.DeleteSnapshotReply{}, nil
+	}
+	if !bdev.DriverSpecific.LVol.Snapshot {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a snapshot", lvolAlias(lvolStore, snapshotID))
+	}
+	if err := spdk.DestroyLVolBDev(ctx, c.SPDK, spdk.DestroyLVolBDevArgs{Name: bdev.Name}); err != nil {
+		return nil, errors.Wrap(err, "DestroyLVolBDev")
+	}
+	c.snapshotTimesMutex.Lock()
+	delete(c.snapshotTimes, bdev.UUID)
+	c.snapshotTimesMutex.Unlock()
+	return &oim.DeleteSnapshotReply{}, nil
+}
+
+// ListSnapshots returns all snapshots in a lvol store, sorted by name.
+func (c *Controller) ListSnapshots(ctx context.Context, in *oim.ListSnapshotsRequest) (*oim.ListSnapshotsReply, error) {
+	lvolStore := in.GetLvolStore()
+	if lvolStore == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty lvol store name")
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+
+	lvols, err := c.getLVols(ctx, lvolStore)
+	if err != nil {
+		return nil, err
+	}
+	reply := &oim.ListSnapshotsReply{}
+	for _, name := range sortedNames(lvols) {
+		if !lvols[name].DriverSpecific.LVol.Snapshot ||
+			in.GetSnapshotId() != "" && in.GetSnapshotId() != name {
+			continue
+		}
+		snapshot := c.snapshotInfo(lvols, name)
+		if in.GetSourceVolumeId() != "" && in.GetSourceVolumeId() != snapshot.SourceVolumeId {
+			continue
+		}
+		reply.Snapshots = append(reply.Snapshots, snapshot)
+	}
+	return reply, nil
+}
+
+// CloneVolume creates a new logical volume from a snapshot or
+// another volume. The new volume gets inflated, i.e. it does not
+// depend on its source.
+func (c *Controller) CloneVolume(ctx context.Context, in *oim.CloneVolumeRequest) (*oim.CloneVolumeReply, error) {
+	lvolStore := in.GetLvolStore()
+	volumeID := in.GetVolumeId()
+	snapshotID := in.GetSnapshotId()
+	sourceVolumeID := in.GetSourceVolumeId()
+	if err := checkLVolArgs(lvolStore, volumeID); err != nil {
+		return nil, err
+	}
+	if (snapshotID == "") == (sourceVolumeID == "") {
+		return nil, status.Error(codes.InvalidArgument, "need either snapshot ID or source volume ID")
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+
+	// Serialize by volume.
+	c.volumeMutex.LockKey(volumeID)
+	defer c.volumeMutex.UnlockKey(volumeID)
+
+	lvols, err := c.getLVols(ctx, lvolStore)
+	if err != nil {
+		return nil, err
+	}
+	if existing, ok := lvols[volumeID]; ok {
+		// A previous call might have succeeded. We cannot check
+		// the content, only the type and size.
+		actualSize := existing.NumBlocks * existing.BlockSize
+		if existing.DriverSpecific.LVol.Snapshot || actualSize < in.Size_ {
+			return nil, status.Errorf(codes.AlreadyExists, "incompatible logical volume %s already exists", lvolAlias(lvolStore, volumeID))
+		}
+		return &oim.CloneVolumeReply{Size_: actualSize}, nil
+	}
+
+	if snapshotID != "" {
+		if snapshot, ok := lvols[snapshotID]; !ok || !snapshot.DriverSpecific.LVol.Snapshot {
+			return nil, status.Errorf(codes.NotFound, "snapshot %s not found", lvolAlias(lvolStore, snapshotID))
+		}
+	} else {
+		if source, ok := lvols[sourceVolumeID]; !ok || source.DriverSpecific.LVol.Snapshot {
+			return nil, status.Errorf(codes.NotFound, "logical volume %s not found", lvolAlias(lvolStore, sourceVolumeID))
+		}
+		// Volumes can only be cloned through a snapshot. We
+		// create a temporary one with a name that is derived
+		// from the new volume, so a retry after a failure
+		// finds and reuses it.
+		snapshotID = fmt.Sprintf("clone-%x", sha256.Sum256([]byte(volumeID)))[:38]
+		if _, ok := lvols[snapshotID]; !ok {
+			args := spdk.SnapshotLVolBDevArgs{
+				LVolName:     lvolAlias(lvolStore, sourceVolumeID),
+				SnapshotName: snapshotID,
+			}
+			if _, err := spdk.SnapshotLVolBDev(ctx, c.SPDK, args); err != nil {
+				return nil, errors.Wrap(err, "SnapshotLVolBDev")
+			}
+		}
+		defer func() {
+			args := spdk.DestroyLVolBDevArgs{Name: lvolAlias(lvolStore, snapshotID)}
+			if err := spdk.DestroyLVolBDev(ctx, c.SPDK, args); err != nil {
+				log.FromContext(ctx).Errorw("removing temporary snapshot", "snapshot", args.Name, "error", err)
+			}
+		}()
+	}
+
+	args := spdk.CloneLVolBDevArgs{
+		SnapshotName: lvolAlias(lvolStore, snapshotID),
+		CloneName:    volumeID,
+	}
+	name, err := spdk.CloneLVolBDev(ctx, c.SPDK, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "CloneLVolBDev")
+	}
+	fail := func(err error) (*oim.CloneVolumeReply, error) {
+		if err2 := spdk.DestroyLVolBDev(ctx, c.SPDK, spdk.DestroyLVolBDevArgs{Name: string(name)}); err2 != nil {
+			log.FromContext(ctx).Errorw("removing incomplete clone", "volume", lvolAlias(lvolStore, volumeID), "error", err2)
+		}
+		return nil, err
+	}
+	if err := spdk.InflateLVolBDev(ctx, c.SPDK, spdk.InflateLVolBDevArgs{Name: string(name)}); err != nil {
+		return fail(errors.Wrap(err, "InflateLVolBDev"))
+	}
+	actualSize, err := c.bdevSize(ctx, string(name))
+	if err != nil {
+		return fail(err)
+	}
+	if actualSize < in.Size_ {
+		if err := spdk.ResizeLVolBDev(ctx, c.SPDK, spdk.ResizeLVolBDevArgs{Name: string(name), Size: in.Size_}); err != nil {
+			return fail(errors.Wrap(err, "ResizeLVolBDev"))
+		}
+		if actualSize, err = c.bdevSize(ctx, string(name)); err != nil {
+			return fail(err)
+		}
+	}
+	return &oim.CloneVolumeReply{Size_: actualSize}, nil
+}
+
+// ResizeVolume grows a logical volume. SPDK notifies users of the
+// BDev about the new size, but the host still has to rescan the
+// device before it becomes visible there.
+func (c *Controller) ResizeVolume(ctx context.Context, in *oim.ResizeVolumeRequest) (*oim.ResizeVolumeReply, error) {
+	lvolStore := in.GetLvolStore()
+	volumeID := in.GetVolumeId()
+	if err := checkLVolArgs(lvolStore, volumeID); err != nil {
+		return nil, err
+	}
+	if in.Size_ <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "size must be positive")
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+
+	// Serialize by volume.
+	c.volumeMutex.LockKey(volumeID)
+	defer c.volumeMutex.UnlockKey(volumeID)
+
+	bdev, err := c.getLVol(ctx, lvolStore, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	if bdev == nil {
+		return nil, status.Errorf(codes.NotFound, "logical volume %s not found", lvolAlias(lvolStore, volumeID))
+	}
+	if bdev.DriverSpecific.LVol.Snapshot {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is a snapshot", lvolAlias(lvolStore, volumeID))
+	}
+	actualSize := bdev.NumBlocks * bdev.BlockSize
+	if actualSize >= in.Size_ {
+		return &oim.ResizeVolumeReply{Size_: actualSize}, nil
+	}
+	if err := spdk.ResizeLVolBDev(ctx, c.SPDK, spdk.ResizeLVolBDevArgs{Name: bdev.Name, Size: in.Size_}); err != nil {
+		return nil, errors.Wrap(err, "ResizeLVolBDev")
+	}
+	if actualSize, err = c.bdevSize(ctx, bdev.Name); err != nil {
+		return nil, err
+	}
+	return &oim.ResizeVolumeReply{Size_: actualSize}, nil
+}
+
+func checkLVolArgs(lvolStore, name string) error {
+	if lvolStore == "" {
+		return status.Error(codes.InvalidArgument, "empty lvol store name")
+	}
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "empty logical volume name")
+	}
+	if len(name) > spdk.LVolNameMaxLength {
+		return status.Errorf(codes.InvalidArgument, "logical volume name %q longer than %d bytes", name, spdk.LVolNameMaxLength)
+	}
+	return nil
+}
+
+// lvolAlias returns the alias under which SPDK knows a logical
+// volume. The actual BDev name is a UUID.
+func lvolAlias(lvolStore, name string) string {
+	return lvolStore + "/" + name
+}
+
+// getLVol returns the BDev of a logical volume, nil if not found.
+func (c *Controller) getLVol(ctx context.Context, lvolStore, name string) (*spdk.BDev, error) {
+	alias := lvolAlias(lvolStore, name)
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: alias})
+	if err != nil {
+		if spdk.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "GetBDevs %s", alias)
+	}
+	if len(bdevs) != 1 || bdevs[0].DriverSpecific.LVol == nil {
+		return nil, errors.Errorf("BDev %s is not a logical volume", alias)
+	}
+	return &bdevs[0], nil
+}
+
+// getLVols returns all logical volumes in the lvol store, indexed by
+// their name inside that store.
+func (c *Controller) getLVols(ctx context.Context, lvolStore string) (map[string]spdk.BDev, error) {
+	stores, err := spdk.GetLVolStores(ctx, c.SPDK, spdk.GetLVolStoresArgs{LVSName: lvolStore})
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetLVolStores %s", lvolStore)
+	}
+	if len(stores) != 1 {
+		return nil, errors.Errorf("expected one lvol store %s, got: %v", lvolStore, stores)
+	}
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetBDevs")
+	}
+	prefix := lvolAlias(lvolStore, "")
+	lvols := map[string]spdk.BDev{}
+	for _, bdev := range bdevs {
+		if bdev.DriverSpecific.LVol == nil || bdev.DriverSpecific.LVol.LVolStoreUUID != stores[0].UUID {
+			continue
+		}
+		for _, alias := range bdev.Aliases {
+			if strings.HasPrefix(alias, prefix) {
+				lvols[strings.TrimPrefix(alias, prefix)] = bdev
+			}
+		}
+	}
+	return lvols, nil
+}
+
+func (c *Controller) bdevSize(ctx context.Context, name string) (int64, error) {
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: name})
+	if err != nil {
+		return 0, errors.Wrapf(err, "GetBDevs %s", name)
+	}
+	if len(bdevs) != 1 {
+		return 0, errors.Errorf("expected one BDev %s, got: %v", name, bdevs)
+	}
+	return bdevs[0].NumBlocks * bdevs[0].BlockSize, nil
+}
+
+// snapshotSource finds the volume that a snapshot was created from
+// by following the chain of clones until it reaches a volume which
+// is not itself a snapshot. Because CloneVolume decouples new
+// volumes from their source, that volume is unique.
+func snapshotSource(lvols map[string]spdk.BDev, name string) string {
+	for _, clone := range lvols[name].DriverSpecific.LVol.Clones {
+		bdev, ok := lvols[clone]
+		if !ok {
+			continue
+		}
+		if !bdev.DriverSpecific.LVol.Snapshot {
+			return clone
+		}
+		if source := snapshotSource(lvols, clone); source != "" {
+			return source
+		}
+	}
+	return ""
+}
+
+func (c *Controller) snapshotInfo(lvols map[string]spdk.BDev, name string) *oim.Snapshot {
+	bdev := lvols[name]
+	c.snapshotTimesMutex.Lock()
+	defer c.snapshotTimesMutex.Unlock()
+	creationTime, ok := c.snapshotTimes[bdev.UUID]
+	if !ok {
+		creationTime = time.Now().UnixNano()
+		c.snapshotTimes[bdev.UUID] = creationTime
+	}
+	return &oim.Snapshot{
+		SnapshotId:     name,
+		SourceVolumeId: snapshotSource(lvols, name),
+		Size_:          bdev.NumBlocks * bdev.BlockSize,
+		CreationTime:   creationTime,
+	}
+}
+
+func sortedNames(lvols map[string]spdk.BDev) []string {
+	var names []string
+	for name := range lvols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spdk"
+)
+
+// watchBDevs starts watching SPDK notifications in the background
+// until stop is closed. While SPDK cannot be reached, it keeps
+// trying. Without notification support in SPDK, only the periodic
+// reconciliation detects deleted BDevs.
+func (c *Controller) watchBDevs(stop <-chan interface{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer cancel()
+		var notifications <-chan spdk.Notification
+		for {
+			var err error
+			notifications, err = spdk.Watch(ctx, c.SPDK, c.notificationInterval)
+			if err == nil {
+				break
+			}
+			if spdk.IsJSONError(err, 0) {
+				log.FromContext(ctx).Infow("not watching SPDK notifications", "error", err)
+				return
+			}
+			log.FromContext(ctx).Debugw("SPDK notifications not available yet", "error", err)
+			select {
+			case <-stop:
+				return
+			case <-time.After(c.notificationInterval):
+			}
+		}
+		for {
+			select {
+			case <-stop:
+				cancel()
+				// Wait for spdk.Watch to finish.
+				for range notifications {
+				}
+				return
+			case notification, ok := <-notifications:
+				if !ok {
+					return
+				}
+				if notification.Type == spdk.NotificationBDevUnregister {
+					c.bdevUnregistered(ctx, notification.Ctx)
+				}
+			}
+		}
+	}()
+}
+
+// bdevUnregistered checks whether the BDev was used by a mapped
+// volume. UnmapVolume also deletes BDevs, but it holds the volume
+// mutex until the mapping is forgotten, so such BDevs are ignored.
+// If the BDev was deleted by someone else, the SCSI target and the
+// BDevs that were below or on top of the deleted one are left
+// behind, therefore the controller looks for orphans right away.
+// Once no SCSI target refers to the volume anymore, it is no longer
+// mapped and its lease gets released.
+func (c *Controller) bdevUnregistered(ctx context.Context, bdevName string) {
+	c.handoverMutex.Lock()
+	volumeID, ok := c.mappedBDevs[bdevName]
+	c.handoverMutex.Unlock()
+	if !ok {
+		return
+	}
+
+	c.volumeMutex.LockKey(volumeID)
+	c.handoverMutex.Lock()
+	ok = c.mappedBDevs[bdevName] == volumeID
+	c.handoverMutex.Unlock()
+	if ok {
+		// The volume might have been mapped again in the
+		// meantime.
+		_, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: bdevName})
+		ok = spdk.IsNotFound(err)
+	}
+	c.volumeMutex.UnlockKey(volumeID)
+	if !ok {
+		return
+	}
+
+	log.FromContext(ctx).Warnw("BDev of mapped volume was deleted outside of OIM", "volume", volumeID, "bdev", bdevName)
+	c.reconcile(ctx, c.orphanGracePeriod)
+
+	c.volumeMutex.LockKey(volumeID)
+	defer c.volumeMutex.UnlockKey(volumeID)
+	c.handoverMutex.Lock()
+	_, mapped := c.mappings[volumeID]
+	c.handoverMutex.Unlock()
+	if !mapped {
+		return
+	}
+	attached, err := c.volumeAttached(ctx, volumeID)
+	if err != nil {
+		log.FromContext(ctx).Errorw("checking volume with deleted BDev", "volume", volumeID, "error", err)
+		return
+	}
+	if attached {
+		// Orphans are only logged or still in their grace
+		// period.
+		return
+	}
+	if err := c.releaseLease(ctx, volumeID); err != nil {
+		log.FromContext(ctx).Errorw("releasing lease of volume with deleted BDev", "volume", volumeID, "error", err)
+		return
+	}
+	c.forgetMapping(volumeID)
+	c.saveConfig(ctx)
+	log.FromContext(ctx).Infow("volume with deleted BDev no longer mapped", "volume", volumeID)
+}
+
+// volumeAttached checks whether a SCSI target of the controller
+// still has a LUN for one of the BDevs of the mapped volume.
+func (c *Controller) volumeAttached(ctx context.Context, volumeID string) (bool, error) {
+	bdevNames := map[string]bool{}
+	c.handoverMutex.Lock()
+	for bdevName, id := range c.mappedBDevs {
+		if id == volumeID {
+			bdevNames[bdevName] = true
+		}
+	}
+	c.handoverMutex.Unlock()
+
+	controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+	if err != nil {
+		return false, errors.Wrap(err, "GetVHostControllers")
+	}
+	for _, controller := range controllers {
+		if _, ours := c.vhostFor(controller.Controller); !ours {
+			continue
+		}
+		scsi, ok := controller.BackendSpecific["scsi"].(spdk.SCSIControllerSpecific)
+		if !ok {
+			continue
+		}
+		for _, target := range scsi {
+			for _, lun := range target.LUNs {
+				if bdevNames[lun.BDevName] {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
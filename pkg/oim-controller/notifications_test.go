@@ -0,0 +1,125 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/oim-controller"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BDev notifications", func() {
+	// Only notifications can trigger the removal of
+	// orphans, the periodic check is disabled.
+	sim := simulate(nil,
+		oimcontroller.WithReconcileInterval(0),
+		oimcontroller.WithOrphanGracePeriod(0),
+		oimcontroller.WithDeleteOrphans(true),
+		oimcontroller.WithNotificationInterval(10*time.Millisecond))
+
+	JustBeforeEach(func() {
+		err := sim.c.Start()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	mapCeph := func(volumeID string, crypto *oim.CryptoParams) {
+		_, err := sim.c.MapVolume(context.Background(), &oim.MapVolumeRequest{
+			VolumeId: volumeID,
+			Params: &oim.MapVolumeRequest_Ceph{
+				Ceph: &oim.CephParams{
+					UserId:   "kubernetes",
+					Secret:   "my-secret",
+					Monitors: "192.168.7.2:6789",
+					Pool:     "rbd",
+					Image:    volumeID,
+				},
+			},
+			Crypto: crypto,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should clean up after an externally deleted BDev", func() {
+		mapCeph("volume-0", &oim.CryptoParams{Key: "0123456789abcdef"})
+		mapCeph("volume-1", nil)
+		Expect(sim.simulated.BDevNames()).To(Equal([]string{"crypto-volume-0", "volume-0", "volume-1"}))
+
+		By("deleting the crypto BDev")
+		sim.simulated.DeleteBDev("crypto-volume-0")
+
+		By("waiting for the RBD BDev below it to be removed")
+		Eventually(sim.simulated.BDevNames, 10*time.Second).Should(Equal([]string{"volume-1"}))
+		Expect(sim.simulated.Targets(simulatedVHost)).To(HaveLen(1), "other volume still mapped")
+
+		By("waiting for the controller to forget the volume")
+		Eventually(func() codes.Code {
+			_, err := sim.c.QuiesceVolume(context.Background(), &oim.QuiesceVolumeRequest{VolumeId: "volume-0"})
+			return status.Code(err)
+		}, 10*time.Second).Should(Equal(codes.NotFound))
+	})
+
+	It("should ignore BDevs deleted by UnmapVolume", func() {
+		ctx := context.Background()
+		mapCeph("volume-0", nil)
+		mapCeph("volume-1", nil)
+		_, err := sim.c.UnmapVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: "volume-0"})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("mapping the volume again")
+		mapCeph("volume-0", nil)
+		Consistently(sim.simulated.BDevNames, time.Second).Should(Equal([]string{"volume-0", "volume-1"}))
+		Expect(sim.simulated.Targets(simulatedVHost)).To(HaveLen(2))
+	})
+
+	Context("with SPDK unreachable at startup", func() {
+		var watching chan interface{}
+
+		BeforeEach(func() {
+			watching = make(chan interface{})
+			var mutex sync.Mutex
+			calls := 0
+			sim.simulated.SetHook(func(method string, params json.RawMessage) error {
+				if method != "get_notifications" {
+					return nil
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				calls++
+				switch calls {
+				case 1:
+					return errors.New("connection lost")
+				case 2:
+					close(watching)
+				}
+				return nil
+			})
+		})
+
+		It("should start watching once SPDK is back", func() {
+			Eventually(watching, 10*time.Second).Should(BeClosed())
+			sim.simulated.SetHook(nil)
+			mapCeph("volume-0", &oim.CryptoParams{Key: "0123456789abcdef"})
+
+			By("deleting the crypto BDev")
+			sim.simulated.DeleteBDev("crypto-volume-0")
+
+			By("waiting for the RBD BDev below it to be removed")
+			Eventually(sim.simulated.BDevNames, 10*time.Second).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,162 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// operationRetention is how long completed operations can still be
+// queried with GetOperation.
+const operationRetention = 10 * time.Minute
+
+// operationTimeout limits how long an operation may run. It only
+// matters when SPDK hangs, because then the operation would
+// otherwise block the volume forever.
+const operationTimeout = 5 * time.Minute
+
+// operation is a MapVolume or UnmapVolume call which runs
+// independently of the gRPC call that started it.
+type operation struct {
+	id       string
+	method   string
+	volumeID string
+	done     chan interface{}
+
+	// The request which started the operation, only set while
+	// it is pending. It may contain secrets.
+	request proto.Message
+
+	// Only valid once done is closed. The result is the reply
+	// of the method.
+	result   interface{}
+	err      error
+	finished time.Time
+}
+
+// wait blocks until the operation is done or the context expires.
+func (op *operation) wait(ctx context.Context) error {
+	select {
+	case <-op.done:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// isDone checks without blocking.
+func (op *operation) isDone() bool {
+	select {
+	case <-op.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// startOperation runs the function in the background, unless an
+// operation for the same method and volume is already running. In
+// that case the existing operation is returned if it was started
+// with the same request, otherwise the call fails with ABORTED. The
+// request must not contain fields like async which are allowed to
+// differ between retries.
+func (c *Controller) startOperation(ctx context.Context, method, volumeID string, request proto.Message, run func(ctx context.Context) (interface{}, error)) (*operation, error) {
+	c.operationsMutex.Lock()
+	defer c.operationsMutex.Unlock()
+
+	key := method + "/" + volumeID
+	if op := c.pendingOperations[key]; op != nil {
+		if !proto.Equal(op.request, request) {
+			return nil, status.Errorf(codes.Aborted, "operation %s for volume %s is running with different parameters", op.id, volumeID)
+		}
+		log.FromContext(ctx).Infow("waiting for running operation", "operation", op.id, "method", method, "volume", volumeID)
+		return op, nil
+	}
+
+	// Forget about old operations.
+	now := time.Now()
+	for id, op := range c.operations {
+		if op.isDone() && now.Sub(op.finished) > operationRetention {
+			delete(c.operations, id)
+		}
+	}
+
+	c.operationCounter++
+	op := &operation{
+		id:       fmt.Sprintf("%x-%d", c.operationEpoch, c.operationCounter),
+		method:   method,
+		volumeID: volumeID,
+		done:     make(chan interface{}),
+		request:  request,
+	}
+	c.operations[op.id] = op
+	c.pendingOperations[key] = op
+
+	// The operation must not be canceled together with the call
+	// which started it.
+	opCtx, cancel := context.WithTimeout(log.WithLogger(context.Background(), log.FromContext(ctx).With("operation", op.id)), operationTimeout)
+	go func() {
+		result, err := run(opCtx)
+		cancel()
+		c.operationsMutex.Lock()
+		defer c.operationsMutex.Unlock()
+		op.result = result
+		op.err = err
+		op.finished = time.Now()
+		op.request = nil
+		delete(c.pendingOperations, key)
+		close(op.done)
+	}()
+	return op, nil
+}
+
+// GetOperation implements oim.Controller.GetOperation.
+func (c *Controller) GetOperation(ctx context.Context, in *oim.GetOperationRequest) (*oim.GetOperationReply, error) {
+	c.operationsMutex.Lock()
+	op := c.operations[in.GetOperationId()]
+	c.operationsMutex.Unlock()
+	if op == nil {
+		return nil, status.Errorf(codes.NotFound, "operation %q not found", in.GetOperationId())
+	}
+	if in.GetWait() {
+		if err := op.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	reply := &oim.GetOperationReply{
+		Operation: &oim.Operation{
+			OperationId: op.id,
+			Method:      op.method,
+			VolumeId:    op.volumeID,
+		},
+	}
+	if op.isDone() {
+		reply.Operation.Done = true
+		if op.err != nil {
+			s := status.Convert(op.err)
+			reply.Operation.ErrorCode = int32(s.Code())
+			reply.Operation.ErrorMessage = s.Message()
+		} else {
+			switch result := op.result.(type) {
+			case *oim.MapVolumeReply:
+				reply.Operation.Result = &oim.Operation_MapVolume{MapVolume: result}
+			case *oim.UnmapVolumeReply:
+				reply.Operation.Result = &oim.Operation_UnmapVolume{UnmapVolume: result}
+			}
+		}
+	}
+	return reply, nil
+}
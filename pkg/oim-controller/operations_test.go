@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("operations", func() {
+	sim := simulate(nil)
+
+	It("should run operations asynchronously", func() {
+		ctx := context.Background()
+		sim.simulated.AddMallocBDev("volume-0")
+		reply, err := sim.c.MapVolume(ctx, &oim.MapVolumeRequest{
+			VolumeId: "volume-0",
+			Params: &oim.MapVolumeRequest_Malloc{
+				Malloc: &oim.MallocParams{},
+			},
+			Async: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetOperationId()).NotTo(BeEmpty())
+		Expect(reply.GetScsiDisk()).To(BeNil())
+
+		op, err := sim.c.GetOperation(ctx, &oim.GetOperationRequest{OperationId: reply.GetOperationId(), Wait: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(op.GetOperation().GetDone()).To(BeTrue())
+		Expect(op.GetOperation().GetMethod()).To(Equal("MapVolume"))
+		Expect(op.GetOperation().GetVolumeId()).To(Equal("volume-0"))
+		Expect(op.GetOperation().GetErrorCode()).To(Equal(int32(codes.OK)))
+		Expect(op.GetOperation().GetMapVolume().GetScsiDisk().GetTarget()).To(Equal(uint32(0)))
+
+		By("reporting errors")
+		reply, err = sim.c.MapVolume(ctx, &oim.MapVolumeRequest{
+			VolumeId: "no-such-bdev",
+			Async:    true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		op, err = sim.c.GetOperation(ctx, &oim.GetOperationRequest{OperationId: reply.GetOperationId(), Wait: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(op.GetOperation().GetDone()).To(BeTrue())
+		Expect(op.GetOperation().GetErrorCode()).NotTo(Equal(int32(codes.OK)))
+		Expect(op.GetOperation().GetResult()).To(BeNil())
+
+		By("unmapping")
+		unmapReply, err := sim.c.UnmapVolume(ctx, &oim.UnmapVolumeRequest{VolumeId: "volume-0", Async: true})
+		Expect(err).NotTo(HaveOccurred())
+		op, err = sim.c.GetOperation(ctx, &oim.GetOperationRequest{OperationId: unmapReply.GetOperationId(), Wait: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(op.GetOperation().GetDone()).To(BeTrue())
+		Expect(op.GetOperation().GetUnmapVolume()).NotTo(BeNil())
+
+		By("looking up an unknown operation")
+		_, err = sim.c.GetOperation(ctx, &oim.GetOperationRequest{OperationId: "no-such-operation"})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("should attach retries to the running operation", func() {
+		ctx := context.Background()
+		sim.simulated.AddMallocBDev("volume-0")
+		request := &oim.MapVolumeRequest{
+			VolumeId: "volume-0",
+			Params: &oim.MapVolumeRequest_Malloc{
+				Malloc: &oim.MallocParams{},
+			},
+			Async: true,
+		}
+
+		By("blocking SPDK")
+		blocked := make(chan interface{})
+		release := make(chan interface{})
+		sim.simulated.SetHook(func(method string, params json.RawMessage) error {
+			if method == "get_vhost_controllers" {
+				close(blocked)
+				<-release
+			}
+			return nil
+		})
+		reply, err := sim.c.MapVolume(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		<-blocked
+		sim.simulated.SetHook(nil)
+
+		By("retrying asynchronously")
+		retry, err := sim.c.MapVolume(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry.GetOperationId()).To(Equal(reply.GetOperationId()))
+
+		By("retrying synchronously")
+		type result struct {
+			reply *oim.MapVolumeReply
+			err   error
+		}
+		results := make(chan result)
+		go func() {
+			defer GinkgoRecover()
+			sync := *request
+			sync.Async = false
+			reply, err := sim.c.MapVolume(ctx, &sync)
+			results <- result{reply, err}
+		}()
+		Consistently(results).ShouldNot(Receive(), "operation still blocked")
+
+		By("retrying with different parameters")
+		different := *request
+		different.Force = true
+		_, err = sim.c.MapVolume(ctx, &different)
+		Expect(status.Code(err)).To(Equal(codes.Aborted), "different request: %v", err)
+
+		By("waiting with a canceled context")
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = sim.c.GetOperation(canceled, &oim.GetOperationRequest{OperationId: reply.GetOperationId(), Wait: true})
+		Expect(status.Code(err)).To(Equal(codes.Canceled), "canceled wait: %v", err)
+
+		By("unblocking SPDK")
+		close(release)
+		var r result
+		Eventually(results).Should(Receive(&r))
+		Expect(r.err).NotTo(HaveOccurred())
+		Expect(r.reply.GetScsiDisk()).To(Equal(&oim.SCSIDisk{}))
+		Expect(sim.simulated.Targets(simulatedVHost)).To(HaveLen(1))
+	})
+})
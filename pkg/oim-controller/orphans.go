@@ -0,0 +1,335 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// rbdProductName is how SPDK identifies Ceph BDevs.
+const rbdProductName = "Ceph Rbd Disk"
+
+// ListOrphans implements oim.Controller.ListOrphans.
+func (c *Controller) ListOrphans(ctx context.Context, in *oim.ListOrphansRequest) (*oim.ListOrphansReply, error) {
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+	orphans, err := c.findOrphans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &oim.ListOrphansReply{Orphans: orphans}, nil
+}
+
+// CollectGarbage implements oim.Controller.CollectGarbage.
+func (c *Controller) CollectGarbage(ctx context.Context, in *oim.CollectGarbageRequest) (*oim.CollectGarbageReply, error) {
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+	gracePeriod := c.orphanGracePeriod
+	if in.GetForce() {
+		gracePeriod = 0
+	}
+	removed, err := c.collectGarbage(ctx, gracePeriod)
+	if err != nil {
+		return nil, err
+	}
+	return &oim.CollectGarbageReply{Removed: removed}, nil
+}
+
+// reconcile looks for orphans, logs them and removes them if
+// enabled. The grace period protects against removing something
+// that is in use by a MapVolume or UnmapVolume call which is
+// still in progress.
+func (c *Controller) reconcile(ctx context.Context, gracePeriod time.Duration) {
+	if !c.deleteOrphans {
+		orphans, err := c.findOrphans(ctx)
+		if err != nil {
+			log.FromContext(ctx).Errorw("looking for orphans", "error", err)
+			return
+		}
+		for _, orphan := range orphans {
+			log.FromContext(ctx).Warnw("found orphan", "orphan", orphan)
+		}
+		return
+	}
+	removed, err := c.collectGarbage(ctx, gracePeriod)
+	for _, orphan := range removed {
+		log.FromContext(ctx).Infow("removed orphan", "orphan", orphan)
+	}
+	if err != nil {
+		log.FromContext(ctx).Errorw("collecting garbage", "error", err)
+	}
+}
+
+// findOrphans takes an inventory of SPDK and returns all orphans.
+// It remembers when each orphan was seen for the first time.
+func (c *Controller) findOrphans(ctx context.Context) ([]*oim.Orphan, error) {
+	current, err := c.inventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.orphansMutex.Lock()
+	defer c.orphansMutex.Unlock()
+	now := time.Now().UnixNano()
+	seen := map[string]*oim.Orphan{}
+	var orphans []*oim.Orphan
+	for key, orphan := range current {
+		if old, ok := c.orphans[key]; ok {
+			orphan.FirstSeen = old.FirstSeen
+		} else {
+			orphan.FirstSeen = now
+		}
+		seen[key] = orphan
+		orphans = append(orphans, orphan)
+	}
+	// Forget about orphans which are gone.
+	c.orphans = seen
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphanKey(orphans[i]) < orphanKey(orphans[j])
+	})
+	return orphans, nil
+}
+
+// inventory returns the current orphans without FirstSeen.
+func (c *Controller) inventory(ctx context.Context) (map[string]*oim.Orphan, error) {
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetBDevs")
+	}
+	controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetVHostControllers")
+	}
+
+	orphans := map[string]*oim.Orphan{}
+	add := func(orphan *oim.Orphan) {
+		orphans[orphanKey(orphan)] = orphan
+	}
+	existing := map[string]bool{}
+	for _, bdev := range bdevs {
+		existing[bdev.Name] = true
+	}
+	used := map[string]bool{}
+	for _, controller := range controllers {
+		scsi, ok := controller.BackendSpecific["scsi"].(spdk.SCSIControllerSpecific)
+		if !ok {
+			continue
+		}
+		// Targets on controllers that OIM was not configured
+		// with belong to someone else, but their LUNs still
+		// keep BDevs in use.
+		_, ours := c.vhostFor(controller.Controller)
+		for _, target := range scsi {
+			if len(target.LUNs) == 0 && ours {
+				add(&oim.Orphan{
+					Kind:       oim.Orphan_SCSI_TARGET,
+					Name:       controller.Controller,
+					ScsiTarget: target.SCSIDevNum,
+					Reason:     "no LUN",
+				})
+			}
+			for _, lun := range target.LUNs {
+				used[lun.BDevName] = true
+				if !existing[lun.BDevName] && ours {
+					add(&oim.Orphan{
+						Kind:       oim.Orphan_SCSI_TARGET,
+						Name:       controller.Controller,
+						ScsiTarget: target.SCSIDevNum,
+						Reason:     fmt.Sprintf("BDev %s of LUN %d is gone", lun.BDevName, lun.LUN),
+					})
+				}
+			}
+		}
+	}
+	for _, bdev := range bdevs {
+		// Only BDevs created by MapVolume are candidates.
+		// Malloc BDevs and logical volumes get provisioned
+		// separately, RBD BDevs claimed by a crypto or RAID
+		// BDev are removed together with it.
+		if used[bdev.Name] || bdev.Claimed {
+			continue
+		}
+		switch {
+		case bdev.DriverSpecific.Crypto != nil:
+			add(&oim.Orphan{
+				Kind:   oim.Orphan_BDEV,
+				Name:   bdev.Name,
+				Reason: "crypto BDev not attached to any SCSI target",
+			})
+		case bdev.DriverSpecific.RAID != nil:
+			add(&oim.Orphan{
+				Kind:   oim.Orphan_BDEV,
+				Name:   bdev.Name,
+				Reason: "RAID BDev not attached to any SCSI target",
+			})
+		case bdev.ProductName == rbdProductName:
+			add(&oim.Orphan{
+				Kind:   oim.Orphan_BDEV,
+				Name:   bdev.Name,
+				Reason: "RBD BDev not attached to any SCSI target",
+			})
+		}
+	}
+	return orphans, nil
+}
+
+// collectGarbage removes orphans that were found at least
+// gracePeriod ago.
+func (c *Controller) collectGarbage(ctx context.Context, gracePeriod time.Duration) ([]*oim.Orphan, error) {
+	orphans, err := c.findOrphans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var removed []*oim.Orphan
+	defer func() {
+		if len(removed) > 0 {
+			c.saveConfig(ctx)
+		}
+	}()
+	deadline := time.Now().Add(-gracePeriod).UnixNano()
+	for _, orphan := range orphans {
+		if orphan.FirstSeen > deadline {
+			continue
+		}
+		ok, err := c.removeOrphan(ctx, orphan)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed = append(removed, orphan)
+		}
+	}
+	return removed, nil
+}
+
+// removeOrphan removes the orphan if it still is one. It serializes
+// with MapVolume and UnmapVolume through the volume mutex for BDevs
+// and the target mutex for SCSI targets.
+func (c *Controller) removeOrphan(ctx context.Context, orphan *oim.Orphan) (bool, error) {
+	if orphan.Kind == oim.Orphan_BDEV {
+		volumeID := c.volumeOfBDev(orphan.Name)
+		c.volumeMutex.LockKey(volumeID)
+		defer c.volumeMutex.UnlockKey(volumeID)
+	} else {
+		c.targetMutex.Lock()
+		defer c.targetMutex.Unlock()
+	}
+
+	// Check again while holding the lock.
+	current, err := c.inventory(ctx)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := current[orphanKey(orphan)]; !ok {
+		return false, nil
+	}
+
+	switch orphan.Kind {
+	case oim.Orphan_BDEV:
+		bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: orphan.Name})
+		if err != nil {
+			return false, errors.Wrapf(err, "GetBDevs %s", orphan.Name)
+		}
+		if len(bdevs) != 1 {
+			return false, errors.Errorf("expected one BDev %s, got: %v", orphan.Name, bdevs)
+		}
+		if crypto := bdevs[0].DriverSpecific.Crypto; crypto != nil {
+			if err := spdk.DeleteCryptoBDev(ctx, c.SPDK, spdk.DeleteCryptoBDevArgs{Name: orphan.Name}); err != nil {
+				return false, errors.Wrapf(err, "DeleteCryptoBDev %s", orphan.Name)
+			}
+			// Also remove the RBD BDev below it, like UnmapVolume does.
+			base, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: crypto.BaseBDevName})
+			if err == nil && len(base) == 1 && base[0].ProductName == rbdProductName && !base[0].Claimed {
+				if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: crypto.BaseBDevName}); err != nil {
+					return true, errors.Wrapf(err, "DeleteBDev %s", crypto.BaseBDevName)
+				}
+				if err := c.releaseLease(ctx, crypto.BaseBDevName); err != nil {
+					return true, err
+				}
+			}
+			return true, nil
+		}
+		if raid := bdevs[0].DriverSpecific.RAID; raid != nil {
+			if err := c.unmapRAID(ctx, orphan.Name, raid); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: orphan.Name}); err != nil {
+			return false, errors.Wrapf(err, "DeleteBDev %s", orphan.Name)
+		}
+		if bdevs[0].ProductName == rbdProductName {
+			// RBD BDevs are named and leased after their
+			// volume.
+			if err := c.releaseLease(ctx, orphan.Name); err != nil {
+				return true, err
+			}
+		}
+	case oim.Orphan_SCSI_TARGET:
+		args := spdk.RemoveVHostSCSITargetArgs{
+			Controller:    orphan.Name,
+			SCSITargetNum: orphan.ScsiTarget,
+		}
+		if err := spdk.RemoveVHostSCSITarget(ctx, c.SPDK, args); err != nil {
+			return false, errors.Wrapf(err, "RemoveVHostSCSITarget %+v", args)
+		}
+	default:
+		return false, status.Errorf(codes.Internal, "unexpected orphan %v", orphan)
+	}
+	return true, nil
+}
+
+// volumeOfBDev determines which volume a BDev belongs to, for
+// locking. RAID members are created while holding the lock of the
+// RAID volume.
+func (c *Controller) volumeOfBDev(bdevName string) string {
+	c.handoverMutex.Lock()
+	volumeID, ok := c.mappedBDevs[bdevName]
+	c.handoverMutex.Unlock()
+	if ok {
+		return volumeID
+	}
+
+	c.operationsMutex.Lock()
+	defer c.operationsMutex.Unlock()
+	for _, op := range c.pendingOperations {
+		request, ok := op.request.(*oim.MapVolumeRequest)
+		if !ok {
+			continue
+		}
+		for _, member := range request.GetRaid().GetMembers() {
+			if member.GetVolumeId() == bdevName {
+				return request.GetVolumeId()
+			}
+		}
+	}
+
+	return strings.TrimPrefix(bdevName, cryptoBDevName(""))
+}
+
+// orphanKey identifies an orphan independently of when it was found.
+func orphanKey(orphan *oim.Orphan) string {
+	if orphan.Kind == oim.Orphan_SCSI_TARGET {
+		return fmt.Sprintf("%s/%s/%d", orphan.Kind, orphan.Name, orphan.ScsiTarget)
+	}
+	return fmt.Sprintf("%s/%s", orphan.Kind, orphan.Name)
+}
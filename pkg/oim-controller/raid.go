@@ -0,0 +1,194 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// raidDefaultStripSizeKB is used when RaidParams leave the strip
+// size unset.
+const raidDefaultStripSizeKB = 64
+
+// raidMember is a member BDev which was leased or created for a
+// RAID BDev and which therefore has to be cleaned up again.
+type raidMember struct {
+	name    string
+	created bool
+	leased  bool
+}
+
+// mapRAID creates the member BDevs and a RAID BDev with the volume
+// ID as name on top of them. Ceph members get fenced with a lease
+// like a volume, shared when the host only reads. Member BDevs created
+// and leases acquired here are released again when something fails.
+func (c *Controller) mapRAID(ctx context.Context, volumeID string, params *oim.RaidParams, reader, force bool) (finalErr error) {
+	if params.GetLevel() != oim.RaidParams_RAID0 {
+		return status.Errorf(codes.InvalidArgument, "unknown RAID level %s", params.GetLevel())
+	}
+	stripSizeKB := params.GetStripSizeKb()
+	if stripSizeKB == 0 {
+		stripSizeKB = raidDefaultStripSizeKB
+	}
+	if stripSizeKB&(stripSizeKB-1) != 0 {
+		return status.Errorf(codes.InvalidArgument, "strip size %d KiB is not a power of two", stripSizeKB)
+	}
+	members := params.GetMembers()
+	if len(members) < 2 {
+		return status.Errorf(codes.InvalidArgument, "RAID needs at least two members, got %d", len(members))
+	}
+	seen := map[string]bool{volumeID: true}
+	for _, member := range members {
+		if member.GetVolumeId() == "" || seen[member.GetVolumeId()] {
+			return status.Errorf(codes.InvalidArgument, "invalid or duplicate member volume ID %q", member.GetVolumeId())
+		}
+		seen[member.GetVolumeId()] = true
+	}
+
+	var cleanup []raidMember
+	defer func() {
+		if finalErr == nil {
+			return
+		}
+		if err := c.cleanupRAIDMembers(ctx, cleanup); err != nil {
+			log.FromContext(ctx).Errorw("removing RAID members after failure", "volume", volumeID, "error", err)
+		}
+	}()
+	var baseBDevs []string
+	for _, member := range members {
+		name, err := c.mapRAIDMember(ctx, member, reader, force, &cleanup)
+		if err != nil {
+			return err
+		}
+		baseBDevs = append(baseBDevs, name)
+	}
+
+	args := spdk.ConstructRAIDBDevArgs{
+		Name:        volumeID,
+		StripSizeKB: stripSizeKB,
+		RAIDLevel:   spdk.RAID0,
+		BaseBDevs:   baseBDevs,
+	}
+	if err := spdk.ConstructRAIDBDev(ctx, c.SPDK, args); err != nil {
+		return errors.Wrapf(err, "ConstructRAIDBDev %s with %v", volumeID, baseBDevs)
+	}
+	return nil
+}
+
+// mapRAIDMember returns the name of the BDev for the member. A
+// member which gets leased or created is added to cleanup.
+func (c *Controller) mapRAIDMember(ctx context.Context, member *oim.RaidMember, reader, force bool, cleanup *[]raidMember) (string, error) {
+	memberID := member.GetVolumeId()
+	if lvol, ok := member.Params.(*oim.RaidMember_Lvol); ok {
+		bdev, err := c.getLVol(ctx, lvol.Lvol.GetLvolStore(), memberID)
+		if err != nil {
+			return "", err
+		}
+		if bdev == nil {
+			return "", errors.Errorf("no existing logical volume %s found", lvolAlias(lvol.Lvol.GetLvolStore(), memberID))
+		}
+		return bdev.Name, nil
+	}
+	state := raidMember{name: memberID}
+	defer func() {
+		if state.leased || state.created {
+			*cleanup = append(*cleanup, state)
+		}
+	}()
+	if _, ok := member.Params.(*oim.RaidMember_Ceph); ok {
+		// Fence off other controllers before touching the
+		// image, as in MapVolume.
+		acquired, err := c.acquireLease(ctx, memberID, reader, force)
+		if err != nil {
+			return "", err
+		}
+		state.leased = acquired
+	}
+	if _, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: memberID}); err == nil {
+		// Assume that it is the right one, as in MapVolume.
+		log.FromContext(ctx).Infof("reusing existing BDev %s as RAID member", memberID)
+		return memberID, nil
+	} else if !spdk.IsNotFound(err) {
+		return "", errors.Wrapf(err, "GetBDevs %s", memberID)
+	}
+	switch x := member.Params.(type) {
+	case *oim.RaidMember_Malloc:
+		return "", errors.Errorf("no existing MallocBDev with name %s found", memberID)
+	case *oim.RaidMember_Ceph:
+		if err := c.mapCeph(ctx, memberID, x.Ceph); err != nil {
+			return "", err
+		}
+		state.created = true
+		return memberID, nil
+	case nil:
+		return "", errors.Errorf("missing parameters for RAID member %s", memberID)
+	default:
+		return "", errors.Errorf("unsupported params type %T for RAID member %s", x, memberID)
+	}
+}
+
+// unmapRAID removes the RAID BDev, deletes the RBD BDevs among its
+// members and releases their leases. Malloc BDevs and logical
+// volumes are kept. The members are taken from the RAID BDev itself,
+// so this also works after a restart of the controller. When
+// cleaning up a member fails after the RAID BDev is gone, the
+// member becomes an orphan and gets removed by reconciliation.
+func (c *Controller) unmapRAID(ctx context.Context, name string, raid *spdk.RAIDDriverSpecific) error {
+	var members []raidMember
+	for _, member := range raid.BaseBDevsList {
+		if member == "" {
+			// Missing base BDev.
+			continue
+		}
+		bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: member})
+		if err != nil {
+			if spdk.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "GetBDevs %s", member)
+		}
+		if len(bdevs) == 1 && bdevs[0].ProductName == rbdProductName {
+			members = append(members, raidMember{name: member, created: true, leased: true})
+		}
+	}
+	if err := spdk.DestroyRAIDBDev(ctx, c.SPDK, spdk.DestroyRAIDBDevArgs{Name: name}); err != nil {
+		return errors.Wrapf(err, "DestroyRAIDBDev %s", name)
+	}
+	return c.cleanupRAIDMembers(ctx, members)
+}
+
+// cleanupRAIDMembers deletes created member BDevs and then releases
+// the leases. Members which are already gone are okay, so this can
+// be repeated after a partial failure.
+func (c *Controller) cleanupRAIDMembers(ctx context.Context, members []raidMember) error {
+	for _, member := range members {
+		if !member.created {
+			continue
+		}
+		if err := spdk.DeleteBDev(ctx, c.SPDK, spdk.DeleteBDevArgs{Name: member.name}); err != nil && !spdk.IsNotFound(err) {
+			return errors.Wrapf(err, "DeleteBDev %s", member.name)
+		}
+	}
+	for _, member := range members {
+		if !member.leased {
+			continue
+		}
+		if err := c.releaseLease(ctx, member.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller_test
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RAID volumes", func() {
+	sim := simulate(nil)
+
+	BeforeEach(func() {
+		sim.simulated.AddMallocBDev("malloc-0")
+	})
+
+	mapRAID := func(params *oim.RaidParams) (*oim.MapVolumeReply, error) {
+		return sim.c.MapVolume(context.Background(), &oim.MapVolumeRequest{
+			VolumeId: "raid-volume",
+			Params: &oim.MapVolumeRequest_Raid{
+				Raid: params,
+			},
+		})
+	}
+
+	cephMember := &oim.RaidMember{
+		VolumeId: "ceph-1",
+		Params: &oim.RaidMember_Ceph{
+			Ceph: &oim.CephParams{Pool: "rbd", Image: "image-1"},
+		},
+	}
+	mallocMember := &oim.RaidMember{
+		VolumeId: "malloc-0",
+		Params: &oim.RaidMember_Malloc{
+			Malloc: &oim.MallocParams{},
+		},
+	}
+
+	It("should construct and remove members", func() {
+		reply, err := mapRAID(&oim.RaidParams{
+			Members: []*oim.RaidMember{mallocMember, cephMember},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetScsiDisk().GetTarget()).To(Equal(uint32(0)))
+		raid := sim.simulated.BDev("raid-volume")
+		Expect(raid).NotTo(BeNil())
+		Expect(raid.DriverSpecific.RAID).To(Equal(&spdk.RAIDDriverSpecific{
+			StripSizeKB:   64,
+			RAIDLevel:     spdk.RAID0,
+			NumBaseBDevs:  2,
+			BaseBDevsList: []string{"malloc-0", "ceph-1"},
+		}))
+		Expect(sim.simulated.BDevNames()).To(ConsistOf("malloc-0", "ceph-1", "raid-volume"))
+
+		By("mapping again")
+		reply, err = mapRAID(&oim.RaidParams{
+			Members: []*oim.RaidMember{mallocMember, cephMember},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetScsiDisk().GetTarget()).To(Equal(uint32(0)))
+
+		By("unmapping")
+		_, err = sim.c.UnmapVolume(context.Background(), &oim.UnmapVolumeRequest{VolumeId: "raid-volume"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sim.simulated.BDevNames()).To(ConsistOf("malloc-0"))
+	})
+
+	It("should remove members after a restart", func() {
+		_, err := mapRAID(&oim.RaidParams{
+			Members: []*oim.RaidMember{mallocMember, cephMember},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("restarting the controller")
+		sim.c.Close()
+		sim.startController()
+
+		_, err = sim.c.UnmapVolume(context.Background(), &oim.UnmapVolumeRequest{VolumeId: "raid-volume"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sim.simulated.BDevNames()).To(ConsistOf("malloc-0"))
+	})
+
+	It("should clean up after a failure", func() {
+		_, err := mapRAID(&oim.RaidParams{
+			Members: []*oim.RaidMember{cephMember, {
+				VolumeId: "no-such-malloc",
+				Params: &oim.RaidMember_Malloc{
+					Malloc: &oim.MallocParams{},
+				},
+			}},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(sim.simulated.BDevNames()).To(ConsistOf("malloc-0"))
+	})
+
+	It("should reject invalid parameters", func() {
+		members := []*oim.RaidMember{mallocMember, cephMember}
+		params := []struct {
+			params *oim.RaidParams
+			code   codes.Code
+		}{
+			{&oim.RaidParams{Level: oim.RaidParams_Level(1), Members: members}, codes.InvalidArgument},
+			{&oim.RaidParams{StripSizeKb: 48, Members: members}, codes.InvalidArgument},
+			{&oim.RaidParams{Members: members[0:1]}, codes.InvalidArgument},
+			{&oim.RaidParams{Members: []*oim.RaidMember{mallocMember, mallocMember}}, codes.InvalidArgument},
+		}
+		for _, p := range params {
+			_, err := mapRAID(p.params)
+			Expect(status.Code(err)).To(Equal(p.code), "%+v", p.params)
+		}
+		Expect(sim.simulated.BDevNames()).To(ConsistOf("malloc-0"))
+	})
+})
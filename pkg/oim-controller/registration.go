@@ -0,0 +1,299 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+const (
+	// registrationMinBackoff is the delay before retrying after
+	// the first failed registration. It doubles after each
+	// failure, up to the registry delay.
+	registrationMinBackoff = time.Second
+
+	// deregistrationTimeout limits how long Close waits for the
+	// registry.
+	deregistrationTimeout = 10 * time.Second
+)
+
+// RegistrationStatus describes the outcome of the self-registration
+// with the OIM registry.
+type RegistrationStatus struct {
+	// Registered is true if the most recent attempt succeeded.
+	Registered bool
+	// LastAttempt is the time of the most recent attempt, zero
+	// if there was none yet.
+	LastAttempt time.Time
+	// LastError is the error of the most recent attempt, nil if
+	// it succeeded.
+	LastError error
+	// Failures counts the failed attempts since the last
+	// successful one.
+	Failures int
+	// PCIConflict is set when the registry contains a PCI
+	// address for the controller ID which contradicts the one
+	// configured for the controller.
+	PCIConflict error
+}
+
+// RegistrationStatus returns the current state of the
+// self-registration.
+func (c *Controller) RegistrationStatus() RegistrationStatus {
+	c.registrationMutex.Lock()
+	defer c.registrationMutex.Unlock()
+	return c.registration
+}
+
+// registrationLoop registers immediately and then again after each
+// registry delay. Failed attempts are retried with exponential
+// backoff and jitter, so controllers do not all hit a recovering
+// registry at the same time.
+func (c *Controller) registrationLoop(stop <-chan interface{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Register for the first time immediately.
+	again := time.After(0 * time.Second)
+	done := make(chan error)
+	running := false
+	backoff := registrationMinBackoff
+	for {
+		select {
+		case <-stop:
+			// Let an ongoing call finish, then deregister can
+			// rely on the status.
+			cancel()
+			if running {
+				<-done
+			}
+			return
+		case err := <-done:
+			running = false
+			delay := c.registryDelay
+			if err != nil {
+				delay = jitter(backoff)
+				backoff *= 2
+				if backoff > c.registryDelay {
+					backoff = c.registryDelay
+				}
+				log.L().Errorw("registering with OIM registry", "error", err, "retry", delay)
+			} else {
+				backoff = registrationMinBackoff
+			}
+			again = time.After(delay)
+		case <-again:
+			// Run at most one call at a time by re-arming
+			// the time only after we are done.
+			running = true
+			go func() {
+				err := c.register(ctx)
+				if ctx.Err() == nil {
+					c.setRegistrationStatus(err)
+				}
+				done <- err
+			}()
+		}
+	}
+}
+
+// setRegistrationStatus records the result of an attempt.
+func (c *Controller) setRegistrationStatus(err error) {
+	c.registrationMutex.Lock()
+	defer c.registrationMutex.Unlock()
+	c.registration.LastAttempt = time.Now()
+	c.registration.LastError = err
+	c.registration.Registered = err == nil
+	if err != nil {
+		c.registration.Failures++
+	} else {
+		c.registration.Failures = 0
+	}
+}
+
+// jitter returns a random duration between half and the full delay.
+func jitter(delay time.Duration) time.Duration {
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// register sets our address in the registry and, if known, also
+// the PCI addresses of our VHost SCSI controllers.
+func (c *Controller) register(ctx context.Context) error {
+	log.L().Infof("Registering OIM controller %s at address %s with OIM registry %s", c.controllerID, c.controllerAddr, c.registryAddress)
+	conn, err := c.dialRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	registry := oim.NewRegistryClient(conn)
+	if err := c.setValue(ctx, registry, oimcommon.RegistryAddress, c.controllerAddr); err != nil {
+		return err
+	}
+	var conflicts []string
+	for _, entry := range c.pciEntries() {
+		conflict, err := c.publishPCI(ctx, registry, entry.element, entry.dev)
+		if err != nil {
+			return err
+		}
+		if conflict != nil {
+			log.L().Errorw("PCI address in OIM registry", "error", conflict)
+			conflicts = append(conflicts, conflict.Error())
+		}
+	}
+	var conflict error
+	if len(conflicts) > 0 {
+		conflict = errors.New(strings.Join(conflicts, "; "))
+	}
+	c.registrationMutex.Lock()
+	c.registration.PCIConflict = conflict
+	c.registrationMutex.Unlock()
+	return nil
+}
+
+// pciEntry is a registry path element below the controller ID and
+// the PCI address that is published there.
+type pciEntry struct {
+	element string
+	dev     *oim.PCIAddress
+}
+
+// pciEntries returns one entry per VHost SCSI controller with a
+// known PCI address. The first one is published under RegistryPCI,
+// additional ones under RegistryPCI/<SPDK controller name>.
+func (c *Controller) pciEntries() []pciEntry {
+	var entries []pciEntry
+	if c.vhostDev != nil {
+		entries = append(entries, pciEntry{oimcommon.RegistryPCI, c.vhostDev})
+	}
+	for _, vhost := range c.extraVHosts {
+		entries = append(entries, pciEntry{
+			oimcommon.RegistryPCI + "/" + filepath.Base(vhost.name),
+			vhost.dev,
+		})
+	}
+	return entries
+}
+
+// publishPCI stores the PCI address of one of our VHost SCSI
+// controllers under the given path element. A value set by someone
+// else is kept if it agrees with ours and may fill in fields which we
+// do not know. It returns a non-nil conflict if the value contradicts
+// ours, which then also remains unchanged because it cannot be
+// determined which of the two is correct.
+func (c *Controller) publishPCI(ctx context.Context, registry oim.RegistryClient, element string, dev *oim.PCIAddress) (conflict error, err error) {
+	path := c.controllerID + "/" + element
+	values, err := registry.GetValues(ctx, &oim.GetValuesRequest{Path: path})
+	if err != nil {
+		return nil, errors.Wrapf(err, "get %s", path)
+	}
+	merged := *dev
+	for _, value := range values.GetValues() {
+		if value.GetPath() != path {
+			continue
+		}
+		current, err := oimcommon.ParseBDFString(value.GetValue())
+		if err != nil {
+			return errors.Wrapf(err, "%s", path), nil
+		}
+		if !oimcommon.CompatiblePCIAddresses(*current, merged) {
+			return errors.Errorf("%s is %s, but the controller is configured to use %s",
+				path, value.GetValue(), oimcommon.PrettyPCIAddress(dev)), nil
+		}
+		merged = oimcommon.CompletePCIAddress(merged, *current)
+		if oimcommon.PrettyPCIAddress(&merged) == value.GetValue() {
+			return nil, nil
+		}
+	}
+	return nil, c.setValue(ctx, registry, element, oimcommon.PrettyPCIAddress(&merged))
+}
+
+// deregister removes our address from the registry, if it is still
+// set there. It does nothing when the controller was never
+// registered. The PCI address is kept, it does not change when the
+// controller restarts.
+func (c *Controller) deregister() {
+	if !c.RegistrationStatus().Registered {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), deregistrationTimeout)
+	defer cancel()
+	log.L().Infof("Removing OIM controller %s from OIM registry %s", c.controllerID, c.registryAddress)
+	if err := c.removeAddress(ctx); err != nil {
+		log.L().Errorw("deregistering from OIM registry", "error", err)
+		return
+	}
+	c.registrationMutex.Lock()
+	c.registration.Registered = false
+	c.registrationMutex.Unlock()
+}
+
+// removeAddress removes our address, but not one set by some other
+// instance in the meantime.
+func (c *Controller) removeAddress(ctx context.Context) error {
+	conn, err := c.dialRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	registry := oim.NewRegistryClient(conn)
+	path := c.controllerID + "/" + oimcommon.RegistryAddress
+	values, err := registry.GetValues(ctx, &oim.GetValuesRequest{Path: path})
+	if err != nil {
+		return errors.Wrapf(err, "get %s", path)
+	}
+	if len(values.GetValues()) != 1 || values.GetValues()[0].GetValue() != c.controllerAddr {
+		log.L().Infow("not removing address from OIM registry", "path", path, "values", values.GetValues())
+		return nil
+	}
+	return c.setValue(ctx, registry, oimcommon.RegistryAddress, "")
+}
+
+// dialRegistry connects to the OIM registry.
+func (c *Controller) dialRegistry(ctx context.Context) (*grpc.ClientConn, error) {
+	// Dial anew, because a) when the registry is down
+	// and our address uses Unix domain sockets, dialing
+	// will fail permanently and b) we don't want to keep
+	// a permanent connection from each controller to
+	// the registry.
+	opts := oimcommon.ChooseDialOpts(c.registryAddress, grpc.WithTransportCredentials(c.creds))
+	conn, err := grpc.DialContext(ctx, c.registryAddress, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connect to OIM registry %s", c.registryAddress)
+	}
+	return conn, nil
+}
+
+// setValue stores a value under our controller ID. An empty value
+// removes the entry.
+func (c *Controller) setValue(ctx context.Context, registry oim.RegistryClient, element, value string) error {
+	path := c.controllerID + "/" + element
+	if _, err := registry.SetValue(ctx, &oim.SetValueRequest{
+		Value: &oim.Value{
+			Path:  path,
+			Value: value,
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "set %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/oim-controller"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spdk/spdktest"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SCSI target allocation", func() {
+	const maxTargets = 12
+
+	sim := simulate([]spdktest.Option{spdktest.WithMaxSCSITargets(maxTargets)},
+		oimcontroller.WithMaxSCSITargets(maxTargets))
+
+	mapVolume := func(volumeID string) (*oim.MapVolumeReply, error) {
+		sim.simulated.AddMallocBDev(volumeID)
+		return sim.c.MapVolume(context.Background(), &oim.MapVolumeRequest{
+			VolumeId: volumeID,
+			Params: &oim.MapVolumeRequest_Malloc{
+				Malloc: &oim.MallocParams{},
+			},
+		})
+	}
+
+	It("should fill all slots", func() {
+		for i := uint32(0); i < maxTargets; i++ {
+			reply, err := mapVolume(fmt.Sprintf("volume-%d", i))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetScsiDisk().GetTarget()).To(Equal(i))
+		}
+
+		By("running out of targets")
+		_, err := mapVolume("one-too-many")
+		Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+		By("mapping an existing volume again")
+		reply, err := mapVolume("volume-3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetScsiDisk().GetTarget()).To(Equal(uint32(3)))
+
+		By("reusing a freed target")
+		_, err = sim.c.UnmapVolume(context.Background(), &oim.UnmapVolumeRequest{VolumeId: "volume-5"})
+		Expect(err).NotTo(HaveOccurred())
+		reply, err = mapVolume("one-too-many")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetScsiDisk().GetTarget()).To(Equal(uint32(5)))
+	})
+
+	It("should map concurrently", func() {
+		var wg sync.WaitGroup
+		targets := make([]uint32, maxTargets)
+		errs := make([]error, maxTargets)
+		for i := 0; i < maxTargets; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				reply, err := mapVolume(fmt.Sprintf("volume-%d", i))
+				targets[i] = reply.GetScsiDisk().GetTarget()
+				errs[i] = err
+			}(i)
+		}
+		wg.Wait()
+		used := map[uint32]bool{}
+		for i := 0; i < maxTargets; i++ {
+			Expect(errs[i]).NotTo(HaveOccurred())
+			used[targets[i]] = true
+		}
+		Expect(used).To(HaveLen(maxTargets))
+	})
+
+	It("should report when SPDK supports fewer targets", func() {
+		sim.c.Close()
+		sim.simulated.Close()
+		sim.startSPDK()
+		sim.startController(oimcontroller.WithMaxSCSITargets(maxTargets))
+
+		for i := uint32(0); i < spdk.VHostSCSIMaxTargets; i++ {
+			_, err := mapVolume(fmt.Sprintf("volume-%d", i))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := mapVolume("one-too-many")
+		Expect(err).To(HaveOccurred())
+		Expect(status.Code(err)).NotTo(Equal(codes.ResourceExhausted))
+		Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("check that SPDK supports %d targets", maxTargets)))
+	})
+
+	It("should default to the limit of SPDK", func() {
+		sim.c.Close()
+		sim.simulated.Close()
+		sim.startSPDK()
+		sim.startController()
+
+		for i := uint32(0); i < spdk.VHostSCSIMaxTargets; i++ {
+			_, err := mapVolume(fmt.Sprintf("volume-%d", i))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := mapVolume("one-too-many")
+		Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	})
+
+	It("should only remove orphaned targets of its own controller", func() {
+		ctx := context.Background()
+		sim.simulated.AddStaleTarget(simulatedVHost, 1, "gone")
+		sim.simulated.AddStaleTarget("vhost.foreign", 2, "gone")
+		list, err := sim.c.ListOrphans(ctx, &oim.ListOrphansRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.GetOrphans()).To(HaveLen(1))
+		Expect(list.GetOrphans()[0].GetName()).To(Equal(simulatedVHost))
+		Expect(list.GetOrphans()[0].GetScsiTarget()).To(Equal(uint32(1)))
+
+		collected, err := sim.c.CollectGarbage(ctx, &oim.CollectGarbageRequest{Force: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collected.GetRemoved()).To(HaveLen(1))
+		Expect(sim.simulated.Targets(simulatedVHost)).To(BeEmpty())
+		Expect(sim.simulated.Targets("vhost.foreign")).To(Equal(map[uint32]string{2: "gone"}))
+	})
+
+	It("should keep orphans during startup", func() {
+		sim.simulated.AddStaleTarget(simulatedVHost, 1, "gone")
+		sim.c.Close()
+		sim.startController(oimcontroller.WithMaxSCSITargets(maxTargets),
+			oimcontroller.WithReconcileInterval(0),
+			oimcontroller.WithDeleteOrphans(true))
+		err := sim.c.Start()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sim.simulated.Targets(simulatedVHost)).To(Equal(map[uint32]string{1: "gone"}))
+	})
+
+	It("should reconnect to SPDK", func() {
+		health := func() grpc_health_v1.HealthCheckResponse_ServingStatus {
+			reply, err := sim.c.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			return reply.GetStatus()
+		}
+		Expect(health()).To(Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+
+		By("stopping SPDK")
+		sim.simulated.Close()
+		Eventually(health, 10*time.Second).Should(Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
+		_, err := mapVolume("volume-0")
+		Expect(err).To(HaveOccurred())
+
+		By("restarting SPDK")
+		sim.startSPDK(spdktest.WithMaxSCSITargets(maxTargets))
+		Eventually(health, 10*time.Second).Should(Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+		reply, err := mapVolume("volume-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetScsiDisk().GetTarget()).To(Equal(uint32(0)))
+	})
+})
+
+var _ = Describe("multiple VHost controllers", func() {
+	const maxTargets = 4
+
+	sim := simulate(
+		[]spdktest.Option{
+			spdktest.WithVHostSCSIControllers("vhost.1"),
+			spdktest.WithMaxSCSITargets(maxTargets),
+		},
+		oimcontroller.WithAdditionalVHostController("vhost.1", "00:16.0"),
+		oimcontroller.WithMaxSCSITargets(maxTargets))
+
+	mapVolume := func(volumeID string) (*oim.MapVolumeReply, error) {
+		sim.simulated.AddMallocBDev(volumeID)
+		return sim.c.MapVolume(context.Background(), &oim.MapVolumeRequest{
+			VolumeId: volumeID,
+			Params: &oim.MapVolumeRequest_Malloc{
+				Malloc: &oim.MallocParams{},
+			},
+		})
+	}
+
+	It("should use the least loaded controller", func() {
+		perDevice := map[uint32]int{}
+		for i := 0; i < 2*maxTargets; i++ {
+			reply, err := mapVolume(fmt.Sprintf("volume-%d", i))
+			Expect(err).NotTo(HaveOccurred())
+			device := reply.GetPciAddress().GetDevice()
+			Expect(device).To(Or(Equal(uint32(0x15)), Equal(uint32(0x16))))
+			perDevice[device]++
+			diff := perDevice[0x15] - perDevice[0x16]
+			Expect(diff == 0 || diff == 1).To(BeTrue(), "balanced after %d volumes: %v", i+1, perDevice)
+		}
+
+		By("running out of targets")
+		_, err := mapVolume("one-too-many")
+		Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+		By("using the controller with a free target")
+		_, err = sim.c.UnmapVolume(context.Background(), &oim.UnmapVolumeRequest{VolumeId: "volume-1"})
+		Expect(err).NotTo(HaveOccurred())
+		reply, err := mapVolume("one-too-many")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetPciAddress().GetDevice()).To(Equal(uint32(0x16)))
+	})
+
+	It("should list volumes", func() {
+		_, err := mapVolume("volume-a")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = mapVolume("volume-b")
+		Expect(err).NotTo(HaveOccurred())
+		sim.simulated.AddMallocBDev("unmapped")
+
+		list, err := sim.c.ListVolumes(context.Background(), &oim.ListVolumesRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.GetVolumes()).To(Equal([]*oim.Volume{
+			{
+				VolumeId:    "unmapped",
+				BdevName:    "unmapped",
+				BackingType: "Malloc disk",
+				Size_:       1024 * 1024,
+			},
+			{
+				VolumeId:    "volume-a",
+				BdevName:    "volume-a",
+				BackingType: "Malloc disk",
+				Size_:       1024 * 1024,
+				PciAddress:  &oim.PCIAddress{Domain: 0xFFFF, Bus: 0, Device: 0x15, Function: 0},
+				ScsiDisk:    &oim.SCSIDisk{},
+			},
+			{
+				VolumeId:    "volume-b",
+				BdevName:    "volume-b",
+				BackingType: "Malloc disk",
+				Size_:       1024 * 1024,
+				PciAddress:  &oim.PCIAddress{Domain: 0xFFFF, Bus: 0, Device: 0x16, Function: 0},
+				ScsiDisk:    &oim.SCSIDisk{},
+			},
+		}))
+
+		volume, err := sim.c.GetVolume(context.Background(), &oim.GetVolumeRequest{VolumeId: "volume-b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(volume.GetVolume()).To(Equal(list.GetVolumes()[2]))
+
+		_, err = sim.c.GetVolume(context.Background(), &oim.GetVolumeRequest{VolumeId: "no-such-volume"})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+})
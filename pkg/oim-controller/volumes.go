@@ -0,0 +1,172 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcontroller
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// ListVolumes implements oim.Controller.ListVolumes.
+func (c *Controller) ListVolumes(ctx context.Context, in *oim.ListVolumesRequest) (*oim.ListVolumesReply, error) {
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+	volumes, err := c.listVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &oim.ListVolumesReply{Volumes: volumes}, nil
+}
+
+// GetVolume implements oim.Controller.GetVolume.
+func (c *Controller) GetVolume(ctx context.Context, in *oim.GetVolumeRequest) (*oim.GetVolumeReply, error) {
+	volumeID := in.GetVolumeId()
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
+	}
+	if c.SPDK == nil {
+		return nil, errors.New("not connected to SPDK")
+	}
+	volumes, err := c.listVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, volume := range volumes {
+		if volume.VolumeId == volumeID {
+			return &oim.GetVolumeReply{Volume: volume}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "volume %s not found", volumeID)
+}
+
+// listVolumes combines the BDevs and the SCSI targets of the
+// configured VHost controllers into a list of volumes. Crypto
+// BDevs and snapshots are not volumes.
+func (c *Controller) listVolumes(ctx context.Context) ([]*oim.Volume, error) {
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetBDevs")
+	}
+	controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetVHostControllers")
+	}
+
+	type lunInfo struct {
+		dev  *oim.PCIAddress
+		disk *oim.SCSIDisk
+	}
+	luns := map[string]lunInfo{}
+	for _, controller := range controllers {
+		vhost, ours := c.vhostFor(controller.Controller)
+		if !ours {
+			continue
+		}
+		scsi, ok := controller.BackendSpecific["scsi"].(spdk.SCSIControllerSpecific)
+		if !ok {
+			continue
+		}
+		for _, target := range scsi {
+			for _, lun := range target.LUNs {
+				luns[lun.BDevName] = lunInfo{
+					dev: vhost.dev,
+					disk: &oim.SCSIDisk{
+						Target: target.SCSIDevNum,
+						Lun:    uint32(lun.LUN),
+					},
+				}
+			}
+		}
+	}
+	// Crypto BDevs indexed by the BDev that they encrypt.
+	crypto := map[string]string{}
+	for _, bdev := range bdevs {
+		if bdev.DriverSpecific.Crypto != nil {
+			crypto[bdev.DriverSpecific.Crypto.BaseBDevName] = bdev.Name
+		}
+	}
+
+	var volumes []*oim.Volume
+	for _, bdev := range bdevs {
+		if bdev.DriverSpecific.Crypto != nil {
+			continue
+		}
+		volumeID := bdev.Name
+		if lvol := bdev.DriverSpecific.LVol; lvol != nil {
+			if lvol.Snapshot {
+				continue
+			}
+			// The alias is "<lvol store>/<volume ID>".
+			if len(bdev.Aliases) > 0 {
+				parts := strings.SplitN(bdev.Aliases[0], "/", 2)
+				volumeID = parts[len(parts)-1]
+			}
+		}
+		volume := &oim.Volume{
+			VolumeId:    volumeID,
+			BdevName:    bdev.Name,
+			BackingType: bdev.ProductName,
+			Size_:       bdev.NumBlocks * bdev.BlockSize,
+			Claimed:     bdev.Claimed,
+		}
+		mappedBDev := bdev.Name
+		if name, ok := crypto[bdev.Name]; ok {
+			volume.Encrypted = true
+			mappedBDev = name
+		}
+		if lun, ok := luns[mappedBDev]; ok {
+			volume.PciAddress = lun.dev
+			volume.ScsiDisk = lun.disk
+		}
+		volumes = append(volumes, volume)
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].VolumeId < volumes[j].VolumeId
+	})
+	return volumes, nil
+}
+
+// GetVolumeStats implements oim.Controller.GetVolumeStats.
+func (c *Controller) GetVolumeStats(ctx context.Context, in *oim.GetVolumeStatsRequest) (*oim.GetVolumeStatsReply, error) {
+	volume, err := c.GetVolume(ctx, &oim.GetVolumeRequest{VolumeId: in.GetVolumeId()})
+	if err != nil {
+		return nil, err
+	}
+	bdevName := volume.GetVolume().GetBdevName()
+	if volume.GetVolume().GetEncrypted() {
+		bdevName = cryptoBDevName(in.GetVolumeId())
+	}
+	stats, err := spdk.GetBDevsIOStat(ctx, c.SPDK, spdk.GetBDevsIOStatArgs{Name: bdevName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetBDevsIOStat %s", bdevName)
+	}
+	if len(stats.BDevs) != 1 {
+		return nil, errors.Errorf("expected statistics for BDev %s, got: %v", bdevName, stats.BDevs)
+	}
+	stat := stats.BDevs[0]
+	return &oim.GetVolumeStatsReply{
+		ReadOps:      stat.NumReadOps,
+		ReadBytes:    stat.BytesRead,
+		WriteOps:     stat.NumWriteOps,
+		WriteBytes:   stat.BytesWritten,
+		UnmapOps:     stat.NumUnmapOps,
+		UnmapBytes:   stat.BytesUnmapped,
+		ReadLatency:  uint64(stats.TicksToDuration(stat.ReadLatencyTicks)),
+		WriteLatency: uint64(stats.TicksToDuration(stat.WriteLatencyTicks)),
+		UnmapLatency: uint64(stats.TicksToDuration(stat.UnmapLatencyTicks)),
+	}, nil
+}
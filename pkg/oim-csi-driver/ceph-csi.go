@@ -28,6 +28,8 @@ type rbdVolume struct {
 	Pool               string `json:"pool"`
 	AdminID            string `json:"adminId"`
 	UserID             string `json:"userId"`
+	RadosNamespace     string `json:"radosNamespace"`
+	ImageName          string `json:"imageName"`
 }
 
 var emulateCephCSI0 = &EmulateCSI0Driver{
@@ -66,6 +68,9 @@ func mapCephVolumeParams(from *csi0.NodeStageVolumeRequest, to *oim.MapVolumeReq
 	// volume_attributes:<key:"storage.kubernetes.io/csiProvisionerIdentity" value:"1539780484677-8081-oim-rbd" >
 	// volume_attributes:<key:"userid" value:"kubernetes" >
 	//
+	// More recent ceph-csi releases also pass "imageName" and
+	// "radosNamespace".
+	//
 	// The volume attributes are documented in https://github.com/ceph/ceph-csi/blob/master/docs/deploy-rbd.md#configuration
 	//
 	// The code for retrieving the relevant attributes was copied from https://github.com/ceph/ceph-csi/tree/master/pkg/rbd
@@ -81,9 +86,18 @@ func mapCephVolumeParams(from *csi0.NodeStageVolumeRequest, to *oim.MapVolumeReq
 	if err != nil {
 		return err
 	}
+	if volOptions.ImageName != "" {
+		volName = volOptions.ImageName
+	}
 	userID := volOptions.UserID
 	credentials := from.GetNodeStageSecrets()
 
+	if volOptions.RadosNamespace != "" {
+		// Mapping the image with the same name from the
+		// pool itself would expose the wrong data.
+		return fmt.Errorf("RBD namespace %q: not supported by the OIM controller", volOptions.RadosNamespace)
+	}
+
 	mon, err := getMon(volOptions, credentials)
 	if err != nil {
 		return err
@@ -128,6 +142,8 @@ func getRBDVolumeOptions(volOptions map[string]string) (*rbdVolume, error) {
 	if !ok {
 		rbdVol.UserID = rbdDefaultUserID
 	}
+	rbdVol.RadosNamespace = volOptions["radosNamespace"]
+	rbdVol.ImageName = volOptions["imageName"]
 	return rbdVol, nil
 }
 
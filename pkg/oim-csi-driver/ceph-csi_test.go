@@ -0,0 +1,78 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+func TestMapCephVolumeParams(t *testing.T) {
+	secrets := map[string]string{
+		"kubernetes": "my-key",
+		"monitors":   "192.168.7.2:6789",
+	}
+	for _, c := range []struct {
+		attributes map[string]string
+		ceph       *oim.CephParams
+	}{
+		{
+			attributes: map[string]string{
+				"pool":               "rbd",
+				"userid":             "kubernetes",
+				"monValueFromSecret": "monitors",
+			},
+			ceph: &oim.CephParams{
+				UserId:   "kubernetes",
+				Secret:   "my-key",
+				Monitors: "192.168.7.2:6789",
+				Pool:     "rbd",
+				Image:    "pvc-1234",
+			},
+		},
+		{
+			attributes: map[string]string{
+				"pool":      "rbd",
+				"userid":    "kubernetes",
+				"monitors":  "192.168.7.4:6789",
+				"imageName": "csi-vol-5678",
+			},
+			ceph: &oim.CephParams{
+				UserId:   "kubernetes",
+				Secret:   "my-key",
+				Monitors: "192.168.7.4:6789",
+				Pool:     "rbd",
+				Image:    "csi-vol-5678",
+			},
+		},
+		{
+			attributes: map[string]string{
+				"pool":           "rbd",
+				"userid":         "kubernetes",
+				"monitors":       "192.168.7.4:6789",
+				"imageName":      "csi-vol-5678",
+				"radosNamespace": "tenant-a",
+			},
+		},
+	} {
+		var to oim.MapVolumeRequest
+		err := mapCephVolumeParams(&csi0.NodeStageVolumeRequest{
+			StagingTargetPath: "/var/lib/kubelet/plugins/kubernetes.io/csi/pv/pvc-1234/globalmount",
+			VolumeAttributes:  c.attributes,
+			NodeStageSecrets:  secrets,
+		}, &to)
+		if c.ceph == nil {
+			assert.Error(t, err, "%v", c.attributes)
+		} else if assert.NoError(t, err, "%v", c.attributes) {
+			assert.Equal(t, c.ceph, to.GetCeph(), "%v", c.attributes)
+		}
+	}
+}
@@ -9,11 +9,16 @@ package oimcsidriver
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
 )
 
 func (od *oimDriver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
@@ -45,8 +50,19 @@ func (od *oimDriver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 			return nil, status.Error(codes.Unimplemented, fmt.Sprintf("%s not supported", cap.GetAccessMode().GetMode()))
 		}
 	}
-	if req.GetVolumeContentSource() != nil {
-		return nil, status.Error(codes.Unimplemented, "snapshots not supported")
+	if _, err := parseQoS(req.GetParameters()); err != nil {
+		return nil, err
+	}
+	var source *volumeSource
+	if contentSource := req.GetVolumeContentSource(); contentSource != nil {
+		switch {
+		case contentSource.GetSnapshot() != nil:
+			source = &volumeSource{snapshotID: contentSource.GetSnapshot().GetSnapshotId()}
+		case contentSource.GetVolume() != nil:
+			source = &volumeSource{volumeID: contentSource.GetVolume().GetVolumeId()}
+		default:
+			return nil, status.Error(codes.InvalidArgument, "unsupported volume content source")
+		}
 	}
 
 	// Serialize operations per volume by name.
@@ -56,15 +72,18 @@ func (od *oimDriver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 	volumeNameMutex.LockKey(name)
 	defer volumeNameMutex.UnlockKey(name)
 
-	actualBytes, err := od.backend.createVolume(ctx, name, req.GetCapacityRange().GetRequiredBytes())
+	// We use the unique name also as ID, if possible.
+	volumeID := od.volumeID(name)
+	actualBytes, err := od.backend.createVolume(ctx, volumeID, req.GetCapacityRange().GetRequiredBytes(), source)
 	if err != nil {
 		return nil, err
 	}
 	return &csi.CreateVolumeResponse{
 		Volume: &csi.Volume{
-			// We use the unique name also as ID.
-			VolumeId:      name,
+			VolumeId:      volumeID,
 			CapacityBytes: actualBytes,
+			VolumeContext: req.GetParameters(),
+			ContentSource: req.GetVolumeContentSource(),
 		},
 	}, nil
 }
@@ -89,6 +108,38 @@ func (od *oimDriver) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 	return &csi.DeleteVolumeResponse{}, nil
 }
 
+func (od *oimDriver) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
+	volumeID := req.GetVolumeId()
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	requiredBytes := req.GetCapacityRange().GetRequiredBytes()
+	limitBytes := req.GetCapacityRange().GetLimitBytes()
+	if requiredBytes <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Required bytes missing in request")
+	}
+	if limitBytes > 0 && limitBytes < requiredBytes {
+		return nil, status.Error(codes.InvalidArgument, "Limit bytes smaller than required bytes")
+	}
+
+	// Volume ID is the same as the volume name in CreateVolume. Serialize by that.
+	volumeNameMutex.LockKey(volumeID)
+	defer volumeNameMutex.UnlockKey(volumeID)
+
+	actualBytes, err := od.backend.resizeVolume(ctx, volumeID, requiredBytes)
+	if err != nil {
+		return nil, err
+	}
+	if limitBytes > 0 && actualBytes > limitBytes {
+		return nil, status.Errorf(codes.OutOfRange, "volume already has %d bytes, more than the limit of %d bytes", actualBytes, limitBytes)
+	}
+	return &csi.ControllerExpandVolumeResponse{
+		CapacityBytes: actualBytes,
+		// The filesystem must be grown on the node.
+		NodeExpansionRequired: true,
+	}, nil
+}
+
 func (od *oimDriver) ControllerPublishVolume(ctx context.Context, req *csi.ControllerPublishVolumeRequest) (*csi.ControllerPublishVolumeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "")
 }
@@ -175,13 +226,93 @@ func (od *oimDriver) ControllerGetCapabilities(ctx context.Context, req *csi.Con
 }
 
 func (od *oimDriver) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name missing in request")
+	}
+	if req.GetSourceVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Source Volume ID missing in request")
+	}
+
+	// Serialize operations per snapshot by name.
+	volumeNameMutex.LockKey(name)
+	defer volumeNameMutex.UnlockKey(name)
+
+	snapshot, err := od.backend.createSnapshot(ctx, req.GetSourceVolumeId(), od.volumeID(name))
+	if err != nil {
+		return nil, err
+	}
+	return &csi.CreateSnapshotResponse{
+		Snapshot: csiSnapshot(snapshot),
+	}, nil
 }
 
 func (od *oimDriver) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	snapshotID := req.GetSnapshotId()
+	if snapshotID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Snapshot ID missing in request")
+	}
+
+	// Snapshot ID is the same as the snapshot name in CreateSnapshot. Serialize by that.
+	volumeNameMutex.LockKey(snapshotID)
+	defer volumeNameMutex.UnlockKey(snapshotID)
+
+	if err := od.backend.deleteSnapshot(ctx, snapshotID); err != nil {
+		return nil, err
+	}
+	return &csi.DeleteSnapshotResponse{}, nil
 }
 
 func (od *oimDriver) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	snapshots, err := od.backend.listSnapshots(ctx, req.GetSnapshotId(), req.GetSourceVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	start, end, nextToken, err := page(len(snapshots), req.GetStartingToken(), req.GetMaxEntries())
+	if err != nil {
+		return nil, err
+	}
+	response := &csi.ListSnapshotsResponse{
+		NextToken: nextToken,
+	}
+	for _, snapshot := range snapshots[start:end] {
+		response.Entries = append(response.Entries, &csi.ListSnapshotsResponse_Entry{
+			Snapshot: csiSnapshot(snapshot),
+		})
+	}
+	return response, nil
+}
+
+func csiSnapshot(snapshot *oim.Snapshot) *csi.Snapshot {
+	return &csi.Snapshot{
+		SizeBytes:      snapshot.GetSize_(),
+		SnapshotId:     snapshot.GetSnapshotId(),
+		SourceVolumeId: snapshot.GetSourceVolumeId(),
+		CreationTime: &timestamp.Timestamp{
+			Seconds: snapshot.GetCreationTime() / int64(time.Second),
+			Nanos:   int32(snapshot.GetCreationTime() % int64(time.Second)),
+		},
+		// SPDK snapshots need no post-processing.
+		ReadyToUse: true,
+	}
+}
+
+// page determines which part of a list with the given length is
+// returned by a List call. The token is the index of the next entry.
+func page(length int, startingToken string, maxEntries int32) (start, end int, nextToken string, err error) {
+	if maxEntries < 0 {
+		return 0, 0, "", status.Errorf(codes.InvalidArgument, "negative max entries %d", maxEntries)
+	}
+	if startingToken != "" {
+		start, err = strconv.Atoi(startingToken)
+		if err != nil || start < 0 || start > length {
+			return 0, 0, "", status.Errorf(codes.Aborted, "invalid starting token %q", startingToken)
+		}
+	}
+	end = length
+	if maxEntries > 0 && start+int(maxEntries) < length {
+		end = start + int(maxEntries)
+		nextToken = strconv.Itoa(end)
+	}
+	return start, end, nextToken, nil
 }
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/intel/oim/pkg/spec/csi/v0"
+	"github.com/intel/oim/pkg/spec/oim/v0"
 )
 
 func (od *oimDriver03) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
@@ -45,8 +46,15 @@ func (od *oimDriver03) CreateVolume(ctx context.Context, req *csi.CreateVolumeRe
 			return nil, status.Error(codes.Unimplemented, fmt.Sprintf("%s not supported", cap.GetAccessMode().GetMode()))
 		}
 	}
-	if req.GetVolumeContentSource() != nil {
-		return nil, status.Error(codes.Unimplemented, "snapshots not supported")
+	if _, err := parseQoS(req.GetParameters()); err != nil {
+		return nil, err
+	}
+	var source *volumeSource
+	if contentSource := req.GetVolumeContentSource(); contentSource != nil {
+		if contentSource.GetSnapshot() == nil {
+			return nil, status.Error(codes.InvalidArgument, "unsupported volume content source")
+		}
+		source = &volumeSource{snapshotID: contentSource.GetSnapshot().GetId()}
 	}
 
 	// Serialize operations per volume by name.
@@ -56,16 +64,18 @@ func (od *oimDriver03) CreateVolume(ctx context.Context, req *csi.CreateVolumeRe
 	volumeNameMutex.LockKey(name)
 	defer volumeNameMutex.UnlockKey(name)
 
-	actualBytes, err := od.backend.createVolume(ctx, name, req.GetCapacityRange().GetRequiredBytes())
+	// We use the unique name also as ID, if possible.
+	volumeID := od.volumeID(name)
+	actualBytes, err := od.backend.createVolume(ctx, volumeID, req.GetCapacityRange().GetRequiredBytes(), source)
 	if err != nil {
 		return nil, err
 	}
 	return &csi.CreateVolumeResponse{
 		Volume: &csi.Volume{
-			// We use the unique name also as ID.
-			Id:            name,
+			Id:            volumeID,
 			CapacityBytes: actualBytes,
 			Attributes:    req.GetParameters(),
+			ContentSource: req.GetVolumeContentSource(),
 		},
 	}, nil
 }
@@ -146,13 +156,72 @@ func (od *oimDriver03) ControllerGetCapabilities(ctx context.Context, req *csi.C
 }
 
 func (od *oimDriver03) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name missing in request")
+	}
+	if req.GetSourceVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Source Volume ID missing in request")
+	}
+
+	// Serialize operations per snapshot by name.
+	volumeNameMutex.LockKey(name)
+	defer volumeNameMutex.UnlockKey(name)
+
+	snapshot, err := od.backend.createSnapshot(ctx, req.GetSourceVolumeId(), od.volumeID(name))
+	if err != nil {
+		return nil, err
+	}
+	return &csi.CreateSnapshotResponse{
+		Snapshot: csi0Snapshot(snapshot),
+	}, nil
 }
 
 func (od *oimDriver03) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	snapshotID := req.GetSnapshotId()
+	if snapshotID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Snapshot ID missing in request")
+	}
+
+	// Snapshot ID is the same as the snapshot name in CreateSnapshot. Serialize by that.
+	volumeNameMutex.LockKey(snapshotID)
+	defer volumeNameMutex.UnlockKey(snapshotID)
+
+	if err := od.backend.deleteSnapshot(ctx, snapshotID); err != nil {
+		return nil, err
+	}
+	return &csi.DeleteSnapshotResponse{}, nil
 }
 
 func (od *oimDriver03) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	snapshots, err := od.backend.listSnapshots(ctx, req.GetSnapshotId(), req.GetSourceVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	start, end, nextToken, err := page(len(snapshots), req.GetStartingToken(), req.GetMaxEntries())
+	if err != nil {
+		return nil, err
+	}
+	response := &csi.ListSnapshotsResponse{
+		NextToken: nextToken,
+	}
+	for _, snapshot := range snapshots[start:end] {
+		response.Entries = append(response.Entries, &csi.ListSnapshotsResponse_Entry{
+			Snapshot: csi0Snapshot(snapshot),
+		})
+	}
+	return response, nil
+}
+
+func csi0Snapshot(snapshot *oim.Snapshot) *csi.Snapshot {
+	return &csi.Snapshot{
+		SizeBytes:      snapshot.GetSize_(),
+		Id:             snapshot.GetSnapshotId(),
+		SourceVolumeId: snapshot.GetSourceVolumeId(),
+		CreatedAt:      snapshot.GetCreationTime(),
+		// SPDK snapshots need no post-processing.
+		Status: &csi.SnapshotStatus{
+			Type: csi.SnapshotStatus_READY,
+		},
+	}
 }
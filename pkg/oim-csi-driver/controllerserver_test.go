@@ -0,0 +1,67 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spdk"
+)
+
+func TestPage(t *testing.T) {
+	for _, c := range []struct {
+		length        int
+		startingToken string
+		maxEntries    int32
+		start, end    int
+		nextToken     string
+		code          codes.Code
+	}{
+		{length: 0, start: 0, end: 0},
+		{length: 5, start: 0, end: 5},
+		{length: 5, maxEntries: 5, start: 0, end: 5},
+		{length: 5, maxEntries: 2, start: 0, end: 2, nextToken: "2"},
+		{length: 5, startingToken: "2", maxEntries: 2, start: 2, end: 4, nextToken: "4"},
+		{length: 5, startingToken: "4", maxEntries: 2, start: 4, end: 5},
+		{length: 5, startingToken: "5", start: 5, end: 5},
+		{length: 5, startingToken: "6", code: codes.Aborted},
+		{length: 5, startingToken: "foo", code: codes.Aborted},
+		{length: 5, maxEntries: -1, code: codes.InvalidArgument},
+	} {
+		start, end, nextToken, err := page(c.length, c.startingToken, c.maxEntries)
+		if c.code != codes.OK {
+			assert.Equal(t, c.code, status.Code(err), "%+v", c)
+			continue
+		}
+		if assert.NoError(t, err, "%+v", c) {
+			assert.Equal(t, c.start, start, "start %+v", c)
+			assert.Equal(t, c.end, end, "end %+v", c)
+			assert.Equal(t, c.nextToken, nextToken, "next token %+v", c)
+		}
+	}
+}
+
+func TestVolumeID(t *testing.T) {
+	short := "pvc-1234"
+	long := strings.Repeat("a", 128)
+
+	od := oimDriver{}
+	assert.Equal(t, short, od.volumeID(short))
+	assert.Equal(t, long, od.volumeID(long), "no lvol store")
+
+	od.lvolStore = "lvs"
+	assert.Equal(t, short, od.volumeID(short))
+	id := od.volumeID(long)
+	assert.Len(t, id, spdk.LVolNameMaxLength)
+	assert.Equal(t, id, od.volumeID(long), "stable")
+	assert.NotEqual(t, id, od.volumeID(long+"b"), "unique")
+}
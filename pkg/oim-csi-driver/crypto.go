@@ -0,0 +1,46 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+const (
+	// cryptoKeySecret is the node-stage secret which contains
+	// the encryption key. Volumes are encrypted if it is set.
+	cryptoKeySecret = "encryptionKey"
+	// cryptoPMDParameter is the optional StorageClass parameter
+	// which selects the DPDK crypto driver.
+	cryptoPMDParameter = "cryptoPMD"
+)
+
+// stageCrypto returns the encryption parameters for a
+// NodeStageVolumeRequest of either CSI version, nil if the volume
+// is not meant to be encrypted.
+func stageCrypto(request interface{}) *oim.CryptoParams {
+	var secrets, volumeContext map[string]string
+	switch r := request.(type) {
+	case *csi.NodeStageVolumeRequest:
+		secrets = r.GetSecrets()
+		volumeContext = r.GetVolumeContext()
+	case *csi0.NodeStageVolumeRequest:
+		secrets = r.GetNodeStageSecrets()
+		volumeContext = r.GetVolumeAttributes()
+	}
+	key, ok := secrets[cryptoKeySecret]
+	if !ok {
+		return nil
+	}
+	return &oim.CryptoParams{
+		CryptoPmd: volumeContext[cryptoPMDParameter],
+		Key:       key,
+	}
+}
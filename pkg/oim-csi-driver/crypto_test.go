@@ -0,0 +1,29 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+func TestStageCrypto(t *testing.T) {
+	assert.Nil(t, stageCrypto(&csi.NodeStageVolumeRequest{}))
+	assert.Nil(t, stageCrypto(&csi.NodeStageVolumeRequest{Secrets: map[string]string{"admin": "foo"}}))
+
+	secrets := map[string]string{"encryptionKey": "0123456789abcdef"}
+	volumeContext := map[string]string{"cryptoPMD": "crypto_qat"}
+	expected := &oim.CryptoParams{CryptoPmd: "crypto_qat", Key: "0123456789abcdef"}
+	assert.Equal(t, expected, stageCrypto(&csi.NodeStageVolumeRequest{Secrets: secrets, VolumeContext: volumeContext}))
+	assert.Equal(t, expected, stageCrypto(&csi0.NodeStageVolumeRequest{NodeStageSecrets: secrets, VolumeAttributes: volumeContext}))
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/mount"
+)
+
+// expandFilesystem grows the filesystem mounted at volumePath to
+// the size of the underlying block device and returns that size.
+func expandFilesystem(ctx context.Context, volumePath string) (int64, error) {
+	var stat syscall.Stat_t
+	if err := syscall.Stat(volumePath, &stat); err != nil {
+		if os.IsNotExist(err) {
+			return 0, status.Errorf(codes.NotFound, "volume path %s: %s", volumePath, err)
+		}
+		return 0, status.Error(codes.Internal, errors.Wrapf(err, "stat %s", volumePath).Error())
+	}
+	major := int(unix.Major(stat.Dev))
+	minor := int(unix.Minor(stat.Dev))
+
+	fsType, err := findFSType(volumePath)
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	size, err := rescanDevice(ctx, "/sys/dev/block", major, minor)
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	exec := mount.NewOsExec()
+	switch fsType {
+	case "ext2", "ext3", "ext4":
+		// resize2fs needs the device. As in remoteSPDK.createDevice,
+		// the device might not be in /dev, so we create our own
+		// temporary block special file.
+		tmpDir, err := ioutil.TempDir("/dev", "resize")
+		if err != nil {
+			return 0, status.Error(codes.Internal, errors.Wrap(err, "temp dir").Error())
+		}
+		defer os.RemoveAll(tmpDir)
+		devNode := filepath.Join(tmpDir, "dev")
+		if err := syscall.Mknod(devNode, syscall.S_IFBLK|0600, makedev(major, minor)); err != nil {
+			return 0, status.Error(codes.Internal, errors.Wrap(err, "mknod").Error())
+		}
+		if out, err := exec.Run("resize2fs", devNode); err != nil {
+			return 0, status.Error(codes.Internal, errors.Wrapf(err, "resize2fs: %s", out).Error())
+		}
+	case "xfs":
+		if out, err := exec.Run("xfs_growfs", volumePath); err != nil {
+			return 0, status.Error(codes.Internal, errors.Wrapf(err, "xfs_growfs: %s", out).Error())
+		}
+	default:
+		return 0, status.Errorf(codes.FailedPrecondition, "resizing %s filesystem not supported", fsType)
+	}
+	log.FromContext(ctx).Infow("expanded filesystem",
+		"path", volumePath,
+		"fstype", fsType,
+		"size", size,
+	)
+	return size, nil
+}
+
+// findFSType returns the type of the filesystem mounted at path.
+func findFSType(path string) (string, error) {
+	mounts, err := mount.New("").List()
+	if err != nil {
+		return "", errors.Wrap(err, "list mounts")
+	}
+	path = filepath.Clean(path)
+	for _, mp := range mounts {
+		if filepath.Clean(mp.Path) == path {
+			return mp.Type, nil
+		}
+	}
+	return "", errors.Errorf("%s is not a mount point", path)
+}
+
+// rescanDevice makes the kernel check the size of a SCSI disk again,
+// which is necessary after the volume was resized by the OIM
+// controller, and returns the current size of the device. Other
+// devices (like NBD) do not support rescanning. For those, the size
+// is already up-to-date when the volume was staged after resizing.
+func rescanDevice(ctx context.Context, sys string, major, minor int) (int64, error) {
+	dev := filepath.Join(sys, fmt.Sprintf("%d:%d", major, minor))
+	rescan := filepath.Join(dev, "device", "rescan")
+	if _, err := os.Stat(rescan); err == nil {
+		log.FromContext(ctx).Debugw("rescanning", "device", dev)
+		if err := ioutil.WriteFile(rescan, []byte("1"), 0200); err != nil {
+			return 0, errors.Wrap(err, "rescan device")
+		}
+	}
+	content, err := ioutil.ReadFile(filepath.Join(dev, "size"))
+	if err != nil {
+		return 0, errors.Wrap(err, "device size")
+	}
+	// Always in 512 byte sectors, regardless of the actual block size.
+	sectors, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse device size %q", content)
+	}
+	return sectors * 512, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRescanDevice(t *testing.T) {
+	ctx := context.Background()
+	sys, err := ioutil.TempDir("", "sys")
+	require.NoError(t, err)
+	defer os.RemoveAll(sys)
+
+	// A SCSI disk.
+	scsi := filepath.Join(sys, "8:16")
+	require.NoError(t, os.MkdirAll(filepath.Join(scsi, "device"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(scsi, "device", "rescan"), nil, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(scsi, "size"), []byte("4096\n"), 0644))
+	size, err := rescanDevice(ctx, sys, 8, 16)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(4096*512), size)
+	}
+	content, err := ioutil.ReadFile(filepath.Join(scsi, "device", "rescan"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(content))
+
+	// An NBD device without rescan support.
+	nbd := filepath.Join(sys, "43:0")
+	require.NoError(t, os.MkdirAll(nbd, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(nbd, "size"), []byte("2048\n"), 0644))
+	size, err = rescanDevice(ctx, sys, 43, 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2048*512), size)
+	}
+
+	// Unknown device.
+	_, err = rescanDevice(ctx, sys, 1, 2)
+	assert.Error(t, err)
+}
@@ -24,7 +24,7 @@ func (od *oimDriver) Probe(ctx context.Context, req *csi.ProbeRequest) (*csi.Pro
 }
 
 func (od *oimDriver) GetPluginCapabilities(ctx context.Context, req *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
-	return &csi.GetPluginCapabilitiesResponse{
+	response := &csi.GetPluginCapabilitiesResponse{
 		Capabilities: []*csi.PluginCapability{
 			{
 				Type: &csi.PluginCapability_Service_{
@@ -34,5 +34,22 @@ func (od *oimDriver) GetPluginCapabilities(ctx context.Context, req *csi.GetPlug
 				},
 			},
 		},
-	}, nil
+	}
+	if od.lvolStore != "" {
+		// A SCSI disk can be rescanned while in use, an NBD
+		// device only picks up the new size when it gets
+		// created again.
+		expansion := csi.PluginCapability_VolumeExpansion_ONLINE
+		if od.backend == &od.local {
+			expansion = csi.PluginCapability_VolumeExpansion_OFFLINE
+		}
+		response.Capabilities = append(response.Capabilities, &csi.PluginCapability{
+			Type: &csi.PluginCapability_VolumeExpansion_{
+				VolumeExpansion: &csi.PluginCapability_VolumeExpansion{
+					Type: expansion,
+				},
+			},
+		})
+	}
+	return response, nil
 }
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -18,16 +19,23 @@ import (
 
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/oim-controller"
 	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spec/oim/v0"
 )
 
 type localSPDK struct {
 	vhostEndpoint string
+	lvolStore     string
+
+	controllerMutex sync.Mutex
+	client          *spdk.Client
+	c               *oimcontroller.Controller
 }
 
 var _ OIMBackend = &localSPDK{}
 
-func (l *localSPDK) createVolume(ctx context.Context, volumeID string, requiredBytes int64) (int64, error) {
+func (l *localSPDK) createVolume(ctx context.Context, volumeID string, requiredBytes int64, source *volumeSource) (int64, error) {
 	// Connect to SPDK.
 	client, err := spdk.New(l.vhostEndpoint)
 	if err != nil {
@@ -35,6 +43,13 @@ func (l *localSPDK) createVolume(ctx context.Context, volumeID string, requiredB
 	}
 	defer client.Close()
 
+	if l.lvolStore != "" {
+		return l.createLVol(ctx, volumeID, requiredBytes, source)
+	}
+	if source != nil {
+		return 0, status.Error(codes.Unimplemented, "volume content source requires an lvol store")
+	}
+
 	// Need to check for already existing volume name, and if found
 	// check for the requested capacity and already allocated capacity
 	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: volumeID})
@@ -51,8 +66,7 @@ func (l *localSPDK) createVolume(ctx context.Context, volumeID string, requiredB
 		return 0, status.Error(codes.AlreadyExists, fmt.Sprintf("Volume with the same name: %s but with different size already exist", volumeID))
 	}
 	// If we get an error, we might have a problem or the bdev simply doesn't exist.
-	// A bit hard to tell, unfortunately (see https://github.com/spdk/spdk/issues/319).
-	if err != nil && !spdk.IsJSONError(err, spdk.ERROR_INVALID_PARAMS) {
+	if err != nil && !spdk.IsNotFound(err) {
 		return 0, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to get BDevs from SPDK: %s", err))
 	}
 
@@ -91,9 +105,20 @@ func (l *localSPDK) deleteVolume(ctx context.Context, volumeID string) error {
 	}
 	defer client.Close()
 
+	if l.lvolStore != "" {
+		c, err := l.controller()
+		if err != nil {
+			return err
+		}
+		_, err = c.ProvisionLVol(ctx, &oim.ProvisionLVolRequest{
+			LvolStore: l.lvolStore,
+			VolumeId:  volumeID,
+		})
+		return err
+	}
+
 	// We must not error out when the BDev does not exist (might have been deleted already).
-	// TODO: proper detection of "bdev not found" (https://github.com/spdk/spdk/issues/319).
-	if err := spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: volumeID}); err != nil && !spdk.IsJSONError(err, spdk.ERROR_INVALID_PARAMS) {
+	if err := spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: volumeID}); err != nil && !spdk.IsNotFound(err) {
 		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to delete SPDK Malloc BDev %s: %s", volumeID, err))
 	}
 	return nil
@@ -107,16 +132,21 @@ func (l *localSPDK) checkVolumeExists(ctx context.Context, volumeID string) erro
 	}
 	defer client.Close()
 
-	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: volumeID})
+	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: l.bdevAlias(volumeID)})
 	if err == nil && len(bdevs) == 1 {
 		return nil
 	}
-
-	// TODO: detect "not found" error (https://github.com/spdk/spdk/issues/319)
+	if err != nil && !spdk.IsNotFound(err) {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to get BDevs from SPDK: %s", err))
+	}
 	return status.Error(codes.NotFound, "")
 }
 
 func (l *localSPDK) createDevice(ctx context.Context, volumeID string, request interface{}) (string, cleanup, error) {
+	if stageCrypto(request) != nil {
+		return "", nil, status.Error(codes.Unimplemented, "encryption requires an OIM controller")
+	}
+
 	// Connect to SPDK.
 	client, err := spdk.New(l.vhostEndpoint)
 	if err != nil {
@@ -124,8 +154,32 @@ func (l *localSPDK) createDevice(ctx context.Context, volumeID string, request i
 	}
 	defer client.Close()
 
+	bdevName, err := l.bdevName(ctx, client, volumeID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	qos, err := stageQoS(request)
+	if err != nil {
+		return "", nil, err
+	}
+	if qos != nil {
+		args := spdk.SetBDevQoSLimitArgs{
+			Name: bdevName,
+			QoSLimits: spdk.QoSLimits{
+				RWIOsPerSec:    qos.GetRwIosPerSec(),
+				RWMBytesPerSec: qos.GetRwMbytesPerSec(),
+				RMBytesPerSec:  qos.GetRMbytesPerSec(),
+				WMBytesPerSec:  qos.GetWMbytesPerSec(),
+			},
+		}
+		if err := spdk.SetBDevQoSLimit(ctx, client, args); err != nil {
+			return "", nil, errors.Wrapf(err, "set SPDK QoS limits %+v", args)
+		}
+	}
+
 	// We might have already mapped that BDev to a NBD disk - check!
-	nbdDevice, err := findNBDDevice(ctx, client, volumeID)
+	nbdDevice, err := findNBDDevice(ctx, client, bdevName)
 	if err != nil {
 		return "", nil, errors.Wrap(err, "find NBD device")
 	}
@@ -176,7 +230,7 @@ func (l *localSPDK) createDevice(ctx context.Context, volumeID string, request i
 	}
 
 	args := spdk.StartNBDDiskArgs{
-		BDevName:  volumeID,
+		BDevName:  bdevName,
 		NBDDevice: nbdDevice,
 	}
 	if err := spdk.StartNBDDisk(ctx, client, args); err != nil {
@@ -193,8 +247,13 @@ func (l *localSPDK) deleteDevice(ctx context.Context, volumeID string) error {
 	}
 	defer client.Close()
 
+	bdevName, err := l.bdevName(ctx, client, volumeID)
+	if err != nil {
+		return err
+	}
+
 	// Stop NBD disk.
-	nbdDevice, err := findNBDDevice(ctx, client, volumeID)
+	nbdDevice, err := findNBDDevice(ctx, client, bdevName)
 	if err != nil {
 		return errors.Wrap(err, "get NDB disks from SPDK")
 	}
@@ -205,13 +264,185 @@ func (l *localSPDK) deleteDevice(ctx context.Context, volumeID string) error {
 	return nil
 }
 
-func findNBDDevice(ctx context.Context, client *spdk.Client, volumeID string) (nbdDevice string, err error) {
+func (l *localSPDK) createSnapshot(ctx context.Context, sourceVolumeID, snapshotID string) (*oim.Snapshot, error) {
+	if l.lvolStore == "" {
+		return nil, status.Error(codes.Unimplemented, "snapshots require an lvol store")
+	}
+	c, err := l.controller()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.CreateSnapshot(ctx, &oim.CreateSnapshotRequest{
+		LvolStore:      l.lvolStore,
+		SourceVolumeId: sourceVolumeID,
+		SnapshotId:     snapshotID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetSnapshot(), nil
+}
+
+func (l *localSPDK) deleteSnapshot(ctx context.Context, snapshotID string) error {
+	if l.lvolStore == "" {
+		return status.Error(codes.Unimplemented, "snapshots require an lvol store")
+	}
+	c, err := l.controller()
+	if err != nil {
+		return err
+	}
+	_, err = c.DeleteSnapshot(ctx, &oim.DeleteSnapshotRequest{
+		LvolStore:  l.lvolStore,
+		SnapshotId: snapshotID,
+	})
+	return err
+}
+
+func (l *localSPDK) listSnapshots(ctx context.Context, snapshotID, sourceVolumeID string) ([]*oim.Snapshot, error) {
+	if l.lvolStore == "" {
+		return nil, status.Error(codes.Unimplemented, "snapshots require an lvol store")
+	}
+	c, err := l.controller()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.ListSnapshots(ctx, &oim.ListSnapshotsRequest{
+		LvolStore:      l.lvolStore,
+		SnapshotId:     snapshotID,
+		SourceVolumeId: sourceVolumeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetSnapshots(), nil
+}
+
+func (l *localSPDK) resizeVolume(ctx context.Context, volumeID string, requiredBytes int64) (int64, error) {
+	if l.lvolStore == "" {
+		return 0, status.Error(codes.Unimplemented, "resizing requires an lvol store")
+	}
+	if requiredBytes >= maxStorageCapacity {
+		return 0, status.Errorf(codes.OutOfRange, "Requested capacity %d exceeds maximum allowed %d", requiredBytes, maxStorageCapacity)
+	}
+	c, err := l.controller()
+	if err != nil {
+		return 0, err
+	}
+	reply, err := c.ResizeVolume(ctx, &oim.ResizeVolumeRequest{
+		LvolStore: l.lvolStore,
+		VolumeId:  volumeID,
+		Size_:     requiredBytes,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetSize_(), nil
+}
+
+func (l *localSPDK) createLVol(ctx context.Context, volumeID string, requiredBytes int64, source *volumeSource) (int64, error) {
+	if requiredBytes >= maxStorageCapacity {
+		return 0, status.Errorf(codes.OutOfRange, "Requested capacity %d exceeds maximum allowed %d", requiredBytes, maxStorageCapacity)
+	}
+	c, err := l.controller()
+	if err != nil {
+		return 0, err
+	}
+	if source != nil {
+		reply, err := c.CloneVolume(ctx, &oim.CloneVolumeRequest{
+			LvolStore:      l.lvolStore,
+			VolumeId:       volumeID,
+			SnapshotId:     source.snapshotID,
+			SourceVolumeId: source.volumeID,
+			Size_:          requiredBytes,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return reply.GetSize_(), nil
+	}
+	if requiredBytes == 0 {
+		requiredBytes = mib
+	}
+	reply, err := c.ProvisionLVol(ctx, &oim.ProvisionLVolRequest{
+		LvolStore: l.lvolStore,
+		VolumeId:  volumeID,
+		Size_:     requiredBytes,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetSize_(), nil
+}
+
+// controller provides the logical volume operations of the OIM
+// controller for the local SPDK instance. The controller and its
+// connection to SPDK are created on demand and then reused until
+// close.
+func (l *localSPDK) controller() (*oimcontroller.Controller, error) {
+	l.controllerMutex.Lock()
+	defer l.controllerMutex.Unlock()
+	if l.c != nil {
+		return l.c, nil
+	}
+	client, err := spdk.New(l.vhostEndpoint)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to connect to SPDK: %s", err))
+	}
+	c, err := oimcontroller.New(oimcontroller.WithSPDKClient(client))
+	if err != nil {
+		client.Close()
+		return nil, errors.Wrap(err, "create OIM controller")
+	}
+	l.client = client
+	l.c = c
+	return c, nil
+}
+
+// close frees the controller and its connection to SPDK, if they
+// were created.
+func (l *localSPDK) close() {
+	l.controllerMutex.Lock()
+	defer l.controllerMutex.Unlock()
+	if l.c != nil {
+		l.c.Close()
+		l.client.Close()
+		l.c = nil
+		l.client = nil
+	}
+}
+
+// bdevAlias returns a name under which SPDK knows the BDev of
+// the volume.
+func (l *localSPDK) bdevAlias(volumeID string) string {
+	if l.lvolStore != "" {
+		return l.lvolStore + "/" + volumeID
+	}
+	return volumeID
+}
+
+// bdevName returns the actual name of the BDev, which is different
+// from the alias for logical volumes.
+func (l *localSPDK) bdevName(ctx context.Context, client *spdk.Client, volumeID string) (string, error) {
+	if l.lvolStore == "" {
+		return volumeID, nil
+	}
+	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: l.bdevAlias(volumeID)})
+	if err != nil {
+		return "", errors.Wrapf(err, "get BDev %s", l.bdevAlias(volumeID))
+	}
+	if len(bdevs) != 1 {
+		return "", errors.Errorf("expected one BDev %s, got: %v", l.bdevAlias(volumeID), bdevs)
+	}
+	return bdevs[0].Name, nil
+}
+
+func findNBDDevice(ctx context.Context, client *spdk.Client, bdevName string) (nbdDevice string, err error) {
 	nbdDisks, err := spdk.GetNBDDisks(ctx, client)
 	if err != nil {
 		return "", errors.Wrap(err, "get NDB disks from SPDK")
 	}
 	for _, nbd := range nbdDisks {
-		if nbd.BDevName == volumeID {
+		if nbd.BDevName == bdevName {
 			return nbd.NBDDevice, nil
 		}
 	}
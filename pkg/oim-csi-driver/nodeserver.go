@@ -27,17 +27,24 @@ func (od *oimDriver) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoReques
 }
 
 func (od *oimDriver) NodeGetCapabilities(ctx context.Context, req *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
-	return &csi.NodeGetCapabilitiesResponse{
-		Capabilities: []*csi.NodeServiceCapability{
-			{
-				Type: &csi.NodeServiceCapability_Rpc{
-					Rpc: &csi.NodeServiceCapability_RPC{
-						Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
-					},
+	caps := []csi.NodeServiceCapability_RPC_Type{
+		csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
+		csi.NodeServiceCapability_RPC_GET_VOLUME_STATS,
+	}
+	if od.lvolStore != "" {
+		caps = append(caps, csi.NodeServiceCapability_RPC_EXPAND_VOLUME)
+	}
+	response := &csi.NodeGetCapabilitiesResponse{}
+	for _, cap := range caps {
+		response.Capabilities = append(response.Capabilities, &csi.NodeServiceCapability{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: cap,
 				},
 			},
-		},
-	}, nil
+		})
+	}
+	return response, nil
 }
 
 func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
@@ -201,6 +208,12 @@ func (od *oimDriver) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolu
 	}
 
 	options := []string{}
+	if stageReadOnly(req) {
+		if err := setReadOnly(device); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		options = append(options, "ro")
+	}
 	diskMounter := &mount.SafeFormatAndMount{Interface: mount.New(""), Exec: mount.NewOsExec()}
 	if err := diskMounter.FormatAndMount(device, targetPath, fsType, options); err != nil {
 		// We get a pretty bad error code from FormatAndMount ("exit code 1") :-/
@@ -242,6 +255,30 @@ func (od *oimDriver) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstage
 	return &csi.NodeUnstageVolumeResponse{}, nil
 }
 
+func (od *oimDriver) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
+	volumeID := req.GetVolumeId()
+	volumePath := req.GetVolumePath()
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
+	}
+	if volumePath == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty volume path")
+	}
+	if od.lvolStore == "" {
+		return nil, status.Error(codes.Unimplemented, "resizing requires an lvol store")
+	}
+
+	// Volume ID is the same as the volume name in CreateVolume. Serialize by that.
+	volumeNameMutex.LockKey(volumeID)
+	defer volumeNameMutex.UnlockKey(volumeID)
+
+	size, err := expandFilesystem(ctx, volumePath)
+	if err != nil {
+		return nil, err
+	}
+	return &csi.NodeExpandVolumeResponse{CapacityBytes: size}, nil
+}
+
 func (od *oimDriver) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
 	volumeID := req.GetVolumeId()
 	volumePath := req.GetVolumePath()
@@ -256,6 +293,12 @@ func (od *oimDriver) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVol
 	volumeNameMutex.LockKey(volumeID)
 	defer volumeNameMutex.UnlockKey(volumeID)
 
-	// TODO: actually check that the path exists and extract information about it.
-	return &csi.NodeGetVolumeStatsResponse{}, nil
+	// CSI 1.1 has no fields for I/O statistics or the volume
+	// condition, those are only available through
+	// GetVolumeStats of the OIM controller.
+	usage, err := filesystemUsage(volumePath)
+	if err != nil {
+		return nil, err
+	}
+	return &csi.NodeGetVolumeStatsResponse{Usage: usage}, nil
 }
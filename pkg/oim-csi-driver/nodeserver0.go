@@ -210,6 +210,12 @@ func (od *oimDriver03) NodeStageVolume(ctx context.Context, req *csi.NodeStageVo
 	}
 
 	options := []string{}
+	if stageReadOnly(req) {
+		if err := setReadOnly(device); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		options = append(options, "ro")
+	}
 	diskMounter := &mount.SafeFormatAndMount{Interface: mount.New(""), Exec: mount.NewOsExec()}
 	if err := diskMounter.FormatAndMount(device, targetPath, fsType, options); err != nil {
 		// We get a pretty bad error code from FormatAndMount ("exit code 1") :-/
@@ -178,3 +178,32 @@ func TestFindDev(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("Unexpected entry in %s, not a major:minor symlink: a:b", tmp), err.Error())
 	}
 }
+
+func TestMatchPCIAddress(t *testing.T) {
+	unknown := uint32(0xFFFF)
+	first := oim.PCIAddress{Domain: 0, Bus: 0, Device: 0x15, Function: 0}
+	second := oim.PCIAddress{Domain: 0, Bus: 0, Device: 0x16, Function: 0}
+	cases := []struct {
+		name       string
+		addr       oim.PCIAddress
+		registered []oim.PCIAddress
+		result     oim.PCIAddress
+		err        bool
+	}{
+		{"none", oim.PCIAddress{Domain: unknown, Bus: unknown, Device: 0x15, Function: unknown}, nil, oim.PCIAddress{}, false},
+		{"single", oim.PCIAddress{Domain: unknown, Bus: unknown, Device: 0x16, Function: unknown}, []oim.PCIAddress{first}, first, false},
+		{"second", oim.PCIAddress{Domain: unknown, Bus: unknown, Device: 0x16, Function: unknown}, []oim.PCIAddress{first, second}, second, false},
+		{"ambiguous", oim.PCIAddress{Domain: unknown, Bus: unknown, Device: unknown, Function: unknown}, []oim.PCIAddress{first, second}, oim.PCIAddress{}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := matchPCIAddress(c.addr, c.registered)
+			if c.err {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, c.result, result)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ package oimcsidriver
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -17,6 +18,7 @@ import (
 	"github.com/intel/oim/pkg/oim-common"
 	"google.golang.org/grpc"
 
+	"github.com/intel/oim/pkg/spdk"
 	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
 	"github.com/intel/oim/pkg/spec/oim/v0"
 )
@@ -34,6 +36,10 @@ const (
 type Driver interface {
 	Start(ctx context.Context) (*oimcommon.NonBlockingGRPCServer, error)
 	Run(ctx context.Context) error
+	// Close frees resources, like the connection to SPDK in
+	// local mode. Must be called after the gRPC server has
+	// stopped.
+	Close()
 }
 
 // oimDriver is the actual implementation based on CSI 1.0.
@@ -46,6 +52,7 @@ type oimDriver struct {
 	remote                remoteSPDK
 	local                 localSPDK
 	emulatedCSIDriverName string
+	lvolStore             string
 
 	backend OIMBackend
 
@@ -69,14 +76,26 @@ type cleanup func() error
 // - OIM CSI driver directly controlling SPDK running on the same host (local.go)
 // - OIM CSI driver controlling SPDK through OIM registry and controller (remote.go)
 type OIMBackend interface {
-	createVolume(ctx context.Context, volumeID string, requiredBytes int64) (int64, error)
+	createVolume(ctx context.Context, volumeID string, requiredBytes int64, source *volumeSource) (int64, error)
 	deleteVolume(ctx context.Context, volumeID string) error
 	checkVolumeExists(ctx context.Context, volumeID string) error
+	resizeVolume(ctx context.Context, volumeID string, requiredBytes int64) (int64, error)
+
+	createSnapshot(ctx context.Context, sourceVolumeID, snapshotID string) (*oim.Snapshot, error)
+	deleteSnapshot(ctx context.Context, snapshotID string) error
+	listSnapshots(ctx context.Context, snapshotID, sourceVolumeID string) ([]*oim.Snapshot, error)
 
 	createDevice(ctx context.Context, volumeID string, request interface{}) (string, cleanup, error)
 	deleteDevice(ctx context.Context, volumeID string) error
 }
 
+// volumeSource defines the initial content of a new volume.
+// Exactly one of the fields is set.
+type volumeSource struct {
+	snapshotID string
+	volumeID   string
+}
+
 // EmulateCSI0Driver deals with parameters meant for some other CSI v0.3 driver.
 type EmulateCSI0Driver struct {
 	CSIDriverName                 string
@@ -196,6 +215,26 @@ func WithEmulation(csiDriverName string) Option {
 	}
 }
 
+// WithLVolStore enables the usage of logical volumes inside the
+// given, existing SPDK lvol store. Those volumes support snapshots
+// and cloning.
+func WithLVolStore(name string) Option {
+	return func(od *oimDriver) error {
+		od.lvolStore = name
+		return nil
+	}
+}
+
+// volumeID maps the CSI name of a new volume or snapshot to its ID.
+// Names of logical volumes are limited in length, therefore longer
+// names get shortened in a deterministic way.
+func (od *oimDriver) volumeID(name string) string {
+	if od.lvolStore == "" || len(name) <= spdk.LVolNameMaxLength {
+		return name
+	}
+	return fmt.Sprintf("%s-%x", name[:30], sha256.Sum256([]byte(name)))[:spdk.LVolNameMaxLength]
+}
+
 // New constructs a new OIM driver instance.
 func New(options ...Option) (Driver, error) {
 	od := oimDriver03{
@@ -224,13 +263,34 @@ func New(options ...Option) (Driver, error) {
 		od.remote.registryKey == "") {
 		return nil, errors.New("Cannot use a OIM registry without a controller ID, CA file and key file")
 	}
-	// malloc capabilities
+	if od.lvolStore != "" && od.emulatedCSIDriverName != "" {
+		return nil, errors.New("lvol store and emulation of another CSI driver are mutually exclusive")
+	}
+	od.local.lvolStore = od.lvolStore
+	od.remote.lvolStore = od.lvolStore
+	// malloc capabilities, plus snapshots, cloning and expansion for logical volumes
 	switch od.csiVersion {
 	case csi03:
-		od.setControllerServiceCapabilities([]csi0.ControllerServiceCapability_RPC_Type{csi0.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME})
+		caps := []csi0.ControllerServiceCapability_RPC_Type{csi0.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME}
+		if od.lvolStore != "" {
+			caps = append(caps,
+				csi0.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+				csi0.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+			)
+		}
+		od.setControllerServiceCapabilities(caps)
 		od.setVolumeCapabilityAccessModes([]csi0.VolumeCapability_AccessMode_Mode{csi0.VolumeCapability_AccessMode_SINGLE_NODE_WRITER})
 	case csi10:
-		od.oimDriver.setControllerServiceCapabilities([]csi.ControllerServiceCapability_RPC_Type{csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME})
+		caps := []csi.ControllerServiceCapability_RPC_Type{csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME}
+		if od.lvolStore != "" {
+			caps = append(caps,
+				csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+				csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+				csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
+				csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
+			)
+		}
+		od.oimDriver.setControllerServiceCapabilities(caps)
 		od.oimDriver.setVolumeCapabilityAccessModes([]csi.VolumeCapability_AccessMode_Mode{csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER})
 	default:
 		return nil, errors.Errorf("running as CSI version %q not supported", od.csiVersion)
@@ -291,6 +351,11 @@ func (od *oimDriver03) Start(ctx context.Context) (*oimcommon.NonBlockingGRPCSer
 	return &s, nil
 }
 
+// Close implements Driver.Close.
+func (od *oimDriver) Close() {
+	od.local.close()
+}
+
 func (od *oimDriver03) Run(ctx context.Context) error {
 	s, err := od.Start(ctx)
 	if err != nil {
@@ -29,10 +29,12 @@ import (
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/oim-controller"
 	"github.com/intel/oim/pkg/oim-registry"
+	spdkclient "github.com/intel/oim/pkg/spdk"
 	"github.com/intel/oim/pkg/spec/oim/v0"
 	"github.com/intel/oim/test/pkg/spdk"
 
 	. "github.com/onsi/ginkgo"
+	ginkgoconfig "github.com/onsi/ginkgo/config"
 )
 
 // SudoMount provides wrappers around several commands used by the k8s
@@ -50,7 +52,7 @@ func SetupSudoMount(t *testing.T) SudoMount {
 		tmpDir:     tmpDir,
 		searchPath: os.Getenv("PATH"),
 	}
-	for _, cmd := range []string{"mount", "umount", "blkid", "fsck", "mkfs.ext2", "mkfs.ext3", "mkfs.ext4"} {
+	for _, cmd := range []string{"mount", "umount", "blkid", "fsck", "mkfs.ext2", "mkfs.ext3", "mkfs.ext4", "resize2fs", "xfs_growfs"} {
 		wrapper := filepath.Join(s.tmpDir, cmd)
 		content := fmt.Sprintf(`#!/bin/sh
 PATH=%q
@@ -81,6 +83,51 @@ func TestSPDK(t *testing.T) {
 	log.SetOutput(GinkgoWriter)
 	ctx := context.Background()
 
+	// Without a real SPDK, the tests run against the simulated
+	// one, which cannot provide the NBD devices needed for
+	// staging and publishing.
+	defer spdk.Finalize()
+	if err := spdk.Init(spdk.WithFake()); err != nil {
+		require.NoError(t, err)
+	}
+	if spdk.Fake != nil {
+		defer func(skip string) {
+			ginkgoconfig.GinkgoConfig.SkipString = skip
+		}(ginkgoconfig.GinkgoConfig.SkipString)
+		ginkgoconfig.GinkgoConfig.SkipString = "Node Service should work"
+	}
+
+	tmp, err := ioutil.TempDir("", "oim-driver")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	endpoint := "unix://" + tmp + "/oim-driver.sock"
+	driver, err := New(WithCSIEndpoint(endpoint), WithVHostEndpoint(spdk.SPDKPath))
+	require.NoError(t, err)
+	defer driver.Close()
+	s, err := driver.Start(ctx)
+	require.NoError(t, err)
+	defer s.ForceStop(ctx)
+
+	sudo := SetupSudoMount(t)
+	defer sudo.Close()
+
+	// Now call the test suite.
+	config := sanity.Config{
+		TargetPath:     tmp + "/target-path",
+		StagingPath:    tmp + "/staging-path",
+		Address:        endpoint,
+		TestVolumeSize: 1 * 1024 * 1024,
+	}
+	sanity.Test(t, &config)
+}
+
+// Runs tests in local SPDK mode with logical volumes.
+func TestSPDKLVol(t *testing.T) {
+	// The sanity suite uses Ginkgo, so log via that.
+	log.SetOutput(GinkgoWriter)
+	ctx := context.Background()
+
 	defer spdk.Finalize()
 	if err := spdk.Init(); err != nil {
 		require.NoError(t, err)
@@ -89,13 +136,33 @@ func TestSPDK(t *testing.T) {
 		t.Skip("No VHost.")
 	}
 
+	base := "sanity-lvs-base"
+	lvolStore := "sanity-lvs"
+	_, err := spdkclient.ConstructMallocBDev(ctx, spdk.SPDK, spdkclient.ConstructMallocBDevArgs{
+		ConstructBDevArgs: spdkclient.ConstructBDevArgs{
+			NumBlocks: 256 * 1024 * 1024 / 512,
+			BlockSize: 512,
+			Name:      base,
+		},
+	})
+	require.NoError(t, err)
+	defer spdkclient.DeleteBDev(ctx, spdk.SPDK, spdkclient.DeleteBDevArgs{Name: base})
+	_, err = spdkclient.ConstructLVolStore(ctx, spdk.SPDK, spdkclient.ConstructLVolStoreArgs{
+		BDevName:  base,
+		LVSName:   lvolStore,
+		ClusterSz: 1024 * 1024,
+	})
+	require.NoError(t, err)
+	defer spdkclient.DestroyLVolStore(ctx, spdk.SPDK, spdkclient.DestroyLVolStoreArgs{LVSName: lvolStore})
+
 	tmp, err := ioutil.TempDir("", "oim-driver")
 	require.NoError(t, err)
 	defer os.RemoveAll(tmp)
 
 	endpoint := "unix://" + tmp + "/oim-driver.sock"
-	driver, err := New(WithCSIEndpoint(endpoint), WithVHostEndpoint(spdk.SPDKPath))
+	driver, err := New(WithCSIEndpoint(endpoint), WithVHostEndpoint(spdk.SPDKPath), WithLVolStore(lvolStore))
 	require.NoError(t, err)
+	defer driver.Close()
 	s, err := driver.Start(ctx)
 	require.NoError(t, err)
 	defer s.ForceStop(ctx)
@@ -103,7 +170,6 @@ func TestSPDK(t *testing.T) {
 	sudo := SetupSudoMount(t)
 	defer sudo.Close()
 
-	// Now call the test suite.
 	config := sanity.Config{
 		TargetPath:     tmp + "/target-path",
 		StagingPath:    tmp + "/staging-path",
@@ -142,6 +208,66 @@ func (m *MockController) CheckMallocBDev(ctx context.Context, in *oim.CheckMallo
 	return &oim.CheckMallocBDevReply{}, nil
 }
 
+func (m *MockController) ProvisionLVol(ctx context.Context, in *oim.ProvisionLVolRequest) (*oim.ProvisionLVolReply, error) {
+	return &oim.ProvisionLVolReply{}, nil
+}
+
+func (m *MockController) CreateSnapshot(ctx context.Context, in *oim.CreateSnapshotRequest) (*oim.CreateSnapshotReply, error) {
+	return &oim.CreateSnapshotReply{}, nil
+}
+
+func (m *MockController) DeleteSnapshot(ctx context.Context, in *oim.DeleteSnapshotRequest) (*oim.DeleteSnapshotReply, error) {
+	return &oim.DeleteSnapshotReply{}, nil
+}
+
+func (m *MockController) ListSnapshots(ctx context.Context, in *oim.ListSnapshotsRequest) (*oim.ListSnapshotsReply, error) {
+	return &oim.ListSnapshotsReply{}, nil
+}
+
+func (m *MockController) CloneVolume(ctx context.Context, in *oim.CloneVolumeRequest) (*oim.CloneVolumeReply, error) {
+	return &oim.CloneVolumeReply{}, nil
+}
+
+func (m *MockController) ResizeVolume(ctx context.Context, in *oim.ResizeVolumeRequest) (*oim.ResizeVolumeReply, error) {
+	return &oim.ResizeVolumeReply{}, nil
+}
+
+func (m *MockController) SetVolumeQoS(ctx context.Context, in *oim.SetVolumeQoSRequest) (*oim.SetVolumeQoSReply, error) {
+	return &oim.SetVolumeQoSReply{}, nil
+}
+
+func (m *MockController) ListOrphans(ctx context.Context, in *oim.ListOrphansRequest) (*oim.ListOrphansReply, error) {
+	return &oim.ListOrphansReply{}, nil
+}
+
+func (m *MockController) CollectGarbage(ctx context.Context, in *oim.CollectGarbageRequest) (*oim.CollectGarbageReply, error) {
+	return &oim.CollectGarbageReply{}, nil
+}
+
+func (m *MockController) ListVolumes(ctx context.Context, in *oim.ListVolumesRequest) (*oim.ListVolumesReply, error) {
+	return &oim.ListVolumesReply{}, nil
+}
+
+func (m *MockController) GetVolume(ctx context.Context, in *oim.GetVolumeRequest) (*oim.GetVolumeReply, error) {
+	return &oim.GetVolumeReply{}, nil
+}
+
+func (m *MockController) GetVolumeStats(ctx context.Context, in *oim.GetVolumeStatsRequest) (*oim.GetVolumeStatsReply, error) {
+	return &oim.GetVolumeStatsReply{}, nil
+}
+
+func (m *MockController) QuiesceVolume(ctx context.Context, in *oim.QuiesceVolumeRequest) (*oim.QuiesceVolumeReply, error) {
+	return &oim.QuiesceVolumeReply{}, nil
+}
+
+func (m *MockController) ResumeVolume(ctx context.Context, in *oim.ResumeVolumeRequest) (*oim.ResumeVolumeReply, error) {
+	return &oim.ResumeVolumeReply{}, nil
+}
+
+func (m *MockController) GetOperation(ctx context.Context, in *oim.GetOperationRequest) (*oim.GetOperationReply, error) {
+	return &oim.GetOperationReply{}, nil
+}
+
 // Runs tests with OIM registry and a mock controller.
 // This can only be used to test the communication paths, but not
 // the actual operation.
@@ -194,6 +320,7 @@ func TestMockOIM(t *testing.T) {
 		WithOIMControllerID(controllerID),
 	)
 	require.NoError(t, err)
+	defer driver.Close()
 	s, err := driver.Start(ctx)
 	require.NoError(t, err)
 	defer s.ForceStop(ctx)
@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// StorageClass parameters for the QoS limits of a volume. They
+// get passed from CreateVolume to NodeStageVolume as volume
+// context.
+const (
+	qosRWIOPS    = "rwIOPS"
+	qosRWMBps    = "rwMBps"
+	qosReadMBps  = "readMBps"
+	qosWriteMBps = "writeMBps"
+)
+
+// parseQoS extracts QoS limits from parameters. It returns nil
+// when none of the QoS parameters are set. Other parameters are
+// ignored.
+func parseQoS(parameters map[string]string) (*oim.QoS, error) {
+	var qos *oim.QoS
+	for _, param := range []struct {
+		name  string
+		value func(qos *oim.QoS) *uint64
+	}{
+		{qosRWIOPS, func(qos *oim.QoS) *uint64 { return &qos.RwIosPerSec }},
+		{qosRWMBps, func(qos *oim.QoS) *uint64 { return &qos.RwMbytesPerSec }},
+		{qosReadMBps, func(qos *oim.QoS) *uint64 { return &qos.RMbytesPerSec }},
+		{qosWriteMBps, func(qos *oim.QoS) *uint64 { return &qos.WMbytesPerSec }},
+	} {
+		str, ok := parameters[param.name]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parameter %s: %s", param.name, err)
+		}
+		if qos == nil {
+			qos = &oim.QoS{}
+		}
+		*param.value(qos) = value
+	}
+	return qos, nil
+}
+
+// stageQoS returns the QoS limits for a NodeStageVolumeRequest of
+// either CSI version.
+func stageQoS(request interface{}) (*oim.QoS, error) {
+	switch r := request.(type) {
+	case *csi.NodeStageVolumeRequest:
+		return parseQoS(r.GetVolumeContext())
+	case *csi0.NodeStageVolumeRequest:
+		return parseQoS(r.GetVolumeAttributes())
+	}
+	return nil, nil
+}
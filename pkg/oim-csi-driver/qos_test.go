@@ -0,0 +1,58 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+func TestParseQoS(t *testing.T) {
+	for _, c := range []struct {
+		parameters map[string]string
+		qos        *oim.QoS
+		code       codes.Code
+	}{
+		{parameters: nil},
+		{parameters: map[string]string{"foo": "bar"}},
+		{
+			parameters: map[string]string{"rwIOPS": "20000", "writeMBps": "10"},
+			qos:        &oim.QoS{RwIosPerSec: 20000, WMbytesPerSec: 10},
+		},
+		{
+			parameters: map[string]string{"rwMBps": "100", "readMBps": "0"},
+			qos:        &oim.QoS{RwMbytesPerSec: 100},
+		},
+		{parameters: map[string]string{"rwIOPS": "-1"}, code: codes.InvalidArgument},
+		{parameters: map[string]string{"readMBps": "fast"}, code: codes.InvalidArgument},
+	} {
+		qos, err := parseQoS(c.parameters)
+		assert.Equal(t, c.code, status.Code(err), "%v", c.parameters)
+		assert.Equal(t, c.qos, qos, "%v", c.parameters)
+	}
+}
+
+func TestStageQoS(t *testing.T) {
+	context := map[string]string{"rwIOPS": "10000"}
+	expected := &oim.QoS{RwIosPerSec: 10000}
+
+	qos, err := stageQoS(&csi.NodeStageVolumeRequest{VolumeContext: context})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, qos)
+
+	qos, err = stageQoS(&csi0.NodeStageVolumeRequest{VolumeAttributes: context})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, qos)
+}
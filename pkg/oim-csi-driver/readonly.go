@@ -0,0 +1,53 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+)
+
+// stageReadOnly returns true if a NodeStageVolumeRequest of either
+// CSI version asks for one of the reader-only access modes.
+func stageReadOnly(request interface{}) bool {
+	switch r := request.(type) {
+	case *csi.NodeStageVolumeRequest:
+		switch r.GetVolumeCapability().GetAccessMode().GetMode() {
+		case csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY,
+			csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY:
+			return true
+		}
+	case *csi0.NodeStageVolumeRequest:
+		switch r.GetVolumeCapability().GetAccessMode().GetMode() {
+		case csi0.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY,
+			csi0.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY:
+			return true
+		}
+	}
+	return false
+}
+
+// setReadOnly marks a block device as read-only in the kernel, like
+// "blockdev --setro". This protects volumes also when SPDK exports
+// them as writable.
+func setReadOnly(device string) error {
+	f, err := os.Open(device)
+	if err != nil {
+		return errors.Wrap(err, "set read-only")
+	}
+	defer f.Close()
+	if err := unix.IoctlSetPointerInt(int(f.Fd()), unix.BLKROSET, 1); err != nil {
+		return errors.Wrapf(err, "set %s read-only", device)
+	}
+	return nil
+}
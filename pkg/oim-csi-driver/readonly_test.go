@@ -0,0 +1,48 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	csi0 "github.com/intel/oim/pkg/spec/csi/v0"
+)
+
+func TestStageReadOnly(t *testing.T) {
+	assert.False(t, stageReadOnly(&csi.NodeStageVolumeRequest{}))
+
+	for mode, readOnly := range map[csi.VolumeCapability_AccessMode_Mode]bool{
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER:      false,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY: true,
+		csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY:  true,
+		csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER: false,
+	} {
+		request := &csi.NodeStageVolumeRequest{
+			VolumeCapability: &csi.VolumeCapability{
+				AccessMode: &csi.VolumeCapability_AccessMode{Mode: mode},
+			},
+		}
+		assert.Equal(t, readOnly, stageReadOnly(request), "CSI 1.x %s", mode)
+	}
+
+	for mode, readOnly := range map[csi0.VolumeCapability_AccessMode_Mode]bool{
+		csi0.VolumeCapability_AccessMode_SINGLE_NODE_WRITER:      false,
+		csi0.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY: true,
+		csi0.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY:  true,
+	} {
+		request := &csi0.NodeStageVolumeRequest{
+			VolumeCapability: &csi0.VolumeCapability{
+				AccessMode: &csi0.VolumeCapability_AccessMode{Mode: mode},
+			},
+		}
+		assert.Equal(t, readOnly, stageReadOnly(request), "CSI 0.3 %s", mode)
+	}
+}
@@ -35,19 +35,27 @@ type remoteSPDK struct {
 	registryCA         string
 	registryKey        string
 	oimControllerID    string
+	lvolStore          string
 
 	mapVolumeParams func(request interface{}, to *oim.MapVolumeRequest) error
 }
 
 var _ OIMBackend = &remoteSPDK{}
 
-func (r *remoteSPDK) createVolume(ctx context.Context, volumeID string, requiredBytes int64) (int64, error) {
+func (r *remoteSPDK) createVolume(ctx context.Context, volumeID string, requiredBytes int64, source *volumeSource) (int64, error) {
 	// Check for maximum available capacity
 	capacity := requiredBytes
 	if capacity >= maxStorageCapacity {
 		return 0, status.Errorf(codes.OutOfRange, "Requested capacity %d exceeds maximum allowed %d", capacity, maxStorageCapacity)
 	}
 
+	if r.lvolStore != "" {
+		return r.createLVol(ctx, volumeID, capacity, source)
+	}
+	if source != nil {
+		return 0, status.Error(codes.Unimplemented, "volume content source requires an lvol store")
+	}
+
 	if capacity == 0 {
 		// If capacity is unset, round up to minimum size (1MB?).
 		capacity = mib
@@ -64,9 +72,142 @@ func (r *remoteSPDK) createVolume(ctx context.Context, volumeID string, required
 }
 
 func (r *remoteSPDK) deleteVolume(ctx context.Context, volumeID string) error {
+	if r.lvolStore != "" {
+		controllerClient, ctx, conn, err := r.controller(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = controllerClient.ProvisionLVol(ctx, &oim.ProvisionLVolRequest{
+			LvolStore: r.lvolStore,
+			VolumeId:  volumeID,
+		})
+		return err
+	}
 	return r.provision(ctx, volumeID, 0)
 }
 
+func (r *remoteSPDK) createLVol(ctx context.Context, volumeID string, requiredBytes int64, source *volumeSource) (int64, error) {
+	controllerClient, ctx, conn, err := r.controller(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if source != nil {
+		reply, err := controllerClient.CloneVolume(ctx, &oim.CloneVolumeRequest{
+			LvolStore:      r.lvolStore,
+			VolumeId:       volumeID,
+			SnapshotId:     source.snapshotID,
+			SourceVolumeId: source.volumeID,
+			Size_:          requiredBytes,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return reply.GetSize_(), nil
+	}
+	if requiredBytes == 0 {
+		requiredBytes = mib
+	}
+	reply, err := controllerClient.ProvisionLVol(ctx, &oim.ProvisionLVolRequest{
+		LvolStore: r.lvolStore,
+		VolumeId:  volumeID,
+		Size_:     requiredBytes,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetSize_(), nil
+}
+
+func (r *remoteSPDK) createSnapshot(ctx context.Context, sourceVolumeID, snapshotID string) (*oim.Snapshot, error) {
+	if r.lvolStore == "" {
+		return nil, status.Error(codes.Unimplemented, "snapshots require an lvol store")
+	}
+	controllerClient, ctx, conn, err := r.controller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	reply, err := controllerClient.CreateSnapshot(ctx, &oim.CreateSnapshotRequest{
+		LvolStore:      r.lvolStore,
+		SourceVolumeId: sourceVolumeID,
+		SnapshotId:     snapshotID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetSnapshot(), nil
+}
+
+func (r *remoteSPDK) deleteSnapshot(ctx context.Context, snapshotID string) error {
+	if r.lvolStore == "" {
+		return status.Error(codes.Unimplemented, "snapshots require an lvol store")
+	}
+	controllerClient, ctx, conn, err := r.controller(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = controllerClient.DeleteSnapshot(ctx, &oim.DeleteSnapshotRequest{
+		LvolStore:  r.lvolStore,
+		SnapshotId: snapshotID,
+	})
+	return err
+}
+
+func (r *remoteSPDK) listSnapshots(ctx context.Context, snapshotID, sourceVolumeID string) ([]*oim.Snapshot, error) {
+	if r.lvolStore == "" {
+		return nil, status.Error(codes.Unimplemented, "snapshots require an lvol store")
+	}
+	controllerClient, ctx, conn, err := r.controller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	reply, err := controllerClient.ListSnapshots(ctx, &oim.ListSnapshotsRequest{
+		LvolStore:      r.lvolStore,
+		SnapshotId:     snapshotID,
+		SourceVolumeId: sourceVolumeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetSnapshots(), nil
+}
+
+func (r *remoteSPDK) resizeVolume(ctx context.Context, volumeID string, requiredBytes int64) (int64, error) {
+	if r.lvolStore == "" {
+		return 0, status.Error(codes.Unimplemented, "resizing requires an lvol store")
+	}
+	controllerClient, ctx, conn, err := r.controller(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	reply, err := controllerClient.ResizeVolume(ctx, &oim.ResizeVolumeRequest{
+		LvolStore: r.lvolStore,
+		VolumeId:  volumeID,
+		Size_:     requiredBytes,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reply.GetSize_(), nil
+}
+
+// controller connects to the OIM controller through the OIM registry.
+// The returned context must be used for calls, the connection must
+// be closed by the caller.
+func (r *remoteSPDK) controller(ctx context.Context) (oim.ControllerClient, context.Context, *grpc.ClientConn, error) {
+	conn, err := r.dialRegistry(ctx)
+	if err != nil {
+		return nil, nil, nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", r.oimControllerID)
+	return oim.NewControllerClient(conn), ctx, conn, nil
+}
+
 func (r *remoteSPDK) provision(ctx context.Context, bdevName string, size int64) error {
 	// Connect to OIM controller through OIM registry.
 	conn, err := r.dialRegistry(ctx)
@@ -92,8 +233,12 @@ func (r *remoteSPDK) checkVolumeExists(ctx context.Context, volumeID string) err
 	defer conn.Close()
 	controllerClient := oim.NewControllerClient(conn)
 	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", r.oimControllerID)
+	bdevName := volumeID
+	if r.lvolStore != "" {
+		bdevName = r.lvolStore + "/" + volumeID
+	}
 	_, err = controllerClient.CheckMallocBDev(ctx, &oim.CheckMallocBDevRequest{
-		BdevName: volumeID,
+		BdevName: bdevName,
 	})
 	return err
 }
@@ -113,6 +258,24 @@ func (r *remoteSPDK) dialRegistry(ctx context.Context) (*grpc.ClientConn, error)
 	return conn, nil
 }
 
+// servingControllerID returns the ID of the controller which
+// currently handles our requests.
+func (r *remoteSPDK) servingControllerID(ctx context.Context, registryClient oim.RegistryClient) (string, error) {
+	path := r.oimControllerID + "/" + oimcommon.RegistryController
+	valuesReply, err := registryClient.GetValues(ctx, &oim.GetValuesRequest{
+		Path: path,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "get controller ID from registry")
+	}
+	for _, value := range valuesReply.GetValues() {
+		if value.GetPath() == path && value.GetValue() != "" {
+			return value.GetValue(), nil
+		}
+	}
+	return r.oimControllerID, nil
+}
+
 func (r *remoteSPDK) createDevice(ctx context.Context, volumeID string, csiRequest interface{}) (string, cleanup, error) {
 	// Connect to OIM controller through OIM registry.
 	conn, err := r.dialRegistry(ctx)
@@ -125,23 +288,30 @@ func (r *remoteSPDK) createDevice(ctx context.Context, volumeID string, csiReque
 
 	// Find out about configured PCI address before
 	// triggering the more complex MapVolume operation.
-	var defPCIAddress oim.PCIAddress
-	path := r.oimControllerID + "/" + oimcommon.RegistryPCI
+	// After a handover, the registry forwards our calls
+	// to a different controller and we need its PCI
+	// address.
+	controllerID, err := r.servingControllerID(ctx, registryClient)
+	if err != nil {
+		return "", nil, err
+	}
+	// A controller with more than one VHost SCSI controller
+	// publishes one address per VHost SCSI controller below
+	// this path.
+	path := controllerID + "/" + oimcommon.RegistryPCI
 	valuesReply, err := registryClient.GetValues(ctx, &oim.GetValuesRequest{
 		Path: path,
 	})
 	if err != nil {
 		return "", nil, errors.Wrap(err, "get PCI address from registry")
 	}
-	if len(valuesReply.GetValues()) > 1 {
-		return "", nil, errors.Errorf("expected at most one PCI address in registry at path %s: %s", path, valuesReply.GetValues())
-	}
-	if len(valuesReply.GetValues()) == 1 {
-		p, err := oimcommon.ParseBDFString(valuesReply.GetValues()[0].Value)
+	var pciAddresses []oim.PCIAddress
+	for _, value := range valuesReply.GetValues() {
+		p, err := oimcommon.ParseBDFString(value.GetValue())
 		if err != nil {
-			return "", nil, errors.Wrapf(err, "get PCI address from registry at path %s", path)
+			return "", nil, errors.Wrapf(err, "get PCI address from registry at path %s", value.GetPath())
 		}
-		defPCIAddress = *p
+		pciAddresses = append(pciAddresses, *p)
 	}
 
 	// Make volume available and/or find out where it is.
@@ -153,6 +323,20 @@ func (r *remoteSPDK) createDevice(ctx context.Context, volumeID string, csiReque
 			Malloc: &oim.MallocParams{},
 		},
 	}
+	if r.lvolStore != "" {
+		request.Params = &oim.MapVolumeRequest_Lvol{
+			Lvol: &oim.LVolParams{
+				LvolStore: r.lvolStore,
+			},
+		}
+	}
+	qos, err := stageQoS(csiRequest)
+	if err != nil {
+		return "", nil, err
+	}
+	request.Qos = qos
+	request.Crypto = stageCrypto(csiRequest)
+	request.Reader = stageReadOnly(csiRequest)
 	if r.mapVolumeParams != nil {
 		// Replace default parameters with the actual
 		// values for the request. Interpretation of
@@ -174,6 +358,10 @@ func (r *remoteSPDK) createDevice(ctx context.Context, volumeID string, csiReque
 	if pciAddress == nil {
 		pciAddress = &oim.PCIAddress{}
 	}
+	defPCIAddress, err := matchPCIAddress(*pciAddress, pciAddresses)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "PCI address %s from controller, %s in registry", oimcommon.PrettyPCIAddress(pciAddress), path)
+	}
 	complete := oimcommon.CompletePCIAddress(*pciAddress, defPCIAddress)
 	if complete.Domain == 0xFFFF {
 		// We default the domain to zero because it
@@ -215,6 +403,31 @@ func (r *remoteSPDK) createDevice(ctx context.Context, volumeID string, csiReque
 	return devNode, cleanup, nil
 }
 
+// matchPCIAddress picks the registry address that fills in the
+// unknown fields of the address returned by MapVolume. A single
+// address is used as it is. Out of several, only those which agree
+// with the returned address are candidates. More than one candidate
+// is an error because it is unknown which one is meant.
+func matchPCIAddress(addr oim.PCIAddress, registered []oim.PCIAddress) (oim.PCIAddress, error) {
+	if len(registered) == 1 {
+		return registered[0], nil
+	}
+	var candidates []oim.PCIAddress
+	for _, p := range registered {
+		if oimcommon.CompatiblePCIAddresses(addr, p) {
+			candidates = append(candidates, p)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return oim.PCIAddress{}, nil
+	case 1:
+		return candidates[0], nil
+	default:
+		return oim.PCIAddress{}, errors.Errorf("%d matching addresses", len(candidates))
+	}
+}
+
 func (r *remoteSPDK) deleteDevice(ctx context.Context, volumeID string) error {
 	// Connect to OIM controller through OIM registry.
 	conn, err := r.dialRegistry(ctx)
@@ -0,0 +1,41 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+)
+
+func TestFilesystemUsage(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "usage")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	usage, err := filesystemUsage(tmp)
+	require.NoError(t, err)
+	require.Len(t, usage, 2)
+	assert.Equal(t, csi.VolumeUsage_BYTES, usage[0].Unit)
+	assert.Equal(t, csi.VolumeUsage_INODES, usage[1].Unit)
+	for _, u := range usage {
+		assert.True(t, u.Total >= u.Used, "used <= total: %v", u)
+		assert.True(t, u.Total >= u.Available, "available <= total: %v", u)
+	}
+	assert.NotZero(t, usage[0].Total, "capacity")
+
+	_, err = filesystemUsage(filepath.Join(tmp, "no-such-dir"))
+	assert.Equal(t, codes.NotFound, status.Code(err), "missing path: %s", err)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/vgough/grpc-proxy/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+const (
+	quiesceVolumeMethod = "/oim.v0.Controller/QuiesceVolume"
+	mapVolumeMethod     = "/oim.v0.Controller/MapVolume"
+)
+
+// handoverKey returns the registry DB key of a handover.
+func handoverKey(target, volumeID string) (string, error) {
+	if target == "" || volumeID == "" {
+		return "", status.Error(codes.InvalidArgument, "empty controller or volume ID")
+	}
+	elements, err := oimcommon.SplitRegistryPath(oimcommon.RegistryHandovers + "/" + target + "/" + volumeID)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return oimcommon.JoinRegistryPath(elements), nil
+}
+
+// handoverContextKey marks a MapVolume call which belongs to a
+// handover in progress.
+type handoverContextKey struct{}
+
+// isHandover returns true for the context of a MapVolume call
+// which was checked by proxyStream.
+func isHandover(ctx context.Context) bool {
+	allowed, _ := ctx.Value(handoverContextKey{}).(bool)
+	return allowed
+}
+
+// proxyStream forwards calls to controllers. It looks at the
+// request of the calls which belong to a handover before
+// forwarding them with the stream director: while the admin
+// quiesces a volume with a target controller, the source controller
+// may map that volume on the target.
+func (r *registry) proxyStream(srv interface{}, stream grpc.ServerStream) error {
+	handler := proxy.TransparentHandler(&streamDirector{r})
+	method, _ := grpc.MethodFromServerStream(stream)
+	if method != quiesceVolumeMethod && method != mapVolumeMethod {
+		return handler(srv, stream)
+	}
+	ctx := stream.Context()
+	peer, err := getPeer(ctx)
+	if err != nil {
+		return err
+	}
+	if !(method == quiesceVolumeMethod && peer == "user.admin") &&
+		!(method == mapVolumeMethod && strings.HasPrefix(peer, "controller.")) {
+		return handler(srv, stream)
+	}
+	var controllerID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["controllerid"]) == 1 {
+		controllerID = md["controllerid"][0]
+	}
+	var raw rawMessage
+	if err := stream.RecvMsg(&raw); err != nil {
+		return err
+	}
+	forwarded := &bufferedStream{ServerStream: stream, ctx: ctx, first: raw, buffered: true}
+
+	if method == quiesceVolumeMethod {
+		var request oim.QuiesceVolumeRequest
+		if err := proto.Unmarshal(raw, &request); err != nil {
+			return status.Errorf(codes.InvalidArgument, "QuiesceVolumeRequest: %s", err)
+		}
+		target := request.GetTargetControllerId()
+		if target == "" {
+			return handler(srv, forwarded)
+		}
+		key, err := handoverKey(target, request.GetVolumeId())
+		if err != nil {
+			return err
+		}
+		if !r.db.CompareAndSwap(key, "", controllerID) {
+			return status.Errorf(codes.Aborted, "volume %s is already being handed over to controller %s", request.GetVolumeId(), target)
+		}
+		defer r.db.CompareAndSwap(key, controllerID, "")
+		return handler(srv, forwarded)
+	}
+
+	var request oim.MapVolumeRequest
+	if err := proto.Unmarshal(raw, &request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "MapVolumeRequest: %s", err)
+	}
+	if key, err := handoverKey(controllerID, request.GetVolumeId()); err == nil &&
+		r.db.Lookup(key) == strings.TrimPrefix(peer, "controller.") {
+		forwarded.ctx = context.WithValue(ctx, handoverContextKey{}, true)
+	}
+	return handler(srv, forwarded)
+}
+
+// rawMessage stores a request as received, so that it can be
+// forwarded unmodified after looking at it.
+type rawMessage []byte
+
+func (m *rawMessage) Reset()         { *m = nil }
+func (m *rawMessage) String() string { return fmt.Sprintf("%d bytes", len(*m)) }
+func (m *rawMessage) ProtoMessage()  {}
+
+// Unmarshal is called by the protobuf codec instead of decoding.
+func (m *rawMessage) Unmarshal(data []byte) error {
+	*m = append(rawMessage(nil), data...)
+	return nil
+}
+
+// bufferedStream returns a message which was already received
+// before the remaining ones.
+type bufferedStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	first    []byte
+	buffered bool
+}
+
+func (s *bufferedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *bufferedStream) RecvMsg(m interface{}) error {
+	if !s.buffered {
+		return s.ServerStream.RecvMsg(m)
+	}
+	s.buffered = false
+	return proxy.Codec().Unmarshal(s.first, m)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// leaseKey returns the registry DB key of the lease for a volume.
+func leaseKey(volumeID string) (string, error) {
+	if volumeID == "" {
+		return "", status.Error(codes.InvalidArgument, "empty volume ID")
+	}
+	elements, err := oimcommon.SplitRegistryPath(oimcommon.RegistryLeases + "/" + volumeID)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return oimcommon.JoinRegistryPath(elements), nil
+}
+
+// controllerPeer returns the ID of the calling controller.
+func controllerPeer(ctx context.Context) (string, error) {
+	peer, err := getPeer(ctx)
+	if err != nil {
+		return "", err
+	}
+	prefix := "controller."
+	if !strings.HasPrefix(peer, prefix) {
+		return "", status.Errorf(codes.PermissionDenied, "caller %q is not a controller", peer)
+	}
+	return peer[len(prefix):], nil
+}
+
+// sharedLeasePrefix marks the registry DB value of a shared lease.
+// It is followed by the sorted, comma-separated IDs of the
+// controllers which hold it. The value of an exclusive lease is just
+// the ID of its holder.
+const sharedLeasePrefix = "shared:"
+
+// lease is the parsed registry DB value. At most one of the two
+// fields is set.
+type lease struct {
+	holder  string
+	readers []string
+}
+
+func parseLease(value string) lease {
+	if strings.HasPrefix(value, sharedLeasePrefix) {
+		return lease{readers: strings.Split(value[len(sharedLeasePrefix):], ",")}
+	}
+	return lease{holder: value}
+}
+
+func (l lease) String() string {
+	if len(l.readers) > 0 {
+		return sharedLeasePrefix + strings.Join(l.readers, ",")
+	}
+	return l.holder
+}
+
+func (l lease) isReader(controllerID string) bool {
+	for _, reader := range l.readers {
+		if reader == controllerID {
+			return true
+		}
+	}
+	return false
+}
+
+// others returns all controllers except the given one which hold
+// the lease.
+func (l lease) others(controllerID string) []string {
+	var others []string
+	if l.holder != "" && l.holder != controllerID {
+		others = append(others, l.holder)
+	}
+	for _, reader := range l.readers {
+		if reader != controllerID {
+			others = append(others, reader)
+		}
+	}
+	return others
+}
+
+// updateLease applies the change to the lease with compare-and-swap,
+// so concurrent updates through different registry instances which
+// share the registry DB cannot overwrite each other. The change is
+// retried when the lease was modified in the meantime. It returns
+// the lease as it was before the change.
+func (r *registry) updateLease(key string, change func(current lease) (lease, error)) (lease, error) {
+	for {
+		old := r.db.Lookup(key)
+		current := parseLease(old)
+		updated, err := change(current)
+		if err != nil {
+			return current, err
+		}
+		value := updated.String()
+		if value == old || r.db.CompareAndSwap(key, old, value) {
+			return current, nil
+		}
+	}
+}
+
+func (r *registry) AcquireLease(ctx context.Context, in *oim.AcquireLeaseRequest) (*oim.AcquireLeaseReply, error) {
+	key, err := leaseKey(in.GetVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	controllerID, err := controllerPeer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := r.updateLease(key, func(current lease) (lease, error) {
+		if current.holder == controllerID {
+			// An exclusive lease also covers reading.
+			return current, nil
+		}
+		// Readers only conflict with an exclusive lease.
+		var others []string
+		switch {
+		case !in.GetShared():
+			others = current.others(controllerID)
+		case current.holder != "":
+			others = []string{current.holder}
+		}
+		if len(others) > 0 {
+			if !in.GetForce() {
+				return current, status.Errorf(codes.FailedPrecondition, "volume %s is in use by controller(s) %s", in.GetVolumeId(), strings.Join(others, ", "))
+			}
+			log.FromContext(ctx).Warnw("taking over lease", "volume", in.GetVolumeId(), "from", others, "to", controllerID, "shared", in.GetShared())
+		}
+		if !in.GetShared() {
+			return lease{holder: controllerID}, nil
+		}
+		var readers []string
+		if current.holder == "" {
+			readers = append(readers, current.readers...)
+		}
+		if !current.isReader(controllerID) {
+			readers = append(readers, controllerID)
+		}
+		sort.Strings(readers)
+		return lease{readers: readers}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &oim.AcquireLeaseReply{
+		PreviousHolder:  previous.holder,
+		PreviousReaders: previous.readers,
+	}, nil
+}
+
+func (r *registry) ReleaseLease(ctx context.Context, in *oim.ReleaseLeaseRequest) (*oim.ReleaseLeaseReply, error) {
+	key, err := leaseKey(in.GetVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	peer, err := getPeer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if peer != "user.admin" && !strings.HasPrefix(peer, "controller.") {
+		return nil, status.Errorf(codes.PermissionDenied, "caller %q not allowed to release leases", peer)
+	}
+
+	if _, err := r.updateLease(key, func(current lease) (lease, error) {
+		if peer == "user.admin" {
+			return lease{}, nil
+		}
+		controllerID := strings.TrimPrefix(peer, "controller.")
+		if current.holder == controllerID {
+			return lease{}, nil
+		}
+		var readers []string
+		for _, reader := range current.readers {
+			if reader != controllerID {
+				readers = append(readers, reader)
+			}
+		}
+		current.readers = readers
+		return current, nil
+	}); err != nil {
+		return nil, err
+	}
+	return &oim.ReleaseLeaseReply{}, nil
+}
@@ -40,6 +40,20 @@ func (m *memRegistryDB) Lookup(controllerID string) (address string) {
 
 	return m.db[controllerID]
 }
+func (m *memRegistryDB) CompareAndSwap(key, old, new string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.db[key] != old {
+		return false
+	}
+	if new == "" {
+		delete(m.db, key)
+	} else {
+		m.db[key] = new
+	}
+	return true
+}
 func (m *memRegistryDB) Foreach(callback func(controllerID, address string) bool) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -38,6 +38,12 @@ type RegistryDB interface {
 	// Foreach iterates over all DB entries until
 	// the callback function returns false.
 	Foreach(func(controllerID, address string) bool)
+
+	// CompareAndSwap atomically replaces the value of an entry
+	// with new if it still is old and returns true if it did.
+	// The empty string stands for a missing entry, as in Store
+	// and Lookup.
+	CompareAndSwap(key, old, new string) bool
 }
 
 // GetRegistryEntries returns all database entries as a map.
@@ -97,13 +103,17 @@ func (r *registry) SetValue(ctx context.Context, in *oim.SetValueRequest) (*oim.
 	}
 	key := oimcommon.JoinRegistryPath(elements)
 
-	// Permission check: admin can set anything, controller only '<controller ID>/address'.
+	// Permission check: admin can set anything, controller only
+	// '<controller ID>/address', '<controller ID>/pci' and
+	// '<controller ID>/pci/<VHost controller>'.
 	peer, err := getPeer(ctx)
 	if err != nil {
 		return nil, err
 	}
 	allowed := peer == "user.admin" ||
-		peer == "controller."+elements[0] && len(elements) == 2 && elements[1] == oimcommon.RegistryAddress
+		peer == "controller."+elements[0] &&
+			(len(elements) == 2 && (elements[1] == oimcommon.RegistryAddress || elements[1] == oimcommon.RegistryPCI) ||
+				len(elements) == 3 && elements[1] == oimcommon.RegistryPCI)
 	if !allowed {
 		return nil, status.Errorf(codes.PermissionDenied, "caller %q not allowed to set %q", peer, key)
 	}
@@ -150,6 +160,23 @@ func (r *registry) StreamDirector() proxy.StreamDirector {
 	return &streamDirector{r}
 }
 
+// readOnlyMethods are the controller methods that only return
+// information.
+var readOnlyMethods = map[string]bool{
+	"/oim.v0.Controller/ListVolumes":    true,
+	"/oim.v0.Controller/GetVolume":      true,
+	"/oim.v0.Controller/GetVolumeStats": true,
+	"/oim.v0.Controller/GetOperation":   true,
+	"/grpc.health.v1.Health/Check":      true,
+}
+
+// handoverMethods are the controller methods that the admin needs
+// for moving volumes between controllers.
+var handoverMethods = map[string]bool{
+	"/oim.v0.Controller/QuiesceVolume": true,
+	"/oim.v0.Controller/ResumeVolume":  true,
+}
+
 type streamDirector struct {
 	r *registry
 }
@@ -172,17 +199,33 @@ func (sd *streamDirector) Connect(ctx context.Context, method string) (context.C
 	controllerID := controllerIDs[0]
 
 	// Permission check: only the host service with the same
-	// controller ID can contact the controller.
+	// controller ID can contact the controller. The admin may
+	// use methods which do not change anything and those needed
+	// for a handover. A quiesced controller maps the volume on the
+	// target of a handover itself, so the secrets in the request
+	// do not leave the controllers. proxyStream checks that such
+	// a call belongs to the handover.
 	peer, err := getPeer(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 	prefix := "host."
-	if !strings.HasPrefix(peer, prefix) ||
-		peer[len(prefix):] != controllerID {
+	if !(peer == "user.admin" && (readOnlyMethods[method] || handoverMethods[method])) &&
+		!(strings.HasPrefix(peer, "controller.") && method == mapVolumeMethod && isHandover(ctx)) &&
+		(!strings.HasPrefix(peer, prefix) ||
+			peer[len(prefix):] != controllerID) {
 		return nil, nil, status.Errorf(codes.PermissionDenied, "caller %q not allowed to contact controller %q", peer, controllerID)
 	}
 
+	// After a handover, the host is served by a different
+	// controller. The admin always gets the controller it asked
+	// for.
+	if strings.HasPrefix(peer, prefix) {
+		if target := sd.r.db.Lookup(controllerID + "/" + oimcommon.RegistryController); target != "" {
+			controllerID = target
+		}
+	}
+
 	address := sd.r.db.Lookup(controllerID + "/" + oimcommon.RegistryAddress)
 	if address == "" {
 		return nil, nil, status.Errorf(codes.Unavailable, "%s: no address registered", controllerID)
@@ -253,7 +296,7 @@ func (r *registry) Server(endpoint string) (*oimcommon.NonBlockingGRPCServer, fu
 		Endpoint: endpoint,
 		ServerOptions: []grpc.ServerOption{
 			grpc.CustomCodec(proxy.Codec()),
-			grpc.UnknownServiceHandler(proxy.TransparentHandler(&streamDirector{r})),
+			grpc.UnknownServiceHandler(r.proxyStream),
 			grpc.Creds(credentials.NewTLS(r.tlsConfig)),
 		},
 	}
@@ -8,12 +8,16 @@ package oimregistry_test
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/oim-controller"
@@ -30,6 +34,24 @@ type MockController struct {
 	UnmapVolumes         []oim.UnmapVolumeRequest
 	ProvisionMallocBDevs []oim.ProvisionMallocBDevRequest
 	CheckMallocBDevs     []oim.CheckMallocBDevRequest
+	ProvisionLVols       []oim.ProvisionLVolRequest
+	CreateSnapshots      []oim.CreateSnapshotRequest
+	DeleteSnapshots      []oim.DeleteSnapshotRequest
+	ListSnapshotsCalls   []oim.ListSnapshotsRequest
+	CloneVolumes         []oim.CloneVolumeRequest
+	ResizeVolumes        []oim.ResizeVolumeRequest
+	SetVolumeQoSCalls    []oim.SetVolumeQoSRequest
+	ListOrphansCalls     []oim.ListOrphansRequest
+	CollectGarbageCalls  []oim.CollectGarbageRequest
+	ListVolumesCalls     []oim.ListVolumesRequest
+	GetVolumeCalls       []oim.GetVolumeRequest
+	GetVolumeStatsCalls  []oim.GetVolumeStatsRequest
+	GetOperationCalls    []oim.GetOperationRequest
+	QuiesceVolumeCalls   []oim.QuiesceVolumeRequest
+	ResumeVolumeCalls    []oim.ResumeVolumeRequest
+
+	// quiesce gets called by QuiesceVolume, if set.
+	quiesce func(in *oim.QuiesceVolumeRequest)
 }
 
 func (m *MockController) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*oim.MapVolumeReply, error) {
@@ -52,6 +74,84 @@ func (m *MockController) CheckMallocBDev(ctx context.Context, in *oim.CheckMallo
 	return &oim.CheckMallocBDevReply{}, nil
 }
 
+func (m *MockController) ProvisionLVol(ctx context.Context, in *oim.ProvisionLVolRequest) (*oim.ProvisionLVolReply, error) {
+	m.ProvisionLVols = append(m.ProvisionLVols, *in)
+	return &oim.ProvisionLVolReply{}, nil
+}
+
+func (m *MockController) CreateSnapshot(ctx context.Context, in *oim.CreateSnapshotRequest) (*oim.CreateSnapshotReply, error) {
+	m.CreateSnapshots = append(m.CreateSnapshots, *in)
+	return &oim.CreateSnapshotReply{}, nil
+}
+
+func (m *MockController) DeleteSnapshot(ctx context.Context, in *oim.DeleteSnapshotRequest) (*oim.DeleteSnapshotReply, error) {
+	m.DeleteSnapshots = append(m.DeleteSnapshots, *in)
+	return &oim.DeleteSnapshotReply{}, nil
+}
+
+func (m *MockController) ListSnapshots(ctx context.Context, in *oim.ListSnapshotsRequest) (*oim.ListSnapshotsReply, error) {
+	m.ListSnapshotsCalls = append(m.ListSnapshotsCalls, *in)
+	return &oim.ListSnapshotsReply{}, nil
+}
+
+func (m *MockController) CloneVolume(ctx context.Context, in *oim.CloneVolumeRequest) (*oim.CloneVolumeReply, error) {
+	m.CloneVolumes = append(m.CloneVolumes, *in)
+	return &oim.CloneVolumeReply{}, nil
+}
+
+func (m *MockController) ResizeVolume(ctx context.Context, in *oim.ResizeVolumeRequest) (*oim.ResizeVolumeReply, error) {
+	m.ResizeVolumes = append(m.ResizeVolumes, *in)
+	return &oim.ResizeVolumeReply{}, nil
+}
+
+func (m *MockController) SetVolumeQoS(ctx context.Context, in *oim.SetVolumeQoSRequest) (*oim.SetVolumeQoSReply, error) {
+	m.SetVolumeQoSCalls = append(m.SetVolumeQoSCalls, *in)
+	return &oim.SetVolumeQoSReply{}, nil
+}
+
+func (m *MockController) ListOrphans(ctx context.Context, in *oim.ListOrphansRequest) (*oim.ListOrphansReply, error) {
+	m.ListOrphansCalls = append(m.ListOrphansCalls, *in)
+	return &oim.ListOrphansReply{}, nil
+}
+
+func (m *MockController) CollectGarbage(ctx context.Context, in *oim.CollectGarbageRequest) (*oim.CollectGarbageReply, error) {
+	m.CollectGarbageCalls = append(m.CollectGarbageCalls, *in)
+	return &oim.CollectGarbageReply{}, nil
+}
+
+func (m *MockController) ListVolumes(ctx context.Context, in *oim.ListVolumesRequest) (*oim.ListVolumesReply, error) {
+	m.ListVolumesCalls = append(m.ListVolumesCalls, *in)
+	return &oim.ListVolumesReply{}, nil
+}
+
+func (m *MockController) GetVolume(ctx context.Context, in *oim.GetVolumeRequest) (*oim.GetVolumeReply, error) {
+	m.GetVolumeCalls = append(m.GetVolumeCalls, *in)
+	return &oim.GetVolumeReply{}, nil
+}
+
+func (m *MockController) GetVolumeStats(ctx context.Context, in *oim.GetVolumeStatsRequest) (*oim.GetVolumeStatsReply, error) {
+	m.GetVolumeStatsCalls = append(m.GetVolumeStatsCalls, *in)
+	return &oim.GetVolumeStatsReply{}, nil
+}
+
+func (m *MockController) QuiesceVolume(ctx context.Context, in *oim.QuiesceVolumeRequest) (*oim.QuiesceVolumeReply, error) {
+	m.QuiesceVolumeCalls = append(m.QuiesceVolumeCalls, *in)
+	if m.quiesce != nil {
+		m.quiesce(in)
+	}
+	return &oim.QuiesceVolumeReply{}, nil
+}
+
+func (m *MockController) ResumeVolume(ctx context.Context, in *oim.ResumeVolumeRequest) (*oim.ResumeVolumeReply, error) {
+	m.ResumeVolumeCalls = append(m.ResumeVolumeCalls, *in)
+	return &oim.ResumeVolumeReply{}, nil
+}
+
+func (m *MockController) GetOperation(ctx context.Context, in *oim.GetOperationRequest) (*oim.GetOperationReply, error) {
+	m.GetOperationCalls = append(m.GetOperationCalls, *in)
+	return &oim.GetOperationReply{}, nil
+}
+
 var _ = Describe("OIM Registry", func() {
 	ctx := context.Background()
 	adminCtx := oimregistry.RegistryClientContext(ctx, "user.admin")
@@ -162,6 +262,172 @@ var _ = Describe("OIM Registry", func() {
 				&oim.Value{Path: key2, Value: value2},
 			}))
 		})
+
+		It("should let controllers set their own entries", func() {
+			db := oimregistry.NewMemRegistryDB()
+			tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
+			Expect(err).NotTo(HaveOccurred())
+			r, err := oimregistry.New(oimregistry.DB(db), oimregistry.TLS(tlsConfig))
+			Expect(err).NotTo(HaveOccurred())
+			controllerCtx := oimregistry.RegistryClientContext(ctx, "controller.host-0")
+
+			for _, path := range []string{"host-0/" + oimcommon.RegistryAddress, "host-0/" + oimcommon.RegistryPCI, "host-0/" + oimcommon.RegistryPCI + "/vhost.1"} {
+				_, err = r.SetValue(controllerCtx, &oim.SetValueRequest{
+					Value: &oim.Value{Path: path, Value: "foo"},
+				})
+				Expect(err).NotTo(HaveOccurred(), path)
+			}
+			for _, path := range []string{"host-0/foo", "host-1/" + oimcommon.RegistryPCI, "host-0/" + oimcommon.RegistryAddress + "/foo", "host-0/" + oimcommon.RegistryPCI + "/vhost.1/foo"} {
+				_, err = r.SetValue(controllerCtx, &oim.SetValueRequest{
+					Value: &oim.Value{Path: path, Value: "foo"},
+				})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied), path)
+			}
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{
+				"host-0/" + oimcommon.RegistryAddress:          "foo",
+				"host-0/" + oimcommon.RegistryPCI:              "foo",
+				"host-0/" + oimcommon.RegistryPCI + "/vhost.1": "foo",
+			}))
+		})
+	})
+
+	Describe("leases", func() {
+		It("should fence volumes", func() {
+			db := oimregistry.NewMemRegistryDB()
+			tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
+			Expect(err).NotTo(HaveOccurred())
+			r, err := oimregistry.New(oimregistry.DB(db), oimregistry.TLS(tlsConfig))
+			Expect(err).NotTo(HaveOccurred())
+			host0 := oimregistry.RegistryClientContext(ctx, "controller.host-0")
+			host1 := oimregistry.RegistryClientContext(ctx, "controller.host-1")
+			key := oimcommon.RegistryLeases + "/my-volume"
+
+			reply, err := r.AcquireLease(host0, &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetPreviousHolder()).To(Equal(""))
+			reply, err = r.AcquireLease(host0, &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred(), "acquire again")
+			Expect(reply.GetPreviousHolder()).To(Equal("host-0"))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{key: "host-0"}))
+
+			By("conflicting with another controller")
+			_, err = r.AcquireLease(host1, &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			_, err = r.AcquireLease(oimregistry.RegistryClientContext(ctx, "host.host-1"), &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+			By("forcing")
+			reply, err = r.AcquireLease(host1, &oim.AcquireLeaseRequest{VolumeId: "my-volume", Force: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetPreviousHolder()).To(Equal("host-0"))
+
+			By("releasing")
+			_, err = r.ReleaseLease(host0, &oim.ReleaseLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{key: "host-1"}), "not released by old holder")
+			_, err = r.ReleaseLease(host1, &oim.ReleaseLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(oimregistry.GetRegistryEntries(db)).To(BeEmpty())
+			_, err = r.ReleaseLease(host1, &oim.ReleaseLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred(), "release again")
+
+			By("admin")
+			_, err = r.AcquireLease(host0, &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = r.ReleaseLease(adminCtx, &oim.ReleaseLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(oimregistry.GetRegistryEntries(db)).To(BeEmpty())
+		})
+
+		It("should share leases between readers", func() {
+			db := oimregistry.NewMemRegistryDB()
+			tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
+			Expect(err).NotTo(HaveOccurred())
+			r, err := oimregistry.New(oimregistry.DB(db), oimregistry.TLS(tlsConfig))
+			Expect(err).NotTo(HaveOccurred())
+			host0 := oimregistry.RegistryClientContext(ctx, "controller.host-0")
+			host1 := oimregistry.RegistryClientContext(ctx, "controller.host-1")
+			host2 := oimregistry.RegistryClientContext(ctx, "controller.host-2")
+			key := oimcommon.RegistryLeases + "/my-volume"
+			shared := &oim.AcquireLeaseRequest{VolumeId: "my-volume", Shared: true}
+
+			_, err = r.AcquireLease(host1, shared)
+			Expect(err).NotTo(HaveOccurred())
+			reply, err := r.AcquireLease(host0, shared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetPreviousReaders()).To(Equal([]string{"host-1"}))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{key: "shared:host-0,host-1"}))
+
+			By("conflicting with a writer")
+			_, err = r.AcquireLease(host2, &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(err.Error()).To(ContainSubstring("host-0, host-1"))
+
+			By("releasing")
+			_, err = r.ReleaseLease(host0, &oim.ReleaseLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{key: "shared:host-1"}))
+
+			By("upgrading the only reader")
+			reply, err = r.AcquireLease(host1, &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetPreviousReaders()).To(Equal([]string{"host-1"}))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{key: "host-1"}))
+
+			By("conflicting with the writer")
+			_, err = r.AcquireLease(host0, shared)
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			_, err = r.AcquireLease(host1, shared)
+			Expect(err).NotTo(HaveOccurred(), "writer also reads")
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{key: "host-1"}))
+
+			By("forcing")
+			reply, err = r.AcquireLease(host0, &oim.AcquireLeaseRequest{VolumeId: "my-volume", Shared: true, Force: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetPreviousHolder()).To(Equal("host-1"))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{key: "shared:host-0"}))
+
+			By("admin")
+			_, err = r.AcquireLease(host2, shared)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = r.ReleaseLease(adminCtx, &oim.ReleaseLeaseRequest{VolumeId: "my-volume"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(oimregistry.GetRegistryEntries(db)).To(BeEmpty())
+		})
+
+		It("should grant one lease across registry instances", func() {
+			db := oimregistry.NewMemRegistryDB()
+			tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
+			Expect(err).NotTo(HaveOccurred())
+			var registries []oimregistry.RegistryServer
+			for i := 0; i < 2; i++ {
+				r, err := oimregistry.New(oimregistry.DB(db), oimregistry.TLS(tlsConfig))
+				Expect(err).NotTo(HaveOccurred())
+				registries = append(registries, r)
+			}
+
+			const numControllers = 10
+			var wg sync.WaitGroup
+			errs := make([]error, numControllers)
+			for i := 0; i < numControllers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					host := oimregistry.RegistryClientContext(ctx, fmt.Sprintf("controller.host-%d", i))
+					_, errs[i] = registries[i%len(registries)].AcquireLease(host, &oim.AcquireLeaseRequest{VolumeId: "my-volume"})
+				}(i)
+			}
+			wg.Wait()
+			granted := 0
+			for _, err := range errs {
+				if err == nil {
+					granted++
+				} else {
+					Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+				}
+			}
+			Expect(granted).To(Equal(1))
+		})
 	})
 
 	Describe("server", func() {
@@ -367,6 +633,112 @@ var _ = Describe("OIM Registry", func() {
 				})
 			}
 
+			It("should let admin inspect and hand over volumes", func() {
+				setupController(ca, key)
+
+				adminCreds, err := oimcommon.LoadTLS(ca, os.ExpandEnv("${TEST_WORK}/ca/user.admin.key"), "component.registry")
+				Expect(err).NotTo(HaveOccurred())
+				opts := oimcommon.ChooseDialOpts(registryAddress, grpc.WithTransportCredentials(adminCreds))
+				conn, err := grpc.Dial(registryAddress, opts...)
+				Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+				adminClient := oim.NewControllerClient(conn)
+
+				callCtx := metadata.AppendToOutgoingContext(ctx, "controllerid", controllerID)
+				_, err = adminClient.ListVolumes(callCtx, &oim.ListVolumesRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = adminClient.GetVolume(callCtx, &oim.GetVolumeRequest{VolumeId: "my-volume"})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = adminClient.GetOperation(callCtx, &oim.GetOperationRequest{OperationId: "my-operation"})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = adminClient.QuiesceVolume(callCtx, &oim.QuiesceVolumeRequest{VolumeId: "my-volume"})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = adminClient.UnmapVolume(callCtx, &oim.UnmapVolumeRequest{VolumeId: "my-volume"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`caller "user.admin" not allowed to contact controller "host-0"`))
+				_, err = adminClient.MapVolume(callCtx, &oim.MapVolumeRequest{VolumeId: "my-volume"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`caller "user.admin" not allowed to contact controller "host-0"`))
+				Expect(*controller).To(Equal(MockController{
+					ListVolumesCalls:   []oim.ListVolumesRequest{{}},
+					GetVolumeCalls:     []oim.GetVolumeRequest{{VolumeId: "my-volume"}},
+					GetOperationCalls:  []oim.GetOperationRequest{{OperationId: "my-operation"}},
+					QuiesceVolumeCalls: []oim.QuiesceVolumeRequest{{VolumeId: "my-volume"}},
+				}))
+			})
+
+			It("should let controllers map volumes only during a handover", func() {
+				setupController(ca, key)
+
+				// The source of the handover, with its own
+				// connection to the registry.
+				otherCreds, err := oimcommon.LoadTLS(ca, wrongKey, "component.registry")
+				Expect(err).NotTo(HaveOccurred())
+				opts := oimcommon.ChooseDialOpts(registryAddress, grpc.WithTransportCredentials(otherCreds))
+				conn, err := grpc.Dial(registryAddress, opts...)
+				Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+				otherClient := oim.NewControllerClient(conn)
+				var handoverErrs []error
+				source := &MockController{
+					quiesce: func(in *oim.QuiesceVolumeRequest) {
+						callCtx := metadata.AppendToOutgoingContext(context.Background(), "controllerid", in.GetTargetControllerId())
+						_, err := otherClient.MapVolume(callCtx, &oim.MapVolumeRequest{VolumeId: in.GetVolumeId()})
+						handoverErrs = append(handoverErrs, err)
+						_, err = otherClient.MapVolume(callCtx, &oim.MapVolumeRequest{VolumeId: "other-volume"})
+						handoverErrs = append(handoverErrs, err)
+					},
+				}
+				serverCreds, err := oimcommon.LoadTLS(ca, wrongKey, "component.registry")
+				Expect(err).NotTo(HaveOccurred())
+				sourceAddress := "unix://" + filepath.Join(tmpDir, "source.sock")
+				server, service := oimcontroller.Server(sourceAddress, source, serverCreds)
+				err = server.Start(ctx, service)
+				Expect(err).NotTo(HaveOccurred())
+				defer func() {
+					server.ForceStop(ctx)
+					server.Wait(ctx)
+				}()
+				_, err = registry.SetValue(adminCtx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  "host-1/" + oimcommon.RegistryAddress,
+						Value: sourceAddress,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				callCtx := metadata.AppendToOutgoingContext(ctx, "controllerid", controllerID)
+				_, err = otherClient.MapVolume(callCtx, &oim.MapVolumeRequest{VolumeId: "my-volume"})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+				By("handing over")
+				adminCreds, err := oimcommon.LoadTLS(ca, os.ExpandEnv("${TEST_WORK}/ca/user.admin.key"), "component.registry")
+				Expect(err).NotTo(HaveOccurred())
+				opts = oimcommon.ChooseDialOpts(registryAddress, grpc.WithTransportCredentials(adminCreds))
+				adminConn, err := grpc.Dial(registryAddress, opts...)
+				Expect(err).NotTo(HaveOccurred())
+				defer adminConn.Close()
+				sourceCtx := metadata.AppendToOutgoingContext(ctx, "controllerid", "host-1")
+				_, err = oim.NewControllerClient(adminConn).QuiesceVolume(sourceCtx, &oim.QuiesceVolumeRequest{
+					VolumeId:           "my-volume",
+					TargetControllerId: controllerID,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handoverErrs).To(HaveLen(2))
+				Expect(handoverErrs[0]).NotTo(HaveOccurred())
+				Expect(status.Code(handoverErrs[1])).To(Equal(codes.PermissionDenied))
+
+				By("checking after the handover")
+				_, err = otherClient.MapVolume(callCtx, &oim.MapVolumeRequest{VolumeId: "my-volume"})
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				_, err = otherClient.UnmapVolume(callCtx, &oim.UnmapVolumeRequest{VolumeId: "my-volume"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`caller "controller.host-1" not allowed to contact controller "host-0"`))
+				Expect(*controller).To(Equal(MockController{
+					MapVolumes: []oim.MapVolumeRequest{{VolumeId: "my-volume"}},
+				}))
+			})
+
 			It("controller should detect wrong registry", func() {
 				// Setup controller with normal creds.
 				setupController(ca, key)
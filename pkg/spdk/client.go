@@ -42,15 +42,18 @@ The original license text is as follows:
 package spdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/rpc"
 	"regexp"
-	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/intel/oim/pkg/log"
 )
@@ -67,31 +70,15 @@ const (
 	ERROR_INVALID_STATE = -1
 )
 
-// jsonError matches against errors strings as encoded by ReadResponseHeader.
-var jsonError = regexp.MustCompile(`^code: (-?\d+) msg: (.*)$`)
-
-// IsJSONError checks that the error has the expected error code. Use
-// code == 0 to check for any JSONError.
-func IsJSONError(err error, code int) bool {
-	m := jsonError.FindStringSubmatch(err.Error())
-	if m == nil {
-		return false
-	}
-	errorCode, ok := strconv.Atoi(m[1])
-	if ok != nil {
-		return false
-	}
-	return code == 0 || errorCode == code
-}
-
 type clientCodec struct {
 	dec *json.Decoder // for reading JSON values
 	enc *json.Encoder // for writing JSON values
 	c   io.Closer
 
 	// temporary work space
-	req  clientRequest
-	resp clientResponse
+	req     clientRequest
+	resp    clientResponse
+	respErr *Error
 
 	// JSON-RPC responses include the request id but not the request method.
 	// Package rpc expects both.
@@ -99,16 +86,27 @@ type clientCodec struct {
 	// and then look it up by request ID when filling out the rpc Response.
 	mutex   sync.Mutex        // protects pending
 	pending map[uint64]string // map request id to method name
+
+	// broken gets called when reading fails, which
+	// shuts down the rpc.Client using the codec.
+	broken func(*clientCodec)
+
+	// The methods supported by SPDK on this connection, see
+	// Client.supportedMethods.
+	methodsMutex sync.Mutex
+	methods      methodSet
+	methodsKnown bool
 }
 
 // newClientCodec returns a new rpc.ClientCodec using JSON-RPC on conn.
-func newClientCodec(conn io.ReadWriteCloser) rpc.ClientCodec {
+func newClientCodec(conn io.ReadWriteCloser, broken func(*clientCodec)) *clientCodec {
 	return &clientCodec{
 		dec:     json.NewDecoder(conn),
 		enc:     json.NewEncoder(conn),
 		c:       conn,
 		req:     clientRequest{Version: "2.0"},
 		pending: make(map[uint64]string),
+		broken:  broken,
 	}
 }
 
@@ -155,6 +153,14 @@ func (r *clientResponse) reset() {
 // an error here is treated as a failed connection, so we can only
 // do that for real connection problems.
 func (c *clientCodec) ReadResponseHeader(r *rpc.Response) error {
+	err := c.readResponseHeader(r)
+	if err != nil && c.broken != nil {
+		c.broken(c)
+	}
+	return err
+}
+
+func (c *clientCodec) readResponseHeader(r *rpc.Response) error {
 	c.resp.reset()
 	if err := c.dec.Decode(&c.resp); err != nil {
 		return err
@@ -165,8 +171,11 @@ func (c *clientCodec) ReadResponseHeader(r *rpc.Response) error {
 	delete(c.pending, c.resp.ID)
 	c.mutex.Unlock()
 
+	// Errors reported by SPDK are passed to the reply by
+	// ReadResponseBody, because r.Error only supports strings.
 	r.Error = ""
 	r.Seq = c.resp.ID
+	c.respErr = nil
 	if c.resp.Error != nil || c.resp.Result == nil {
 		// SPDK returns a map[string]interface {}
 		// with "code" and "message" as keys.
@@ -190,10 +199,7 @@ func (c *clientCodec) ReadResponseHeader(r *rpc.Response) error {
 			if !haveCode || !haveMessage {
 				return fmt.Errorf("invalid error content %v", c.resp.Error)
 			}
-			// It would be nice to return the real error code through
-			// net/rpc, but it only supports simple strings. Therefore
-			// we have to encode the available information as string.
-			r.Error = fmt.Sprintf("code: %d msg: %s", codeVal, messageVal)
+			c.respErr = &Error{Code: codeVal, Message: messageVal, Method: r.ServiceMethod}
 		} else {
 			// The following code is from the original
 			// net/rpc/json: it expects a simple string
@@ -205,16 +211,33 @@ func (c *clientCodec) ReadResponseHeader(r *rpc.Response) error {
 			if x == "" {
 				x = "unspecified error"
 			}
-			r.Error = x
+			c.respErr = &Error{Message: x, Method: r.ServiceMethod}
 		}
 	}
 	return nil
 }
 
+// response is the reply that Invoke passes to the rpc.Client. It
+// receives either the raw result or the error reported by SPDK.
+type response struct {
+	result json.RawMessage
+	err    *Error
+}
+
 func (c *clientCodec) ReadResponseBody(x interface{}) error {
 	if x == nil {
 		return nil
 	}
+	if resp, ok := x.(*response); ok {
+		resp.err = c.respErr
+		if c.respErr == nil {
+			resp.result = *c.resp.Result
+		}
+		return nil
+	}
+	if c.respErr != nil {
+		return c.respErr
+	}
 	return json.Unmarshal(*c.resp.Result, x)
 }
 
@@ -222,27 +245,90 @@ func (c *clientCodec) Close() error {
 	return c.c.Close()
 }
 
-// Client encapsulates the connection to a SPDK JSON server.
+// ErrDisconnected is returned by Invoke while the Client waits for
+// SPDK to accept connections again.
+var ErrDisconnected = errors.New("not connected to SPDK")
+
+// Client encapsulates the connection to a SPDK JSON server. When the
+// connection breaks, for example because SPDK was restarted, the
+// client dials again in the background with exponential backoff.
 type Client struct {
+	path        string
+	dialer      func() (net.Conn, error)
+	recorder    *recorder
+	onReconnect func()
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+
+	mutex  sync.Mutex // protects the following fields
 	client *rpc.Client
+	codec  *clientCodec
+	// connections counts how often dial succeeded.
+	connections uint64
+	closed      bool
+	stop        chan interface{}
+	wg          sync.WaitGroup
+}
+
+// Option is the type of all optional parameters for New.
+type Option func(c *Client)
+
+// WithReconnectHandler sets a function that gets called each time
+// the Client has connected to SPDK again after losing the
+// connection. It is called in a separate goroutine and may use the
+// Client.
+func WithReconnectHandler(handler func()) Option {
+	return func(c *Client) {
+		c.onReconnect = handler
+	}
+}
+
+// WithReconnectBackoff sets the initial and the maximum delay between
+// attempts to connect to SPDK again. The default is 100ms and 30s.
+func WithReconnectBackoff(min, max time.Duration) Option {
+	return func(c *Client) {
+		c.minBackoff = min
+		c.maxBackoff = max
+	}
 }
 
+// logConn logs and records all messages. Both SPDK and the JSON
+// encoder write one message per line, so incomplete lines are
+// buffered until the entire message can be logged with secrets
+// removed.
 type logConn struct {
 	net.Conn
-	logger log.Logger
+	logger   log.Logger
+	recorder *recorder
+	// closed is set when the connection gets closed by the
+	// client, then read errors are expected.
+	closed int32
+
+	readBuffer bytes.Buffer
 }
 
 func (lc *logConn) Read(b []byte) (int, error) {
 	n, err := lc.Conn.Read(b)
 	if err == nil {
-		lc.logger.Debugw("read", "data", log.LineBuffer(b[:n]))
-	} else if err != io.EOF {
+		lc.readBuffer.Write(b[:n])
+		for {
+			end := bytes.IndexByte(lc.readBuffer.Bytes(), '\n')
+			if end < 0 {
+				break
+			}
+			msg := stripSecrets(lc.readBuffer.Next(end + 1))
+			lc.logger.Debugw("read", "data", log.LineBuffer(msg))
+			lc.recorder.record(responsePrefix, msg)
+		}
+	} else if err != io.EOF && atomic.LoadInt32(&lc.closed) == 0 {
 		lc.logger.Errorw("read error", "error", err)
 	}
 	return n, err
 }
 func (lc *logConn) Write(b []byte) (int, error) {
-	lc.logger.Debugw("write", "data", log.LineBuffer(b))
+	msg := stripSecrets(b)
+	lc.logger.Debugw("write", "data", log.LineBuffer(msg))
+	lc.recorder.record(requestPrefix, msg)
 	n, err := lc.Conn.Write(b)
 	if err != nil {
 		lc.logger.Errorw("write error", "error", err)
@@ -250,23 +336,225 @@ func (lc *logConn) Write(b []byte) (int, error) {
 	return n, err
 }
 
-// New constructs a new SPDK JSON client.
-func New(path string) (*Client, error) {
-	conn, err := net.Dial("unix", path)
-	if err != nil {
+func (lc *logConn) Close() error {
+	atomic.StoreInt32(&lc.closed, 1)
+	return lc.Conn.Close()
+}
+
+// secretValue matches string values of parameters which contain
+// secrets, like the "key" of a crypto BDev or in the Ceph
+// configuration of an RBD BDev.
+var secretValue = regexp.MustCompile(`("(?:key|secret)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// stripSecrets returns a copy of a JSON message where secret values
+// are replaced.
+func stripSecrets(b []byte) []byte {
+	return secretValue.ReplaceAll(b, []byte(`$1"***stripped***"`))
+}
+
+// New constructs a new SPDK JSON client. SPDK must be running,
+// otherwise connecting fails.
+func New(path string, options ...Option) (*Client, error) {
+	c := &Client{
+		path:       path,
+		minBackoff: 100 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+		stop:       make(chan interface{}),
+	}
+	for _, op := range options {
+		op(c)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.dial(); err != nil {
 		return nil, err
 	}
-	conn = &logConn{conn, log.L().With("at", "spdk-rpc")}
-	client := rpc.NewClientWithCodec(newClientCodec(conn))
-	return &Client{client: client}, nil
+	return c, nil
+}
+
+// dial establishes a new connection. Must be called with the mutex
+// locked.
+func (c *Client) dial() error {
+	var conn net.Conn
+	var err error
+	if c.dialer != nil {
+		conn, err = c.dialer()
+	} else {
+		conn, err = net.Dial("unix", c.path)
+	}
+	if err != nil {
+		return err
+	}
+	conn = &logConn{Conn: conn, logger: log.L().With("at", "spdk-rpc"), recorder: c.recorder}
+	c.codec = newClientCodec(conn, c.disconnected)
+	c.client = rpc.NewClientWithCodec(c.codec)
+	c.connections++
+	return nil
+}
+
+// connection identifies the current or, while disconnected, the
+// most recent connection.
+func (c *Client) connection() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.connections
+}
+
+// disconnected gets called when the connection with the given codec
+// is no longer usable. It starts reconnecting unless that has
+// already been done.
+func (c *Client) disconnected(codec *clientCodec) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed || c.codec != codec {
+		return
+	}
+	log.L().Warnw("lost connection to SPDK", "path", c.path)
+	client := c.client
+	c.client = nil
+	c.codec = nil
+	// Closing a client which was shut down because of a read
+	// error returns rpc.ErrShutdown, nothing to report.
+	client.Close() // nolint: gosec
+	c.wg.Add(1)
+	go c.reconnect()
+}
+
+// reconnect dials until it succeeds or the client gets closed.
+func (c *Client) reconnect() {
+	defer c.wg.Done()
+	backoff := c.minBackoff
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-time.After(backoff):
+		}
+		c.mutex.Lock()
+		if c.closed {
+			c.mutex.Unlock()
+			return
+		}
+		err := c.dial()
+		c.mutex.Unlock()
+		if err == nil {
+			break
+		}
+		log.L().Debugw("reconnecting to SPDK", "path", c.path, "error", err, "retry", backoff)
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+	log.L().Infow("reconnected to SPDK", "path", c.path)
+	if c.onReconnect != nil {
+		c.onReconnect()
+	}
 }
 
-// Close the connection to the server.
+// Connected returns true if the Client currently has a connection to
+// SPDK. Calls may still fail if SPDK went away and that has not been
+// noticed yet.
+func (c *Client) Connected() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.client != nil
+}
+
+// Close the connection to the server and stop reconnecting.
 func (c *Client) Close() error {
-	return c.client.Close()
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return rpc.ErrShutdown
+	}
+	c.closed = true
+	close(c.stop)
+	client := c.client
+	c.client = nil
+	c.codec = nil
+	c.mutex.Unlock()
+	c.wg.Wait()
+	if client == nil {
+		return nil
+	}
+	return client.Close()
 }
 
 // Invoke a certain method, get the reply and return the error (if any).
-func (c *Client) Invoke(_ context.Context, method string, args, reply interface{}) error {
-	return c.client.Call(method, args, reply)
+// While disconnected, ErrDisconnected is returned. When the context
+// gets canceled or its deadline expires before SPDK responds, Invoke
+// returns the context error without waiting further. The request
+// may still get executed by SPDK in that case.
+//
+// The method may be given with the name from before or after the
+// renaming in SPDK 19.10. It gets invoked under the name that SPDK
+// supports.
+func (c *Client) Invoke(ctx context.Context, method string, args, reply interface{}) error {
+	c.mutex.Lock()
+	client, codec := c.client, c.codec
+	c.mutex.Unlock()
+	if client == nil {
+		return ErrDisconnected
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	methods, err := c.supportedMethods(ctx, client, codec)
+	if err != nil {
+		return err
+	}
+	return c.call(ctx, client, codec, methods.resolve(method), args, reply)
+}
+
+// call invokes the method exactly as named.
+func (c *Client) call(ctx context.Context, client *rpc.Client, codec *clientCodec, method string, args, reply interface{}) error {
+	// The request is encoded here and the response decoded into
+	// a private buffer, so neither args nor reply are touched
+	// by the rpc.Client after Invoke has returned. A late
+	// response then simply gets dropped.
+	var params interface{}
+	if args != nil {
+		data, err := json.Marshal(args)
+		if err != nil {
+			return err
+		}
+		params = json.RawMessage(data)
+	}
+	var resp response
+	done := make(chan *rpc.Call, 1)
+	// Sending blocks when SPDK stops reading, therefore that
+	// also has to happen in the background.
+	go client.Go(method, params, &resp, done)
+	var call *rpc.Call
+	select {
+	case call = <-done:
+	case <-ctx.Done():
+		log.FromContext(ctx).Warnw("SPDK call aborted", "method", method, "error", ctx.Err())
+		return ctx.Err()
+	}
+	if call.Error != nil {
+		if isConnectionError(call.Error) {
+			c.disconnected(codec)
+		}
+		return call.Error
+	}
+	if resp.err != nil {
+		return resp.err
+	}
+	if reply == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.result, reply)
+}
+
+// isConnectionError returns true for errors that indicate that the
+// connection to SPDK is broken, as opposed to errors reported by
+// SPDK.
+func isConnectionError(err error) bool {
+	if err == rpc.ErrShutdown || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true
+	}
+	_, ok := err.(*net.OpError)
+	return ok
 }
@@ -0,0 +1,247 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intel/oim/pkg/log/testlog"
+)
+
+func TestStripSecrets(t *testing.T) {
+	for _, c := range []struct {
+		in, out string
+	}{
+		{`{"method":"get_bdevs"}`, `{"method":"get_bdevs"}`},
+		{
+			`{"method":"construct_crypto_bdev","params":{"base_bdev_name":"foo","name":"crypto-foo","crypto_pmd":"crypto_aesni_mb","key":"0123456789abcdef"}}`,
+			`{"method":"construct_crypto_bdev","params":{"base_bdev_name":"foo","name":"crypto-foo","crypto_pmd":"crypto_aesni_mb","key":"***stripped***"}}`,
+		},
+		{
+			`{"config":{"mon_host":"1.2.3.4","key": "with \"quotes\" inside"}}`,
+			`{"config":{"mon_host":"1.2.3.4","key": "***stripped***"}}`,
+		},
+		{`{"secret":"x","name":"key"}`, `{"secret":"***stripped***","name":"key"}`},
+	} {
+		assert.Equal(t, c.out, string(stripSecrets([]byte(c.in))))
+	}
+}
+
+// fakeSPDK answers each request with an empty list, except for
+// delete_bdev which fails like it does for an unknown BDev. While
+// stalled is not closed, it reads requests without responding.
+type fakeSPDK struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	conns    []net.Conn
+	stalled  chan interface{}
+}
+
+func startFakeSPDK(t *testing.T, path string) *fakeSPDK {
+	stalled := make(chan interface{})
+	close(stalled)
+	return startStalledSPDK(t, path, stalled)
+}
+
+func startStalledSPDK(t *testing.T, path string, stalled chan interface{}) *fakeSPDK {
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	f := &fakeSPDK{listener: listener, stalled: stalled}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			f.mutex.Lock()
+			f.conns = append(f.conns, conn)
+			f.mutex.Unlock()
+			go func() {
+				dec := json.NewDecoder(conn)
+				enc := json.NewEncoder(conn)
+				for {
+					var req clientRequest
+					if err := dec.Decode(&req); err != nil {
+						return
+					}
+					<-f.stalled
+					resp := map[string]interface{}{
+						"jsonrpc": "2.0",
+						"id":      req.ID,
+						"result":  []interface{}{},
+					}
+					if req.Method == "delete_bdev" {
+						delete(resp, "result")
+						resp["error"] = map[string]interface{}{
+							"code":    ERROR_INVALID_PARAMS,
+							"message": "No such device",
+						}
+					}
+					if err := enc.Encode(resp); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return f
+}
+
+// stop simulates a terminated SPDK process.
+func (f *fakeSPDK) stop() {
+	f.listener.Close()
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-reconnect")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "vhost.sock")
+	ctx := context.Background()
+
+	f := startFakeSPDK(t, path)
+	reconnected := make(chan bool, 1)
+	client, err := New(path,
+		WithReconnectBackoff(10*time.Millisecond, 100*time.Millisecond),
+		WithReconnectHandler(func() { reconnected <- true }))
+	require.NoError(t, err)
+	defer client.Close()
+
+	var reply []interface{}
+	err = client.Invoke(ctx, "get_bdevs", nil, &reply)
+	require.NoError(t, err, "first call")
+	assert.True(t, client.Connected(), "connected initially")
+
+	f.stop()
+	deadline := time.Now().Add(10 * time.Second)
+	for client.Connected() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.False(t, client.Connected(), "disconnected after SPDK stopped")
+	err = client.Invoke(ctx, "get_bdevs", nil, &reply)
+	assert.Equal(t, ErrDisconnected, err, "call while disconnected")
+
+	f = startFakeSPDK(t, path)
+	defer f.stop()
+	select {
+	case <-reconnected:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "not reconnected")
+	}
+	assert.True(t, client.Connected(), "connected again")
+	err = client.Invoke(ctx, "get_bdevs", nil, &reply)
+	require.NoError(t, err, "call after reconnect")
+}
+
+func TestCancel(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-cancel")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "vhost.sock")
+
+	stalled := make(chan interface{})
+	f := startStalledSPDK(t, path, stalled)
+	defer f.stop()
+	client, err := New(path)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	reply := []interface{}{"unchanged"}
+	start := time.Now()
+	err = client.Invoke(ctx, "get_bdevs", nil, &reply)
+	assert.Equal(t, context.DeadlineExceeded, err, "call with deadline")
+	assert.True(t, time.Since(start) < 5*time.Second, "call returned promptly")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = client.Invoke(ctx, "get_bdevs", nil, &reply)
+	assert.Equal(t, context.Canceled, err, "call with canceled context")
+
+	// The late responses must be ignored and the connection
+	// must remain usable.
+	close(stalled)
+	var other []interface{}
+	err = client.Invoke(context.Background(), "get_bdevs", nil, &other)
+	require.NoError(t, err, "call after stall")
+	assert.Equal(t, []interface{}{}, other, "reply after stall")
+	assert.Equal(t, []interface{}{"unchanged"}, reply, "reply of aborted call")
+	assert.True(t, client.Connected(), "still connected")
+}
+
+func TestErrorResponse(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-error")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "vhost.sock")
+
+	f := startFakeSPDK(t, path)
+	defer f.stop()
+	client, err := New(path)
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.Invoke(context.Background(), "delete_bdev", DeleteBDevArgs{Name: "no-such-bdev"}, nil)
+	var spdkErr *Error
+	require.True(t, errors.As(err, &spdkErr), "errors.As(%+v)", err)
+	assert.Equal(t, &Error{Code: ERROR_INVALID_PARAMS, Message: "No such device", Method: "delete_bdev"}, spdkErr)
+	wrapped := pkgerrors.Wrap(err, "delete")
+	assert.Equal(t, spdkErr, AsError(wrapped), "AsError(%+v)", wrapped)
+	assert.Equal(t, spdkErr, AsError(fmt.Errorf("delete: %w", wrapped)), "AsError of twice wrapped error")
+	assert.Nil(t, AsError(pkgerrors.New("delete")), "AsError without Error")
+	assert.True(t, IsNotFound(wrapped), "IsNotFound of wrapped error")
+	assert.True(t, IsNotFound(err), "IsNotFound")
+	assert.True(t, IsJSONError(err, ERROR_INVALID_PARAMS), "IsJSONError")
+	assert.True(t, client.Connected(), "still connected")
+}
+
+func TestErrorClassification(t *testing.T) {
+	for _, c := range []struct {
+		err                     error
+		notFound, alreadyExists bool
+	}{
+		{err: io.EOF},
+		{err: &Error{Code: ERROR_INVALID_PARAMS, Message: "Invalid parameters", Method: "construct_malloc_bdev"}},
+		{err: &Error{Code: ERROR_INVALID_PARAMS, Message: "Invalid parameters", Method: "get_bdevs"}, notFound: true},
+		{err: &Error{Code: ERROR_INVALID_PARAMS, Message: "Invalid parameters", Method: "bdev_get_bdevs"}, notFound: true},
+		{err: &Error{Code: ERROR_INVALID_PARAMS, Message: "No such device", Method: "delete_bdev"}, notFound: true},
+		{err: &Error{Code: -19, Message: "No such device", Method: "get_bdevs"}, notFound: true},
+		{err: &Error{Code: -2, Message: "No such file or directory", Method: "destroy_lvol_store"}, notFound: true},
+		{err: &Error{Code: ERROR_INVALID_PARAMS, Message: "File exists", Method: "construct_malloc_bdev"}, alreadyExists: true},
+		{err: &Error{Code: -17, Message: "File exists", Method: "construct_lvol_store"}, alreadyExists: true},
+		{err: &Error{Code: ERROR_INTERNAL_ERROR, Message: "Bdev name foo already exists", Method: "construct_rbd_bdev"}, alreadyExists: true},
+		{err: pkgerrors.Wrap(&Error{Code: -19, Method: "get_bdevs"}, "GetBDevs"), notFound: true},
+		{err: fmt.Errorf("lookup: %w", &Error{Code: -17, Method: "construct_malloc_bdev"}), alreadyExists: true},
+	} {
+		assert.Equal(t, c.notFound, IsNotFound(c.err), "IsNotFound(%v)", c.err)
+		assert.Equal(t, c.alreadyExists, IsAlreadyExists(c.err), "IsAlreadyExists(%v)", c.err)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2018 Intel Corporation
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Subsystems whose configuration describes BDevs and VHost
+// controllers.
+const (
+	SubsystemBDev  = "bdev"
+	SubsystemVHost = "vhost"
+)
+
+// Subsystem is one entry in the result of get_subsystems.
+type Subsystem struct {
+	Subsystem string   `json:"subsystem"`
+	DependsOn []string `json:"depends_on"`
+}
+
+// GetSubsystems returns all subsystems, each one after those that it
+// depends on.
+func GetSubsystems(ctx context.Context, client *Client) ([]Subsystem, error) {
+	var response []Subsystem
+	err := client.Invoke(ctx, "get_subsystems", nil, &response)
+	return response, err
+}
+
+// nolint: golint
+type GetSubsystemConfigArgs struct {
+	Name string `json:"name"`
+}
+
+// ConfigCall is one method call which recreates a part of the
+// configuration of a subsystem.
+type ConfigCall struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// GetSubsystemConfig returns the calls which recreate the current
+// state of a subsystem, in the order in which they have to be
+// invoked.
+func GetSubsystemConfig(ctx context.Context, client *Client, args GetSubsystemConfigArgs) ([]ConfigCall, error) {
+	var response []ConfigCall
+	err := client.Invoke(ctx, "get_subsystem_config", args, &response)
+	return response, err
+}
+
+// SubsystemConfig is the configuration of one subsystem.
+type SubsystemConfig struct {
+	Subsystem string       `json:"subsystem"`
+	Config    []ConfigCall `json:"config"`
+}
+
+// Config is a snapshot of the SPDK configuration, in the same
+// format as the one written by "rpc.py save_config". It may contain
+// secrets like the keys of crypto BDevs.
+type Config struct {
+	Subsystems []SubsystemConfig `json:"subsystems"`
+}
+
+// SaveConfig does the same as "rpc.py save_config", which is not a
+// method of SPDK itself. It returns the configuration of the given
+// subsystems in the given order, or of all subsystems if none are
+// given.
+func SaveConfig(ctx context.Context, client *Client, subsystems ...string) (*Config, error) {
+	if len(subsystems) == 0 {
+		all, err := GetSubsystems(ctx, client)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetSubsystems")
+		}
+		for _, subsystem := range all {
+			subsystems = append(subsystems, subsystem.Subsystem)
+		}
+	}
+	config := &Config{Subsystems: []SubsystemConfig{}}
+	for _, subsystem := range subsystems {
+		calls, err := GetSubsystemConfig(ctx, client, GetSubsystemConfigArgs{Name: subsystem})
+		if err != nil {
+			return nil, errors.Wrapf(err, "GetSubsystemConfig %s", subsystem)
+		}
+		if calls == nil {
+			calls = []ConfigCall{}
+		}
+		config.Subsystems = append(config.Subsystems, SubsystemConfig{
+			Subsystem: subsystem,
+			Config:    calls,
+		})
+	}
+	return config, nil
+}
+
+// LoadConfig does the same as "rpc.py load_config" for an SPDK
+// which has already been initialized: it invokes all calls that
+// SPDK allows in its current state, skipping those that are only
+// allowed during startup. Calls which fail because the object
+// exists already are ignored, so a configuration can also be loaded
+// into an SPDK which already has some parts of it. Other failures
+// do not stop loading the rest and are returned together.
+func LoadConfig(ctx context.Context, client *Client, config *Config) error {
+	var current []string
+	err := client.Invoke(ctx, "get_rpc_methods", map[string]bool{"current": true}, &current)
+	if err != nil && !IsJSONError(err, 0) {
+		return errors.Wrap(err, "GetRPCMethods")
+	}
+	// Without a list, everything gets tried.
+	var allowed methodSet
+	if err == nil {
+		allowed = methodSet{}
+		for _, method := range current {
+			allowed[method] = true
+		}
+	}
+
+	var failed []string
+	for _, subsystem := range config.Subsystems {
+		for _, call := range subsystem.Config {
+			if allowed != nil && !allowed[allowed.resolve(call.Method)] {
+				continue
+			}
+			var args interface{}
+			if len(call.Params) > 0 {
+				args = call.Params
+			}
+			err := client.Invoke(ctx, call.Method, args, nil)
+			switch {
+			case err == nil || IsAlreadyExists(err):
+			case IsJSONError(err, 0):
+				failed = append(failed, err.Error())
+			default:
+				// Connection lost or context canceled,
+				// the remaining calls would fail too.
+				return errors.Wrap(err, call.Method)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("%d call(s) failed: %s", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
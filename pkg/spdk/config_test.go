@@ -0,0 +1,119 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intel/oim/pkg/log/testlog"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spdk/spdktest"
+)
+
+func TestSaveLoadConfig(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "spdk.sock")
+	server, err := spdktest.New(path, spdktest.WithVHostSCSIControllers("vhost.0"))
+	require.NoError(t, err)
+	defer func() {
+		server.Close()
+	}()
+	client, err := spdk.New(path, spdk.WithReconnectBackoff(time.Millisecond, 10*time.Millisecond))
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+
+	rbd := spdk.ConstructRBDBDevArgs{
+		BlockSize: 512,
+		Name:      "rbd-0",
+		PoolName:  "rbd",
+		RBDName:   "image-0",
+		Config:    map[string]string{"key": "my-secret"},
+	}
+	_, err = spdk.ConstructRBDBDev(ctx, client, rbd)
+	require.NoError(t, err)
+	_, err = spdk.ConstructCryptoBDev(ctx, client, spdk.ConstructCryptoBDevArgs{
+		BaseBDevName: "rbd-0",
+		Name:         "crypto-0",
+		CryptoPMD:    spdk.CryptoAESNIMB,
+		Key:          "0123456789abcdef",
+	})
+	require.NoError(t, err)
+	err = spdk.AddVHostSCSILUN(ctx, client, spdk.AddVHostSCSILUNArgs{Controller: "vhost.0", SCSITargetNum: 3, BDevName: "crypto-0"})
+	require.NoError(t, err)
+
+	config, err := spdk.SaveConfig(ctx, client, spdk.SubsystemBDev, spdk.SubsystemVHost)
+	require.NoError(t, err)
+	require.Len(t, config.Subsystems, 2)
+	assert.Equal(t, spdk.SubsystemBDev, config.Subsystems[0].Subsystem)
+	assert.Len(t, config.Subsystems[0].Config, 2, "RBD and crypto BDev")
+	all, err := spdk.SaveConfig(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, config, all, "configuration of all subsystems")
+
+	// Loading into the same SPDK skips what exists already...
+	err = spdk.LoadConfig(ctx, client, config)
+	assert.NoError(t, err, "load into existing SPDK")
+
+	// ... and recreates everything in a restarted one.
+	server.Close()
+	server, err = spdktest.New(path, spdktest.WithVHostSCSIControllers("vhost.0"))
+	require.NoError(t, err)
+	// Loading fails until the client has noticed the restart
+	// and reconnected.
+	for i := 0; i < 1000; i++ {
+		err = spdk.LoadConfig(ctx, client, config)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err, "load into restarted SPDK")
+	assert.Equal(t, []string{"crypto-0", "rbd-0"}, server.BDevNames())
+	assert.Equal(t, rbd, server.RBDArgs("rbd-0"))
+	assert.Equal(t, map[uint32]string{3: "crypto-0"}, server.Targets("vhost.0"))
+}
+
+func TestLoadConfigFailure(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "spdk.sock")
+	server, err := spdktest.New(path)
+	require.NoError(t, err)
+	defer server.Close()
+	client, err := spdk.New(path)
+	require.NoError(t, err)
+	defer client.Close()
+
+	config := &spdk.Config{
+		Subsystems: []spdk.SubsystemConfig{
+			{
+				Subsystem: spdk.SubsystemBDev,
+				Config: []spdk.ConfigCall{
+					{Method: "construct_crypto_bdev", Params: []byte(`{"base_bdev_name":"no-such-bdev","name":"crypto-0","crypto_pmd":"crypto_aesni_mb","key":"0123456789abcdef"}`)},
+					{Method: "construct_malloc_bdev", Params: []byte(`{"name":"malloc-0","num_blocks":2048,"block_size":512}`)},
+				},
+			},
+		},
+	}
+	err = spdk.LoadConfig(context.Background(), client, config)
+	assert.Error(t, err, "invalid crypto BDev")
+	assert.Equal(t, []string{"malloc-0"}, server.BDevNames(), "later calls invoked")
+}
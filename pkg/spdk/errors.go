@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2018 Intel Corporation
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// Error is returned by Client.Invoke when SPDK rejects a call.
+// Errors caused by the connection are returned as they are.
+//
+// Use AsError instead of errors.As to find an Error:
+// github.com/pkg/errors as vendored here has no Unwrap, so errors.As
+// does not see through errors.Wrap.
+type Error struct {
+	// Code is either one of the JSON-RPC ERROR_* codes or, in
+	// more recent SPDK releases, a negative errno value. It is
+	// zero when SPDK only sent a message.
+	Code int
+	// Message is the human-readable explanation from SPDK.
+	Message string
+	// Method is the name of the method that failed.
+	Method string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: code: %d msg: %s", e.Method, e.Code, e.Message)
+}
+
+// Messages produced by spdk_strerror, i.e. strerror of glibc.
+const (
+	msgNoSuchDevice = "No such device"
+	msgNoSuchFile   = "No such file or directory"
+	msgFileExists   = "File exists"
+)
+
+// AsError finds an *Error in a chain of wrapped errors. It supports
+// both the standard Unwrap and github.com/pkg/errors' Cause. Returns
+// nil if there is no such error.
+func AsError(err error) *Error {
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			return e
+		}
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Cause() error }:
+			err = x.Cause()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// IsJSONError checks that the error has the expected error code. Use
+// code == 0 to check for any Error.
+func IsJSONError(err error, code int) bool {
+	e := AsError(err)
+	return e != nil && (code == 0 || e.Code == code)
+}
+
+// IsNotFound checks whether SPDK failed because the BDev, logical
+// volume store or some other named object does not exist. SPDK
+// reports that differently depending on the version and the method.
+func IsNotFound(err error) bool {
+	e := AsError(err)
+	if e == nil {
+		return false
+	}
+	switch e.Code {
+	case -int(syscall.ENODEV), -int(syscall.ENOENT):
+		return true
+	case ERROR_INVALID_PARAMS:
+		switch e.Message {
+		case msgNoSuchDevice, msgNoSuchFile:
+			return true
+		}
+		// get_bdevs and get_bdevs_iostat, also under their
+		// new names, report an unknown name only as "Invalid
+		// parameters".
+		switch e.Method {
+		case "get_bdevs", "get_bdevs_iostat", "bdev_get_bdevs", "bdev_get_iostat":
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// IsAlreadyExists checks whether SPDK failed because an object with
+// the same name exists already.
+func IsAlreadyExists(err error) bool {
+	e := AsError(err)
+	if e == nil {
+		return false
+	}
+	switch e.Code {
+	case -int(syscall.EEXIST):
+		return true
+	case ERROR_INVALID_PARAMS, ERROR_INTERNAL_ERROR:
+		return e.Message == msgFileExists ||
+			strings.Contains(e.Message, "already exists")
+	}
+	return false
+}
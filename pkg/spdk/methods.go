@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2018 Intel Corporation
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"context"
+	"net/rpc"
+)
+
+// renamedMethods maps the method names used in this package, which
+// are the ones known by SPDK up to 19.07, to the names introduced in
+// SPDK 19.10. Later releases removed the old names. delete_bdev has
+// no replacement and therefore is not listed, DeleteBDev uses the
+// methods for the different BDev types instead.
+var renamedMethods = map[string]string{
+	"get_rpc_methods":      "rpc_get_methods",
+	"get_subsystems":       "framework_get_subsystems",
+	"get_subsystem_config": "framework_get_config",
+
+	"get_bdevs":             "bdev_get_bdevs",
+	"get_bdevs_iostat":      "bdev_get_iostat",
+	"set_bdev_qos_limit":    "bdev_set_qos_limit",
+	"construct_malloc_bdev": "bdev_malloc_create",
+	"delete_malloc_bdev":    "bdev_malloc_delete",
+	"construct_rbd_bdev":    "bdev_rbd_create",
+	"delete_rbd_bdev":       "bdev_rbd_delete",
+	"construct_crypto_bdev": "bdev_crypto_create",
+	"delete_crypto_bdev":    "bdev_crypto_delete",
+	"construct_raid_bdev":   "bdev_raid_create",
+	"destroy_raid_bdev":     "bdev_raid_delete",
+	"get_raid_bdevs":        "bdev_raid_get_bdevs",
+
+	"construct_lvol_store":      "bdev_lvol_create_lvstore",
+	"destroy_lvol_store":        "bdev_lvol_delete_lvstore",
+	"get_lvol_stores":           "bdev_lvol_get_lvstores",
+	"construct_lvol_bdev":       "bdev_lvol_create",
+	"destroy_lvol_bdev":         "bdev_lvol_delete",
+	"resize_lvol_bdev":          "bdev_lvol_resize",
+	"snapshot_lvol_bdev":        "bdev_lvol_snapshot",
+	"clone_lvol_bdev":           "bdev_lvol_clone",
+	"inflate_lvol_bdev":         "bdev_lvol_inflate",
+	"decouple_parent_lvol_bdev": "bdev_lvol_decouple_parent",
+
+	"construct_vhost_scsi_controller": "vhost_create_scsi_controller",
+	"remove_vhost_controller":         "vhost_delete_controller",
+	"get_vhost_controllers":           "vhost_get_controllers",
+	"add_vhost_scsi_lun":              "vhost_scsi_controller_add_target",
+	"remove_vhost_scsi_target":        "vhost_scsi_controller_remove_target",
+
+	"start_nbd_disk": "nbd_start_disk",
+	"stop_nbd_disk":  "nbd_stop_disk",
+	"get_nbd_disks":  "nbd_get_disks",
+
+	"get_notification_types": "notify_get_types",
+	"get_notifications":      "notify_get_notifications",
+}
+
+// legacyMethods is the reverse of renamedMethods.
+var legacyMethods = func() map[string]string {
+	legacy := map[string]string{}
+	for old, current := range renamedMethods {
+		legacy[current] = old
+	}
+	return legacy
+}()
+
+// CurrentMethodName returns the name that SPDK 19.10 and later use
+// for a method, which is the same name if it was not renamed.
+func CurrentMethodName(method string) string {
+	if current, ok := renamedMethods[method]; ok {
+		return current
+	}
+	return method
+}
+
+// methodSet contains the methods supported by SPDK. nil means that
+// SPDK could not tell.
+type methodSet map[string]bool
+
+// resolve returns the name under which SPDK supports the method,
+// trying both the legacy and the current name. If SPDK supports
+// neither or cannot tell, the name is returned unchanged.
+func (m methodSet) resolve(method string) string {
+	if m == nil || m[method] {
+		return method
+	}
+	if current, ok := renamedMethods[method]; ok && m[current] {
+		return current
+	}
+	if legacy, ok := legacyMethods[method]; ok && m[legacy] {
+		return legacy
+	}
+	return method
+}
+
+// supportedMethods asks SPDK once per connection which methods it
+// supports. Errors are only returned when the question has to be
+// asked again, i.e. when the connection failed or the context was
+// canceled.
+func (c *Client) supportedMethods(ctx context.Context, client *rpc.Client, codec *clientCodec) (methodSet, error) {
+	codec.methodsMutex.Lock()
+	defer codec.methodsMutex.Unlock()
+	if codec.methodsKnown {
+		return codec.methods, nil
+	}
+
+	var names []string
+	err := c.call(ctx, client, codec, "rpc_get_methods", nil, &names)
+	if IsJSONError(err, ERROR_METHOD_NOT_FOUND) {
+		// Before SPDK 19.10.
+		err = c.call(ctx, client, codec, "get_rpc_methods", nil, &names)
+	}
+	switch {
+	case err == nil:
+		codec.methods = methodSet{}
+		for _, name := range names {
+			codec.methods[name] = true
+		}
+	case IsJSONError(err, 0):
+		codec.methods = nil
+	default:
+		return nil, err
+	}
+	codec.methodsKnown = true
+	return codec.methods, nil
+}
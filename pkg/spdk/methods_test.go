@@ -0,0 +1,96 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intel/oim/pkg/log/testlog"
+)
+
+func TestResolve(t *testing.T) {
+	legacy := methodSet{"get_bdevs": true, "delete_bdev": true}
+	current := methodSet{"bdev_get_bdevs": true, "delete_bdev": true}
+	for _, c := range []struct {
+		methods        methodSet
+		method, result string
+	}{
+		{nil, "get_bdevs", "get_bdevs"},
+		{nil, "bdev_get_bdevs", "bdev_get_bdevs"},
+		{legacy, "get_bdevs", "get_bdevs"},
+		{legacy, "bdev_get_bdevs", "get_bdevs"},
+		{current, "get_bdevs", "bdev_get_bdevs"},
+		{current, "bdev_get_bdevs", "bdev_get_bdevs"},
+		{current, "delete_bdev", "delete_bdev"},
+		{current, "no_such_method", "no_such_method"},
+		{methodSet{}, "get_bdevs", "get_bdevs"},
+	} {
+		assert.Equal(t, c.result, c.methods.resolve(c.method), "%s with %v", c.method, c.methods)
+	}
+}
+
+func TestMethodNames(t *testing.T) {
+	for _, c := range []struct {
+		name, transcript string
+	}{
+		{
+			"current",
+			`> {"jsonrpc":"2.0","method":"rpc_get_methods","id":0}
+< {"jsonrpc":"2.0","id":0,"result":["rpc_get_methods","bdev_get_bdevs","nbd_get_disks"]}
+> {"jsonrpc":"2.0","method":"bdev_get_bdevs","params":{},"id":1}
+< {"jsonrpc":"2.0","id":1,"result":[]}
+> {"jsonrpc":"2.0","method":"nbd_get_disks","id":2}
+< {"jsonrpc":"2.0","id":2,"result":[]}
+`,
+		},
+		{
+			"legacy",
+			`> {"jsonrpc":"2.0","method":"rpc_get_methods","id":0}
+< {"jsonrpc":"2.0","id":0,"error":{"code":-32601,"message":"Method not found"}}
+> {"jsonrpc":"2.0","method":"get_rpc_methods","id":1}
+< {"jsonrpc":"2.0","id":1,"result":["get_rpc_methods","get_bdevs","get_nbd_disks"]}
+> {"jsonrpc":"2.0","method":"get_bdevs","params":{},"id":2}
+< {"jsonrpc":"2.0","id":2,"result":[]}
+> {"jsonrpc":"2.0","method":"get_nbd_disks","id":3}
+< {"jsonrpc":"2.0","id":3,"result":[]}
+`,
+		},
+		{
+			"unknown",
+			`> {"jsonrpc":"2.0","method":"rpc_get_methods","id":0}
+< {"jsonrpc":"2.0","id":0,"error":{"code":-32601,"message":"Method not found"}}
+> {"jsonrpc":"2.0","method":"get_rpc_methods","id":1}
+< {"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}
+> {"jsonrpc":"2.0","method":"get_bdevs","params":{},"id":2}
+< {"jsonrpc":"2.0","id":2,"result":[]}
+> {"jsonrpc":"2.0","method":"get_nbd_disks","id":3}
+< {"jsonrpc":"2.0","id":3,"result":[]}
+`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer testlog.SetGlobal(t)()
+			ctx := context.Background()
+			replay, err := NewReplay(strings.NewReader(c.transcript))
+			require.NoError(t, err)
+			client, err := New("replay", WithDialer(replay.Dial))
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = GetBDevs(ctx, client, GetBDevsArgs{})
+			assert.NoError(t, err, "GetBDevs")
+			_, err = GetNBDDisks(ctx, client)
+			assert.NoError(t, err, "GetNBDDisks")
+			assert.NoError(t, replay.Verify(), "methods asked for once, invoked under the supported name")
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (C) 2018 Intel Corporation
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// NotificationType identifies what kind of event SPDK reported.
+type NotificationType string
+
+// Notification types emitted by the SPDK bdev layer.
+const (
+	NotificationBDevRegister   NotificationType = "bdev_register"
+	NotificationBDevUnregister NotificationType = "bdev_unregister"
+)
+
+// Notification is one event. For BDev events, Ctx is the name of
+// the BDev. IDs start at zero when SPDK starts and increase by one
+// for each event.
+type Notification struct {
+	Type NotificationType `json:"type"`
+	Ctx  string           `json:"ctx"`
+	ID   uint64           `json:"id"`
+}
+
+// GetNotificationTypes returns the types of events that SPDK can
+// report.
+func GetNotificationTypes(ctx context.Context, client *Client) ([]NotificationType, error) {
+	var response []NotificationType
+	err := client.Invoke(ctx, "get_notification_types", nil, &response)
+	return response, err
+}
+
+// GetNotificationsArgs selects events with an ID equal to or larger
+// than ID. Max limits the number of events, zero means no limit.
+type GetNotificationsArgs struct {
+	ID  uint64 `json:"id"`
+	Max uint64 `json:"max,omitempty"`
+}
+
+// GetNotifications returns past events in the order in which they
+// occurred. SPDK responds immediately, also when there are none.
+func GetNotifications(ctx context.Context, client *Client, args GetNotificationsArgs) ([]Notification, error) {
+	var response []Notification
+	err := client.Invoke(ctx, "get_notifications", args, &response)
+	return response, err
+}
+
+// Watch polls SPDK for new events every interval and delivers them
+// on the returned channel, which gets closed once the context is
+// done. Events that occurred before Watch was called are skipped.
+//
+// Watch fails when SPDK does not support notifications. Later
+// errors are logged and polling continues. After reconnecting to the
+// same SPDK instance, polling continues where it left off. A
+// restarted SPDK starts counting at zero again, then events are
+// delivered starting with its first one. A restart is detected when
+// SPDK no longer reports the last delivered event under its ID.
+// Events that occurred while a restarted SPDK was unreachable get
+// lost.
+func Watch(ctx context.Context, client *Client, interval time.Duration) (<-chan Notification, error) {
+	connection := client.connection()
+	past, err := GetNotifications(ctx, client, GetNotificationsArgs{})
+	if err != nil {
+		return nil, err
+	}
+	var next uint64
+	// last is the most recent event seen by Watch, nil if none.
+	var last *Notification
+	if len(past) > 0 {
+		last = &past[len(past)-1]
+		next = last.ID + 1
+	}
+	reconnected := false
+
+	notifications := make(chan Notification)
+	go func() {
+		defer close(notifications)
+		logger := log.L().With("at", "spdk-notifications")
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			current := client.connection()
+			if current != connection {
+				connection = current
+				reconnected = true
+			}
+			if reconnected && last != nil {
+				events, err := GetNotifications(ctx, client, GetNotificationsArgs{ID: last.ID, Max: 1})
+				if err != nil {
+					if ctx.Err() == nil && err != ErrDisconnected {
+						logger.Errorw("get notifications", "error", err)
+					}
+					continue
+				}
+				if client.connection() != connection {
+					continue
+				}
+				if len(events) == 0 || events[0] != *last {
+					logger.Infow("SPDK was restarted, watching all new events")
+					last = nil
+					next = 0
+				}
+			}
+			reconnected = false
+			events, err := GetNotifications(ctx, client, GetNotificationsArgs{ID: next})
+			if err != nil {
+				if ctx.Err() == nil && err != ErrDisconnected {
+					logger.Errorw("get notifications", "error", err)
+				}
+				continue
+			}
+			if client.connection() != connection {
+				// The response might come from the new
+				// SPDK instance. Try again with the
+				// right start ID.
+				continue
+			}
+			for _, event := range events {
+				select {
+				case <-ctx.Done():
+					return
+				case notifications <- event:
+				}
+				event := event
+				last = &event
+				next = event.ID + 1
+			}
+		}
+	}()
+	return notifications, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intel/oim/pkg/log/testlog"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spdk/spdktest"
+)
+
+func expectNotifications(t *testing.T, notifications <-chan spdk.Notification, expected ...spdk.Notification) {
+	for _, e := range expected {
+		select {
+		case n := <-notifications:
+			assert.Equal(t, e, n)
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "timed out", "waiting for %+v", e)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-notify")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "spdk.sock")
+	server, err := spdktest.New(path)
+	require.NoError(t, err)
+	defer func() {
+		server.Close()
+	}()
+	client, err := spdk.New(path, spdk.WithReconnectBackoff(time.Millisecond, 10*time.Millisecond))
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	types, err := spdk.GetNotificationTypes(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, []spdk.NotificationType{spdk.NotificationBDevRegister, spdk.NotificationBDevUnregister}, types)
+
+	// Past events are skipped.
+	server.AddMallocBDev("before")
+	notifications, err := spdk.Watch(ctx, client, time.Millisecond)
+	require.NoError(t, err)
+	server.AddMallocBDev("malloc-0")
+	server.DeleteBDev("malloc-0")
+	expectNotifications(t, notifications,
+		spdk.Notification{Type: spdk.NotificationBDevRegister, Ctx: "malloc-0", ID: 1},
+		spdk.Notification{Type: spdk.NotificationBDevUnregister, Ctx: "malloc-0", ID: 2},
+	)
+
+	// After losing the connection to the same SPDK, only new
+	// events are delivered.
+	var once sync.Once
+	server.SetHook(func(method string, params json.RawMessage) error {
+		var err error
+		if method == "get_notifications" {
+			once.Do(func() { err = errors.New("connection lost") })
+		}
+		return err
+	})
+	server.AddMallocBDev("malloc-2")
+	expectNotifications(t, notifications,
+		spdk.Notification{Type: spdk.NotificationBDevRegister, Ctx: "malloc-2", ID: 3},
+	)
+	server.SetHook(nil)
+
+	// A restarted SPDK starts counting at zero again.
+	server.Close()
+	server, err = spdktest.New(path)
+	require.NoError(t, err)
+	server.AddMallocBDev("malloc-1")
+	expectNotifications(t, notifications,
+		spdk.Notification{Type: spdk.NotificationBDevRegister, Ctx: "malloc-1", ID: 0},
+	)
+
+	cancel()
+	for range notifications {
+	}
+}
+
+func TestWatchUnsupported(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-notify")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "spdk.sock")
+	server, err := spdktest.New(path, spdktest.WithHook(func(method string, params json.RawMessage) error {
+		if method == "get_notifications" {
+			return &spdk.Error{Code: spdk.ERROR_METHOD_NOT_FOUND, Message: "Method not found"}
+		}
+		return nil
+	}))
+	require.NoError(t, err)
+	defer server.Close()
+	client, err := spdk.New(path)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = spdk.Watch(context.Background(), client, time.Millisecond)
+	assert.True(t, spdk.IsJSONError(err, spdk.ERROR_METHOD_NOT_FOUND), "SPDK without notifications: %v", err)
+}
@@ -10,6 +10,7 @@ package spdk
 
 import (
 	"context"
+	"time"
 )
 
 // nolint: golint
@@ -31,13 +32,55 @@ type SupportedIOTypes struct {
 
 // nolint: golint
 type BDev struct {
-	Name             string           `json:"name"`
-	ProductName      string           `json:"product_name"`
-	UUID             string           `json:"uuid"`
-	BlockSize        int64            `json:"block_size"`
-	NumBlocks        int64            `json:"num_blocks"`
-	Claimed          bool             `json:"claimed"`
-	SupportedIOTypes SupportedIOTypes `json:"supported_io_types"`
+	Name             string             `json:"name"`
+	Aliases          []string           `json:"aliases"`
+	ProductName      string             `json:"product_name"`
+	UUID             string             `json:"uuid"`
+	BlockSize        int64              `json:"block_size"`
+	NumBlocks        int64              `json:"num_blocks"`
+	Claimed          bool               `json:"claimed"`
+	SupportedIOTypes SupportedIOTypes   `json:"supported_io_types"`
+	DriverSpecific   BDevDriverSpecific `json:"driver_specific"`
+	RateLimits       QoSLimits          `json:"assigned_rate_limits"`
+}
+
+// BDevDriverSpecific contains those parts of the driver specific
+// information that are known to the bindings. Everything else
+// is ignored.
+type BDevDriverSpecific struct {
+	LVol   *LVolDriverSpecific   `json:"lvol,omitempty"`
+	Crypto *CryptoDriverSpecific `json:"crypto,omitempty"`
+	RAID   *RAIDDriverSpecific   `json:"raid,omitempty"`
+}
+
+// CryptoDriverSpecific describes a crypto BDev. SPDK also
+// reports the key, which is intentionally left out here.
+type CryptoDriverSpecific struct {
+	BaseBDevName string `json:"base_bdev_name"`
+	Name         string `json:"name"`
+	CryptoPMD    string `json:"crypto_pmd"`
+}
+
+// RAIDDriverSpecific describes a RAID BDev. Base BDevs which are
+// missing are listed with an empty name.
+type RAIDDriverSpecific struct {
+	StripSizeKB   uint32   `json:"strip_size_kb"`
+	State         uint32   `json:"state"`
+	RAIDLevel     uint32   `json:"raid_level"`
+	NumBaseBDevs  uint32   `json:"num_base_bdevs"`
+	BaseBDevsList []string `json:"base_bdevs_list"`
+}
+
+// LVolDriverSpecific describes a logical volume. Snapshots and
+// clones are referenced by their name inside the same lvol store.
+type LVolDriverSpecific struct {
+	LVolStoreUUID string   `json:"lvol_store_uuid"`
+	BaseBDev      string   `json:"base_bdev"`
+	ThinProvision bool     `json:"thin_provision"`
+	Snapshot      bool     `json:"snapshot"`
+	Clone         bool     `json:"clone"`
+	BaseSnapshot  string   `json:"base_snapshot,omitempty"`
+	Clones        []string `json:"clones,omitempty"`
 }
 
 // nolint: golint
@@ -58,9 +101,111 @@ type DeleteBDevArgs struct {
 	Name string `json:"name"`
 }
 
-// nolint: golint
+// deleteMethods maps the product name of a BDev to the method which
+// deletes BDevs of that type.
+var deleteMethods = map[string]string{
+	"Malloc disk":    "delete_malloc_bdev",
+	"Ceph Rbd Disk":  "delete_rbd_bdev",
+	"crypto":         "delete_crypto_bdev",
+	"Pooled Device":  "destroy_raid_bdev",
+	"Raid Volume":    "destroy_raid_bdev",
+	"Logical Volume": "destroy_lvol_bdev",
+}
+
+// DeleteBDev removes a BDev regardless of its type. The generic
+// delete_bdev was removed in SPDK 19.10, therefore the BDev is looked
+// up first and then removed with the method for its product name.
+// delete_bdev is only used for other types.
 func DeleteBDev(ctx context.Context, client *Client, args DeleteBDevArgs) error {
-	return client.Invoke(ctx, "delete_bdev", args, nil)
+	bdevs, err := GetBDevs(ctx, client, GetBDevsArgs{Name: args.Name})
+	if err != nil {
+		return err
+	}
+	method := "delete_bdev"
+	if len(bdevs) == 1 {
+		if m, ok := deleteMethods[bdevs[0].ProductName]; ok {
+			method = m
+		}
+	}
+	return client.Invoke(ctx, method, args, nil)
+}
+
+// QoSLimits are the rate limits of a BDev. Zero means unlimited.
+type QoSLimits struct {
+	RWIOsPerSec    uint64 `json:"rw_ios_per_sec"`
+	RWMBytesPerSec uint64 `json:"rw_mbytes_per_sec"`
+	RMBytesPerSec  uint64 `json:"r_mbytes_per_sec"`
+	WMBytesPerSec  uint64 `json:"w_mbytes_per_sec"`
+}
+
+// SetBDevQoSLimitArgs sets all limits at once.
+type SetBDevQoSLimitArgs struct {
+	Name string `json:"name"`
+	QoSLimits
+}
+
+// SetBDevQoSLimit changes the rate limits of a BDev. SPDK rounds
+// up to multiples of 10000 IOPS resp. 10 MB/s.
+func SetBDevQoSLimit(ctx context.Context, client *Client, args SetBDevQoSLimitArgs) error {
+	return client.Invoke(ctx, "set_bdev_qos_limit", args, nil)
+}
+
+// GetBDevsIOStatArgs selects one BDev. All BDevs are returned when
+// the name is empty.
+type GetBDevsIOStatArgs struct {
+	Name string `json:"name,omitempty"`
+}
+
+// BDevIOStat contains the I/O counters of a BDev since its creation.
+// Latencies are the sum over all operations, in ticks.
+type BDevIOStat struct {
+	Name              string `json:"name"`
+	BytesRead         uint64 `json:"bytes_read"`
+	NumReadOps        uint64 `json:"num_read_ops"`
+	BytesWritten      uint64 `json:"bytes_written"`
+	NumWriteOps       uint64 `json:"num_write_ops"`
+	BytesUnmapped     uint64 `json:"bytes_unmapped"`
+	NumUnmapOps       uint64 `json:"num_unmap_ops"`
+	ReadLatencyTicks  uint64 `json:"read_latency_ticks"`
+	WriteLatencyTicks uint64 `json:"write_latency_ticks"`
+	UnmapLatencyTicks uint64 `json:"unmap_latency_ticks"`
+}
+
+// GetBDevsIOStatResponse is the parsed result of get_bdevs_iostat.
+type GetBDevsIOStatResponse struct {
+	// TickRate is the number of ticks per second.
+	TickRate uint64
+	BDevs    []BDevIOStat
+}
+
+// TicksToDuration converts latency ticks into nanoseconds.
+func (r GetBDevsIOStatResponse) TicksToDuration(ticks uint64) time.Duration {
+	if r.TickRate == 0 {
+		return 0
+	}
+	return time.Duration(float64(ticks) / float64(r.TickRate) * float64(time.Second))
+}
+
+// GetBDevsIOStat retrieves I/O statistics. SPDK returns a list where
+// the first entry contains just the tick rate, followed by one entry
+// per BDev.
+func GetBDevsIOStat(ctx context.Context, client *Client, args GetBDevsIOStatArgs) (GetBDevsIOStatResponse, error) {
+	var entries []struct {
+		TickRate uint64 `json:"tick_rate"`
+		BDevIOStat
+	}
+	var response GetBDevsIOStatResponse
+	if err := client.Invoke(ctx, "get_bdevs_iostat", args, &entries); err != nil {
+		return response, err
+	}
+	for _, entry := range entries {
+		if entry.Name == "" {
+			response.TickRate = entry.TickRate
+			continue
+		}
+		response.BDevs = append(response.BDevs, entry.BDevIOStat)
+	}
+	return response, nil
 }
 
 // nolint: golint
@@ -103,6 +248,226 @@ func ConstructRBDBDev(ctx context.Context, client *Client, args ConstructRBDBDev
 	return response, err
 }
 
+// Names of the DPDK crypto drivers supported by SPDK.
+const (
+	CryptoAESNIMB = "crypto_aesni_mb"
+	CryptoQAT     = "crypto_qat"
+)
+
+// CryptoKeyLength is the required length of the AES-CBC key.
+const CryptoKeyLength = 16
+
+// ConstructCryptoBDevArgs contains the key for the new
+// BDev. Beware that it must not be logged.
+type ConstructCryptoBDevArgs struct {
+	BaseBDevName string `json:"base_bdev_name"`
+	Name         string `json:"name"`
+	CryptoPMD    string `json:"crypto_pmd"`
+	Key          string `json:"key"`
+}
+
+// ConstructCryptoBDev creates a BDev which encrypts all data
+// before passing it on to the base BDev.
+func ConstructCryptoBDev(ctx context.Context, client *Client, args ConstructCryptoBDevArgs) (ConstructBDevResponse, error) {
+	var response ConstructBDevResponse
+	err := client.Invoke(ctx, "construct_crypto_bdev", args, &response)
+	return response, err
+}
+
+// nolint: golint
+type DeleteCryptoBDevArgs struct {
+	Name string `json:"name"`
+}
+
+// nolint: golint
+func DeleteCryptoBDev(ctx context.Context, client *Client, args DeleteCryptoBDevArgs) error {
+	return client.Invoke(ctx, "delete_crypto_bdev", args, nil)
+}
+
+// RAID0 is the only RAID level supported by SPDK.
+const RAID0 = 0
+
+// ConstructRAIDBDevArgs combines the base BDevs into a new BDev.
+// The strip size must be a power of two.
+type ConstructRAIDBDevArgs struct {
+	Name        string   `json:"name"`
+	StripSizeKB uint32   `json:"strip_size_kb"`
+	RAIDLevel   uint32   `json:"raid_level"`
+	BaseBDevs   []string `json:"base_bdevs"`
+}
+
+// nolint: golint
+func ConstructRAIDBDev(ctx context.Context, client *Client, args ConstructRAIDBDevArgs) error {
+	return client.Invoke(ctx, "construct_raid_bdev", args, nil)
+}
+
+// nolint: golint
+type DestroyRAIDBDevArgs struct {
+	Name string `json:"name"`
+}
+
+// DestroyRAIDBDev removes the RAID BDev and releases its base BDevs.
+func DestroyRAIDBDev(ctx context.Context, client *Client, args DestroyRAIDBDevArgs) error {
+	return client.Invoke(ctx, "destroy_raid_bdev", args, nil)
+}
+
+// Categories for GetRAIDBDevs.
+const (
+	RAIDCategoryAll         = "all"
+	RAIDCategoryOnline      = "online"
+	RAIDCategoryConfiguring = "configuring"
+	RAIDCategoryOffline     = "offline"
+)
+
+// nolint: golint
+type GetRAIDBDevsArgs struct {
+	Category string `json:"category"`
+}
+
+// GetRAIDBDevs returns the names of RAID BDevs, including those
+// which are not registered as BDev because base BDevs are missing.
+func GetRAIDBDevs(ctx context.Context, client *Client, args GetRAIDBDevsArgs) ([]string, error) {
+	var response []string
+	err := client.Invoke(ctx, "get_raid_bdevs", args, &response)
+	return response, err
+}
+
+// nolint: golint
+type ConstructLVolStoreArgs struct {
+	BDevName  string `json:"bdev_name"`
+	LVSName   string `json:"lvs_name"`
+	ClusterSz int64  `json:"cluster_sz,omitempty"`
+}
+
+// ConstructLVolStoreResponse is the UUID of the new lvol store.
+type ConstructLVolStoreResponse string
+
+// nolint: golint
+func ConstructLVolStore(ctx context.Context, client *Client, args ConstructLVolStoreArgs) (ConstructLVolStoreResponse, error) {
+	var response ConstructLVolStoreResponse
+	err := client.Invoke(ctx, "construct_lvol_store", args, &response)
+	return response, err
+}
+
+// nolint: golint
+type DestroyLVolStoreArgs struct {
+	UUID    string `json:"uuid,omitempty"`
+	LVSName string `json:"lvs_name,omitempty"`
+}
+
+// nolint: golint
+func DestroyLVolStore(ctx context.Context, client *Client, args DestroyLVolStoreArgs) error {
+	return client.Invoke(ctx, "destroy_lvol_store", args, nil)
+}
+
+// nolint: golint
+type GetLVolStoresArgs struct {
+	UUID    string `json:"uuid,omitempty"`
+	LVSName string `json:"lvs_name,omitempty"`
+}
+
+// nolint: golint
+type LVolStore struct {
+	UUID              string `json:"uuid"`
+	Name              string `json:"name"`
+	BaseBDev          string `json:"base_bdev"`
+	TotalDataClusters int64  `json:"total_data_clusters"`
+	FreeClusters      int64  `json:"free_clusters"`
+	BlockSize         int64  `json:"block_size"`
+	ClusterSize       int64  `json:"cluster_size"`
+}
+
+// nolint: golint
+type GetLVolStoresResponse []LVolStore
+
+// nolint: golint
+func GetLVolStores(ctx context.Context, client *Client, args GetLVolStoresArgs) (GetLVolStoresResponse, error) {
+	var response GetLVolStoresResponse
+	err := client.Invoke(ctx, "get_lvol_stores", args, &response)
+	return response, err
+}
+
+// LVolNameMaxLength is the maximum length of a logical volume name
+// (SPDK_LVOL_NAME_MAX without the terminating null byte).
+const LVolNameMaxLength = 63
+
+// nolint: golint
+type ConstructLVolBDevArgs struct {
+	LVolName      string `json:"lvol_name"`
+	Size          int64  `json:"size"`
+	ThinProvision bool   `json:"thin_provision,omitempty"`
+	UUID          string `json:"uuid,omitempty"`
+	LVSName       string `json:"lvs_name,omitempty"`
+}
+
+// ConstructLVolBDev creates a new logical volume. The size gets
+// rounded up to a multiple of the cluster size. The name of the
+// new BDev is a UUID, the lvol is also available under the
+// alias "<lvs name>/<lvol name>".
+func ConstructLVolBDev(ctx context.Context, client *Client, args ConstructLVolBDevArgs) (ConstructBDevResponse, error) {
+	var response ConstructBDevResponse
+	err := client.Invoke(ctx, "construct_lvol_bdev", args, &response)
+	return response, err
+}
+
+// nolint: golint
+type SnapshotLVolBDevArgs struct {
+	LVolName     string `json:"lvol_name"`
+	SnapshotName string `json:"snapshot_name"`
+}
+
+// nolint: golint
+func SnapshotLVolBDev(ctx context.Context, client *Client, args SnapshotLVolBDevArgs) (ConstructBDevResponse, error) {
+	var response ConstructBDevResponse
+	err := client.Invoke(ctx, "snapshot_lvol_bdev", args, &response)
+	return response, err
+}
+
+// nolint: golint
+type CloneLVolBDevArgs struct {
+	SnapshotName string `json:"snapshot_name"`
+	CloneName    string `json:"clone_name"`
+}
+
+// nolint: golint
+func CloneLVolBDev(ctx context.Context, client *Client, args CloneLVolBDevArgs) (ConstructBDevResponse, error) {
+	var response ConstructBDevResponse
+	err := client.Invoke(ctx, "clone_lvol_bdev", args, &response)
+	return response, err
+}
+
+// nolint: golint
+type InflateLVolBDevArgs struct {
+	Name string `json:"name"`
+}
+
+// InflateLVolBDev allocates and copies all clusters of a clone,
+// thus removing the dependency on its snapshot.
+func InflateLVolBDev(ctx context.Context, client *Client, args InflateLVolBDevArgs) error {
+	return client.Invoke(ctx, "inflate_lvol_bdev", args, nil)
+}
+
+// nolint: golint
+type ResizeLVolBDevArgs struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// nolint: golint
+func ResizeLVolBDev(ctx context.Context, client *Client, args ResizeLVolBDevArgs) error {
+	return client.Invoke(ctx, "resize_lvol_bdev", args, nil)
+}
+
+// nolint: golint
+type DestroyLVolBDevArgs struct {
+	Name string `json:"name"`
+}
+
+// nolint: golint
+func DestroyLVolBDev(ctx context.Context, client *Client, args DestroyLVolBDevArgs) error {
+	return client.Invoke(ctx, "destroy_lvol_bdev", args, nil)
+}
+
 // nolint: golint
 type StartNBDDiskArgs struct {
 	BDevName  string `json:"bdev_name"`
@@ -145,6 +510,11 @@ func ConstructVHostSCSIController(ctx context.Context, client *Client, args Cons
 	return client.Invoke(ctx, "construct_vhost_scsi_controller", args, nil)
 }
 
+// VHostSCSIMaxTargets is the number of targets supported by a VHost
+// SCSI controller, SPDK_VHOST_SCSI_CTRLR_MAX_DEVS in SPDK. It is a
+// compile-time constant that is not available via JSON RPC.
+const VHostSCSIMaxTargets = 8
+
 // nolint: golint
 type AddVHostSCSILUNArgs struct {
 	Controller    string `json:"ctrlr"`
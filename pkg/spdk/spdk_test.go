@@ -8,6 +8,7 @@ package spdk_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -50,11 +51,12 @@ func TestError(t *testing.T) {
 	client := connect(t)
 	defer client.Close()
 
-	// It would be nice to get a well-documented error code here,
-	// but currently we don't (https://github.com/spdk/spdk/issues/319).
 	_, err := spdk.GetBDevs(context.Background(), client, spdk.GetBDevsArgs{Name: "no-such-bdev"})
 	require.Error(t, err, "Should have failed to find no-such-bdev")
-	require.True(t, spdk.IsJSONError(err, spdk.ERROR_INVALID_PARAMS), "IsJSONError(%+v, ERROR_INVALID_PARAMS)", err)
+	require.True(t, spdk.IsNotFound(err), "IsNotFound(%+v)", err)
+	var spdkErr *spdk.Error
+	require.True(t, errors.As(err, &spdkErr), "errors.As(%+v)", err)
+	assert.Equal(t, "get_bdevs", spdkErr.Method)
 }
 
 func TestMallocBDev(t *testing.T) {
@@ -103,6 +105,7 @@ func TestMallocBDev(t *testing.T) {
 		bdev := bdevs[0]
 		expected := spdk.BDev{
 			Name:        string(created),
+			Aliases:     []string{},
 			ProductName: "Malloc disk",
 			BlockSize:   arg.BlockSize,
 			NumBlocks:   arg.NumBlocks,
@@ -329,3 +332,166 @@ func TestSCSI(t *testing.T) {
 	expected = expected[0:1]
 	checkControllers(t, expected)
 }
+
+func TestLVol(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	defer testspdk.Finalize()
+	client := connect(t)
+	defer client.Close()
+
+	base := "my_lvs_base"
+	_, err := spdk.ConstructMallocBDev(ctx, client, spdk.ConstructMallocBDevArgs{ConstructBDevArgs: spdk.ConstructBDevArgs{NumBlocks: 16 * 2048, BlockSize: 512, Name: base}})
+	require.NoError(t, err, "create base BDev")
+	defer spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: base})
+
+	lvsArgs := spdk.ConstructLVolStoreArgs{BDevName: base, LVSName: "my_lvs", ClusterSz: 1024 * 1024}
+	lvsUUID, err := spdk.ConstructLVolStore(ctx, client, lvsArgs)
+	require.NoError(t, err, "Construct lvol store with %+v", lvsArgs)
+	defer spdk.DestroyLVolStore(ctx, client, spdk.DestroyLVolStoreArgs{LVSName: lvsArgs.LVSName})
+
+	stores, err := spdk.GetLVolStores(ctx, client, spdk.GetLVolStoresArgs{LVSName: lvsArgs.LVSName})
+	require.NoError(t, err, "GetLVolStores")
+	require.Len(t, stores, 1)
+	assert.Equal(t, string(lvsUUID), stores[0].UUID)
+	assert.Equal(t, base, stores[0].BaseBDev)
+	assert.Equal(t, int64(1024*1024), stores[0].ClusterSize)
+
+	lvolArgs := spdk.ConstructLVolBDevArgs{LVolName: "my_lvol", Size: 1, LVSName: lvsArgs.LVSName}
+	lvol, err := spdk.ConstructLVolBDev(ctx, client, lvolArgs)
+	require.NoError(t, err, "Construct lvol with %+v", lvolArgs)
+	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: "my_lvs/my_lvol"})
+	require.NoError(t, err, "GetBDevs by alias")
+	require.Len(t, bdevs, 1)
+	assert.Equal(t, string(lvol), bdevs[0].Name)
+	assert.Equal(t, []string{"my_lvs/my_lvol"}, bdevs[0].Aliases)
+	assert.Equal(t, int64(1024*1024), bdevs[0].NumBlocks*bdevs[0].BlockSize, "size rounded up to cluster size")
+	require.NotNil(t, bdevs[0].DriverSpecific.LVol)
+	assert.Equal(t, string(lvsUUID), bdevs[0].DriverSpecific.LVol.LVolStoreUUID)
+
+	_, err = spdk.SnapshotLVolBDev(ctx, client, spdk.SnapshotLVolBDevArgs{LVolName: "my_lvs/my_lvol", SnapshotName: "my_snapshot"})
+	require.NoError(t, err, "SnapshotLVolBDev")
+	bdevs, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: "my_lvs/my_snapshot"})
+	require.NoError(t, err, "GetBDevs snapshot")
+	require.Len(t, bdevs, 1)
+	require.NotNil(t, bdevs[0].DriverSpecific.LVol)
+	assert.True(t, bdevs[0].DriverSpecific.LVol.Snapshot, "is snapshot")
+	assert.Equal(t, []string{"my_lvol"}, bdevs[0].DriverSpecific.LVol.Clones)
+
+	clone, err := spdk.CloneLVolBDev(ctx, client, spdk.CloneLVolBDevArgs{SnapshotName: "my_lvs/my_snapshot", CloneName: "my_clone"})
+	require.NoError(t, err, "CloneLVolBDev")
+	bdevs, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: string(clone)})
+	require.NoError(t, err, "GetBDevs clone")
+	require.Len(t, bdevs, 1)
+	require.NotNil(t, bdevs[0].DriverSpecific.LVol)
+	assert.True(t, bdevs[0].DriverSpecific.LVol.Clone, "is clone")
+	assert.Equal(t, "my_snapshot", bdevs[0].DriverSpecific.LVol.BaseSnapshot)
+
+	err = spdk.InflateLVolBDev(ctx, client, spdk.InflateLVolBDevArgs{Name: string(clone)})
+	require.NoError(t, err, "InflateLVolBDev")
+	err = spdk.ResizeLVolBDev(ctx, client, spdk.ResizeLVolBDevArgs{Name: string(clone), Size: 2 * 1024 * 1024})
+	require.NoError(t, err, "ResizeLVolBDev")
+	bdevs, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: string(clone)})
+	require.NoError(t, err, "GetBDevs clone")
+	require.Len(t, bdevs, 1)
+	require.NotNil(t, bdevs[0].DriverSpecific.LVol)
+	assert.False(t, bdevs[0].DriverSpecific.LVol.Clone, "inflated clone")
+	assert.Equal(t, int64(2*1024*1024), bdevs[0].NumBlocks*bdevs[0].BlockSize, "resized clone")
+
+	for _, name := range []string{string(clone), "my_lvs/my_snapshot", string(lvol)} {
+		err = spdk.DestroyLVolBDev(ctx, client, spdk.DestroyLVolBDevArgs{Name: name})
+		assert.NoError(t, err, "DestroyLVolBDev %s", name)
+	}
+}
+
+func TestRAID(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	defer testspdk.Finalize()
+	client := connect(t)
+	defer client.Close()
+
+	var base []string
+	for i := 0; i < 2; i++ {
+		name := fmt.Sprintf("my_raid_base_%d", i)
+		_, err := spdk.ConstructMallocBDev(ctx, client, spdk.ConstructMallocBDevArgs{ConstructBDevArgs: spdk.ConstructBDevArgs{NumBlocks: 2048, BlockSize: 512, Name: name}})
+		require.NoError(t, err, "create base BDev %s", name)
+		defer spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: name})
+		base = append(base, name)
+	}
+
+	args := spdk.ConstructRAIDBDevArgs{Name: "my_raid", StripSizeKB: 64, RAIDLevel: spdk.RAID0, BaseBDevs: base}
+	err := spdk.ConstructRAIDBDev(ctx, client, args)
+	require.NoError(t, err, "ConstructRAIDBDev %+v", args)
+	names, err := spdk.GetRAIDBDevs(ctx, client, spdk.GetRAIDBDevsArgs{Category: spdk.RAIDCategoryOnline})
+	require.NoError(t, err, "GetRAIDBDevs")
+	assert.Equal(t, []string{"my_raid"}, names)
+	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: "my_raid"})
+	require.NoError(t, err, "GetBDevs")
+	require.Len(t, bdevs, 1)
+	require.NotNil(t, bdevs[0].DriverSpecific.RAID)
+	assert.Equal(t, base, bdevs[0].DriverSpecific.RAID.BaseBDevsList)
+	assert.Equal(t, uint32(64), bdevs[0].DriverSpecific.RAID.StripSizeKB)
+
+	err = spdk.DestroyRAIDBDev(ctx, client, spdk.DestroyRAIDBDevArgs{Name: "my_raid"})
+	require.NoError(t, err, "DestroyRAIDBDev")
+	names, err = spdk.GetRAIDBDevs(ctx, client, spdk.GetRAIDBDevsArgs{Category: spdk.RAIDCategoryAll})
+	require.NoError(t, err, "GetRAIDBDevs")
+	assert.Empty(t, names)
+}
+
+func TestQoS(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	defer testspdk.Finalize()
+	client := connect(t)
+	defer client.Close()
+
+	name := "my_qos_bdev"
+	_, err := spdk.ConstructMallocBDev(ctx, client, spdk.ConstructMallocBDevArgs{ConstructBDevArgs: spdk.ConstructBDevArgs{NumBlocks: 2048, BlockSize: 512, Name: name}})
+	require.NoError(t, err, "create BDev")
+	defer spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: name})
+
+	limits := spdk.QoSLimits{
+		RWIOsPerSec:    15000,
+		RWMBytesPerSec: 100,
+		WMBytesPerSec:  10,
+	}
+	err = spdk.SetBDevQoSLimit(ctx, client, spdk.SetBDevQoSLimitArgs{Name: name, QoSLimits: limits})
+	require.NoError(t, err, "SetBDevQoSLimit")
+	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: name})
+	require.NoError(t, err, "GetBDevs")
+	require.Len(t, bdevs, 1)
+	limits.RWIOsPerSec = 20000 // rounded up
+	assert.Equal(t, limits, bdevs[0].RateLimits)
+
+	err = spdk.SetBDevQoSLimit(ctx, client, spdk.SetBDevQoSLimitArgs{Name: name})
+	require.NoError(t, err, "remove limits")
+	bdevs, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: name})
+	require.NoError(t, err, "GetBDevs")
+	require.Len(t, bdevs, 1)
+	assert.Equal(t, spdk.QoSLimits{}, bdevs[0].RateLimits)
+}
+
+func TestIOStat(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	defer testspdk.Finalize()
+	client := connect(t)
+	defer client.Close()
+
+	name := "my_iostat_bdev"
+	_, err := spdk.ConstructMallocBDev(ctx, client, spdk.ConstructMallocBDevArgs{ConstructBDevArgs: spdk.ConstructBDevArgs{NumBlocks: 2048, BlockSize: 512, Name: name}})
+	require.NoError(t, err, "create BDev")
+	defer spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: name})
+
+	stats, err := spdk.GetBDevsIOStat(ctx, client, spdk.GetBDevsIOStatArgs{Name: name})
+	require.NoError(t, err, "GetBDevsIOStat")
+	assert.NotZero(t, stats.TickRate, "tick rate")
+	require.Len(t, stats.BDevs, 1)
+	assert.Equal(t, name, stats.BDevs[0].Name)
+	assert.Equal(t, time.Second, stats.TicksToDuration(stats.TickRate))
+
+	_, err = spdk.GetBDevsIOStat(ctx, client, spdk.GetBDevsIOStatArgs{Name: "no-such-bdev"})
+	assert.True(t, spdk.IsNotFound(err), "unknown BDev: %s", err)
+}
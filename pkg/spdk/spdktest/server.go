@@ -0,0 +1,979 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package spdktest provides an in-process fake SPDK for tests. It
+// serves the SPDK JSON-RPC protocol on a Unix domain socket and
+// keeps BDevs, VHost SCSI controllers and NBD disks in memory, so
+// code using pkg/spdk can be tested without a running SPDK vhost.
+//
+// Only the methods used by OIM are implemented. Errors are reported
+// with the same codes and messages as the SPDK release vendored by
+// OIM. Method names are those from before SPDK 19.10 unless
+// WithCurrentMethodNames is used.
+package spdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/intel/oim/pkg/spdk"
+)
+
+// Product names reported by SPDK for the different BDev types.
+const (
+	MallocProductName = "Malloc disk"
+	RBDProductName    = "Ceph Rbd Disk"
+	CryptoProductName = "crypto"
+	RAIDProductName   = "Pooled Device"
+)
+
+// deleteProductNames maps the type-specific delete methods which
+// have no driver specific information to the type that they delete.
+var deleteProductNames = map[string]string{
+	"delete_malloc_bdev": MallocProductName,
+	"delete_rbd_bdev":    RBDProductName,
+}
+
+// TickRate is reported by get_bdevs_iostat.
+const TickRate = 1000000000
+
+// rbdSize is the size of every simulated Ceph image.
+const rbdSize = 1024 * 1024 * 1024
+
+// methods lists the implemented methods under the names from before
+// SPDK 19.10.
+var methods = []string{
+	"get_rpc_methods",
+	"get_bdevs",
+	"get_bdevs_iostat",
+	"construct_malloc_bdev",
+	"delete_malloc_bdev",
+	"construct_rbd_bdev",
+	"delete_rbd_bdev",
+	"construct_crypto_bdev",
+	"delete_crypto_bdev",
+	"construct_raid_bdev",
+	"destroy_raid_bdev",
+	"get_raid_bdevs",
+	"delete_bdev",
+	"set_bdev_qos_limit",
+	"construct_vhost_scsi_controller",
+	"remove_vhost_controller",
+	"get_vhost_controllers",
+	"add_vhost_scsi_lun",
+	"remove_vhost_scsi_target",
+	"start_nbd_disk",
+	"stop_nbd_disk",
+	"get_nbd_disks",
+	"get_notification_types",
+	"get_notifications",
+	"get_subsystems",
+	"get_subsystem_config",
+}
+
+// removedMethods are not available in SPDK 19.10 or later.
+var removedMethods = map[string]bool{
+	"delete_bdev": true,
+}
+
+// legacyMethods maps the current name of each implemented method to
+// the old one.
+var legacyMethods = func() map[string]string {
+	legacy := map[string]string{}
+	for _, method := range methods {
+		legacy[spdk.CurrentMethodName(method)] = method
+	}
+	return legacy
+}()
+
+// Hook gets called for each request before it is handled. Returning
+// a *spdk.Error sends that error to the client instead of executing
+// the request. Any other error closes the connection, like a
+// crashing SPDK would. A hook may also block to delay the response.
+type Hook func(method string, params json.RawMessage) error
+
+// Server is the fake SPDK. It must be closed after use.
+type Server struct {
+	listener   net.Listener
+	dir        string
+	maxTargets uint32
+	// currentNames enables the method names of SPDK 19.10.
+	currentNames bool
+
+	mutex   sync.Mutex
+	conns   map[net.Conn]bool
+	hook    Hook
+	counter int
+	bdevs   map[string]*spdk.BDev
+	// order contains the BDev names in the order in which the
+	// BDevs were created.
+	order  []string
+	rbd    map[string]spdk.ConstructRBDBDevArgs
+	crypto map[string]spdk.ConstructCryptoBDevArgs
+	iostat map[string]spdk.BDevIOStat
+	// controllers maps VHost SCSI controller name to target
+	// number to BDev name.
+	controllers map[string]map[uint32]string
+	// nbd maps NBD device to BDev name.
+	nbd map[string]string
+	// notifications contains all BDev events, the index is the
+	// ID.
+	notifications []spdk.Notification
+}
+
+// Option is the type of all optional parameters for New.
+type Option func(s *Server)
+
+// WithVHostSCSIControllers creates VHost SCSI controllers when
+// starting, as if construct_vhost_scsi_controller had been called.
+func WithVHostSCSIControllers(names ...string) Option {
+	return func(s *Server) {
+		for _, name := range names {
+			s.controllers[name] = map[uint32]string{}
+		}
+	}
+}
+
+// WithMaxSCSITargets overrides the number of targets per VHost SCSI
+// controller. The default is spdk.VHostSCSIMaxTargets.
+func WithMaxSCSITargets(max uint32) Option {
+	return func(s *Server) {
+		s.maxTargets = max
+	}
+}
+
+// WithCurrentMethodNames simulates SPDK 19.10 or later, which only
+// accepts the method names introduced in that release (see
+// spdk.CurrentMethodName) and no longer has delete_bdev. By default,
+// the fake behaves like older releases and only accepts the old
+// names.
+func WithCurrentMethodNames() Option {
+	return func(s *Server) {
+		s.currentNames = true
+	}
+}
+
+// WithHook sets the initial hook, see also SetHook.
+func WithHook(hook Hook) Option {
+	return func(s *Server) {
+		s.hook = hook
+	}
+}
+
+// New starts listening on the given path.
+func New(path string, options ...Option) (*Server, error) {
+	s := &Server{
+		dir:         filepath.Dir(path) + "/",
+		maxTargets:  spdk.VHostSCSIMaxTargets,
+		conns:       map[net.Conn]bool{},
+		bdevs:       map[string]*spdk.BDev{},
+		rbd:         map[string]spdk.ConstructRBDBDevArgs{},
+		crypto:      map[string]spdk.ConstructCryptoBDevArgs{},
+		iostat:      map[string]spdk.BDevIOStat{},
+		controllers: map[string]map[uint32]string{},
+		nbd:         map[string]string{},
+	}
+	for _, op := range options {
+		op(s)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	s.listener = listener
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mutex.Lock()
+			s.conns[conn] = true
+			s.mutex.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s, nil
+}
+
+// Close stops listening and drops all connections, like a
+// terminated SPDK process would.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	return err
+}
+
+// SetHook replaces the current hook. nil removes it.
+func (s *Server) SetHook(hook Hook) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.hook = hook
+}
+
+// AddMallocBDev creates a 1MiB Malloc BDev, as if
+// construct_malloc_bdev had been called. An existing BDev with the
+// same name is left unchanged.
+func (s *Server) AddMallocBDev(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.bdevs[name] == nil {
+		s.addBDev(name, MallocProductName, 512, 2048)
+	}
+}
+
+// DeleteBDev removes a BDev as if it had disappeared in SPDK,
+// regardless of whether it is claimed.
+func (s *Server) DeleteBDev(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.deleteBDev(name)
+}
+
+// AddIO counts read and write operations of a BDev, as if the
+// VM had used it. get_bdevs_iostat reports them.
+func (s *Server) AddIO(name string, reads, writes uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stat := s.iostat[name]
+	stat.NumReadOps += reads
+	stat.NumWriteOps += writes
+	s.iostat[name] = stat
+}
+
+// BDevNames returns the sorted names of all BDevs.
+func (s *Server) BDevNames() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sortedBDevs()
+}
+
+// BDev returns a copy of the BDev as get_bdevs would report it,
+// nil if not found.
+func (s *Server) BDev(name string) *spdk.BDev {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.bdevs[name] == nil {
+		return nil
+	}
+	bdev := s.bdev(name)
+	return &bdev
+}
+
+// Targets returns a copy of the SCSI target number to BDev name
+// mapping of a VHost SCSI controller.
+func (s *Server) Targets(controller string) map[uint32]string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	targets := map[uint32]string{}
+	for num, bdev := range s.controllers[controller] {
+		targets[num] = bdev
+	}
+	return targets
+}
+
+// AddStaleTarget occupies a SCSI target of a VHost SCSI controller
+// with a LUN for a BDev that does not exist, as if the BDev had
+// disappeared without SPDK removing the target.
+func (s *Server) AddStaleTarget(controller string, num uint32, bdevName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.controllers[controller] == nil {
+		s.controllers[controller] = map[uint32]string{}
+	}
+	s.controllers[controller][num] = bdevName
+}
+
+// RBDArgs returns the parameters that the RBD BDev was created
+// with.
+func (s *Server) RBDArgs(name string) spdk.ConstructRBDBDevArgs {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rbd[name]
+}
+
+type request struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	ID     uint64          `json:"id"`
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+	}()
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+	for {
+		var req request
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		s.mutex.Lock()
+		hook := s.hook
+		s.mutex.Unlock()
+		var (
+			result interface{}
+			err    *spdk.Error
+		)
+		if hook != nil {
+			if hookErr := hook(req.Method, req.Params); hookErr != nil {
+				e, ok := hookErr.(*spdk.Error)
+				if !ok {
+					return
+				}
+				err = e
+			}
+		}
+		if err == nil {
+			result, err = s.handle(req)
+		}
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+		}
+		if err != nil {
+			resp["error"] = map[string]interface{}{
+				"code":    err.Code,
+				"message": err.Message,
+			}
+		} else {
+			resp["result"] = result
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// Errors as reported by SPDK.
+var (
+	errInvalidParams  = &spdk.Error{Code: spdk.ERROR_INVALID_PARAMS, Message: "Invalid parameters"}
+	errNoSuchDevice   = &spdk.Error{Code: spdk.ERROR_INVALID_PARAMS, Message: "No such device"}
+	errFileExists     = &spdk.Error{Code: spdk.ERROR_INVALID_PARAMS, Message: "File exists"}
+	errBusy           = &spdk.Error{Code: spdk.ERROR_INVALID_PARAMS, Message: "Device or resource busy"}
+	errMethodNotFound = &spdk.Error{Code: spdk.ERROR_METHOD_NOT_FOUND, Message: "Method not found"}
+)
+
+// decode parses the parameters. Missing parameters are okay when
+// optional is true.
+func decode(req request, args interface{}, optional bool) *spdk.Error {
+	if len(req.Params) == 0 || string(req.Params) == "null" {
+		if optional {
+			return nil
+		}
+		return errInvalidParams
+	}
+	if err := json.Unmarshal(req.Params, args); err != nil {
+		return errInvalidParams
+	}
+	return nil
+}
+
+func (s *Server) handle(req request) (interface{}, *spdk.Error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	method, ok := s.implementedName(req.Method)
+	if !ok {
+		return nil, errMethodNotFound
+	}
+	switch method {
+	case "get_rpc_methods":
+		var names []string
+		for _, method := range methods {
+			if s.currentNames {
+				if removedMethods[method] {
+					continue
+				}
+				method = spdk.CurrentMethodName(method)
+			}
+			names = append(names, method)
+		}
+		sort.Strings(names)
+		return names, nil
+	case "get_bdevs":
+		var args spdk.GetBDevsArgs
+		if err := decode(req, &args, true); err != nil {
+			return nil, err
+		}
+		bdevs := []spdk.BDev{}
+		for _, name := range s.sortedBDevs() {
+			if args.Name == "" || args.Name == name || contains(s.bdevs[name].Aliases, args.Name) {
+				bdevs = append(bdevs, s.bdev(name))
+			}
+		}
+		if args.Name != "" && len(bdevs) == 0 {
+			return nil, errInvalidParams
+		}
+		return bdevs, nil
+	case "get_bdevs_iostat":
+		var args spdk.GetBDevsIOStatArgs
+		if err := decode(req, &args, true); err != nil {
+			return nil, err
+		}
+		stats := []interface{}{map[string]interface{}{"tick_rate": TickRate}}
+		for _, name := range s.sortedBDevs() {
+			if args.Name == "" || args.Name == name {
+				stat := s.iostat[name]
+				stat.Name = name
+				stats = append(stats, stat)
+			}
+		}
+		if args.Name != "" && len(stats) == 1 {
+			return nil, errInvalidParams
+		}
+		return stats, nil
+	case "construct_malloc_bdev":
+		var args spdk.ConstructMallocBDevArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if args.NumBlocks <= 0 || args.BlockSize <= 0 || args.BlockSize%512 != 0 {
+			return nil, errInvalidParams
+		}
+		name := args.Name
+		if name == "" {
+			name = fmt.Sprintf("Malloc%d", s.counter)
+		}
+		if s.bdevs[name] != nil {
+			return nil, errFileExists
+		}
+		s.addBDev(name, MallocProductName, args.BlockSize, args.NumBlocks)
+		return name, nil
+	case "construct_rbd_bdev":
+		var args spdk.ConstructRBDBDevArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if args.PoolName == "" || args.RBDName == "" || args.BlockSize <= 0 {
+			return nil, errInvalidParams
+		}
+		name := args.Name
+		if name == "" {
+			name = fmt.Sprintf("Ceph%d", s.counter)
+		}
+		if s.bdevs[name] != nil {
+			return nil, errFileExists
+		}
+		s.addBDev(name, RBDProductName, args.BlockSize, rbdSize/args.BlockSize)
+		s.rbd[name] = args
+		return name, nil
+	case "construct_crypto_bdev":
+		var args spdk.ConstructCryptoBDevArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if args.Name == "" || len(args.Key) != spdk.CryptoKeyLength ||
+			(args.CryptoPMD != spdk.CryptoAESNIMB && args.CryptoPMD != spdk.CryptoQAT) {
+			return nil, errInvalidParams
+		}
+		if s.bdevs[args.Name] != nil {
+			return nil, errFileExists
+		}
+		base := s.bdevs[args.BaseBDevName]
+		if base == nil {
+			return nil, errNoSuchDevice
+		}
+		if s.claimed(args.BaseBDevName) {
+			return nil, errBusy
+		}
+		bdev := s.addBDev(args.Name, CryptoProductName, base.BlockSize, base.NumBlocks)
+		bdev.DriverSpecific.Crypto = &spdk.CryptoDriverSpecific{
+			BaseBDevName: args.BaseBDevName,
+			Name:         args.Name,
+			CryptoPMD:    args.CryptoPMD,
+		}
+		s.crypto[args.Name] = args
+		return args.Name, nil
+	case "delete_crypto_bdev":
+		var args spdk.DeleteCryptoBDevArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if s.bdevs[args.Name] == nil || s.bdevs[args.Name].DriverSpecific.Crypto == nil {
+			return nil, errNoSuchDevice
+		}
+		s.deleteBDev(args.Name)
+		return true, nil
+	case "construct_raid_bdev":
+		var args spdk.ConstructRAIDBDevArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if args.Name == "" || args.RAIDLevel != spdk.RAID0 ||
+			args.StripSizeKB == 0 || args.StripSizeKB&(args.StripSizeKB-1) != 0 ||
+			len(args.BaseBDevs) == 0 {
+			return nil, errInvalidParams
+		}
+		if s.bdevs[args.Name] != nil {
+			return nil, errFileExists
+		}
+		var blockSize, minBlocks int64
+		for _, name := range args.BaseBDevs {
+			base := s.bdevs[name]
+			if base == nil {
+				return nil, errNoSuchDevice
+			}
+			if s.claimed(name) {
+				return nil, errBusy
+			}
+			if blockSize != 0 && base.BlockSize != blockSize {
+				return nil, errInvalidParams
+			}
+			blockSize = base.BlockSize
+			if minBlocks == 0 || base.NumBlocks < minBlocks {
+				minBlocks = base.NumBlocks
+			}
+		}
+		bdev := s.addBDev(args.Name, RAIDProductName, blockSize, minBlocks*int64(len(args.BaseBDevs)))
+		bdev.DriverSpecific.RAID = &spdk.RAIDDriverSpecific{
+			StripSizeKB:   args.StripSizeKB,
+			RAIDLevel:     args.RAIDLevel,
+			NumBaseBDevs:  uint32(len(args.BaseBDevs)),
+			BaseBDevsList: append([]string(nil), args.BaseBDevs...),
+		}
+		return true, nil
+	case "destroy_raid_bdev":
+		var args spdk.DestroyRAIDBDevArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if s.bdevs[args.Name] == nil || s.bdevs[args.Name].DriverSpecific.RAID == nil {
+			return nil, errNoSuchDevice
+		}
+		s.deleteBDev(args.Name)
+		return true, nil
+	case "get_raid_bdevs":
+		var args spdk.GetRAIDBDevsArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		names := []string{}
+		switch args.Category {
+		case spdk.RAIDCategoryAll, spdk.RAIDCategoryOnline:
+			for _, name := range s.sortedBDevs() {
+				if s.bdevs[name].DriverSpecific.RAID != nil {
+					names = append(names, name)
+				}
+			}
+		case spdk.RAIDCategoryConfiguring, spdk.RAIDCategoryOffline:
+		default:
+			return nil, errInvalidParams
+		}
+		return names, nil
+	case "delete_malloc_bdev", "delete_rbd_bdev", "delete_bdev":
+		var args spdk.DeleteBDevArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		productName := deleteProductNames[method]
+		if s.bdevs[args.Name] == nil ||
+			productName != "" && s.bdevs[args.Name].ProductName != productName {
+			return nil, errNoSuchDevice
+		}
+		if s.claimed(args.Name) {
+			return nil, errBusy
+		}
+		s.deleteBDev(args.Name)
+		return true, nil
+	case "set_bdev_qos_limit":
+		var args spdk.SetBDevQoSLimitArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		bdev := s.bdevs[args.Name]
+		if bdev == nil {
+			return nil, errNoSuchDevice
+		}
+		bdev.RateLimits = args.QoSLimits
+		return true, nil
+	case "construct_vhost_scsi_controller":
+		var args spdk.ConstructVHostSCSIControllerArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if args.Controller == "" {
+			return nil, errInvalidParams
+		}
+		if s.controllers[args.Controller] != nil {
+			return nil, errFileExists
+		}
+		s.controllers[args.Controller] = map[uint32]string{}
+		return true, nil
+	case "remove_vhost_controller":
+		var args spdk.RemoveVHostControllerArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		args.Controller = s.controllerName(args.Controller)
+		targets := s.controllers[args.Controller]
+		if targets == nil {
+			return nil, errNoSuchDevice
+		}
+		if len(targets) > 0 {
+			return nil, errBusy
+		}
+		delete(s.controllers, args.Controller)
+		return true, nil
+	case "get_vhost_controllers":
+		var names []string
+		for name := range s.controllers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		controllers := []interface{}{}
+		for _, name := range names {
+			var nums []int
+			for num := range s.controllers[name] {
+				nums = append(nums, int(num))
+			}
+			sort.Ints(nums)
+			targets := []interface{}{}
+			for _, num := range nums {
+				targets = append(targets, map[string]interface{}{
+					"scsi_dev_num": num,
+					"id":           num,
+					"target_name":  fmt.Sprintf("Target %d", num),
+					"luns": []interface{}{
+						map[string]interface{}{"id": 0, "bdev_name": s.controllers[name][uint32(num)]},
+					},
+				})
+			}
+			controllers = append(controllers, map[string]interface{}{
+				"ctrlr":            name,
+				"cpumask":          "0x1",
+				"backend_specific": map[string]interface{}{"scsi": targets},
+			})
+		}
+		return controllers, nil
+	case "add_vhost_scsi_lun":
+		var args spdk.AddVHostSCSILUNArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		args.Controller = s.controllerName(args.Controller)
+		targets := s.controllers[args.Controller]
+		if targets == nil || s.bdevs[args.BDevName] == nil {
+			return nil, errNoSuchDevice
+		}
+		if args.SCSITargetNum >= s.maxTargets {
+			return nil, errInvalidParams
+		}
+		if targets[args.SCSITargetNum] != "" {
+			// SPDK reports EEXIST for an occupied target.
+			return nil, errFileExists
+		}
+		targets[args.SCSITargetNum] = args.BDevName
+		return args.SCSITargetNum, nil
+	case "remove_vhost_scsi_target":
+		var args spdk.RemoveVHostSCSITargetArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		args.Controller = s.controllerName(args.Controller)
+		if s.controllers[args.Controller][args.SCSITargetNum] == "" {
+			return nil, errNoSuchDevice
+		}
+		delete(s.controllers[args.Controller], args.SCSITargetNum)
+		return true, nil
+	case "start_nbd_disk":
+		var args spdk.StartNBDDiskArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if s.bdevs[args.BDevName] == nil {
+			return nil, errNoSuchDevice
+		}
+		if args.NBDDevice == "" {
+			return nil, errInvalidParams
+		}
+		if s.nbd[args.NBDDevice] != "" {
+			return nil, errBusy
+		}
+		s.nbd[args.NBDDevice] = args.BDevName
+		return args.NBDDevice, nil
+	case "stop_nbd_disk":
+		var args spdk.StopNBDDiskArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		if s.nbd[args.NBDDevice] == "" {
+			return nil, errNoSuchDevice
+		}
+		delete(s.nbd, args.NBDDevice)
+		return true, nil
+	case "get_nbd_disks":
+		var devices []string
+		for device := range s.nbd {
+			devices = append(devices, device)
+		}
+		sort.Strings(devices)
+		disks := spdk.GetNBDDisksResponse{}
+		for _, device := range devices {
+			disks = append(disks, spdk.StartNBDDiskArgs{BDevName: s.nbd[device], NBDDevice: device})
+		}
+		return disks, nil
+	case "get_notification_types":
+		return []spdk.NotificationType{spdk.NotificationBDevRegister, spdk.NotificationBDevUnregister}, nil
+	case "get_notifications":
+		var args spdk.GetNotificationsArgs
+		if err := decode(req, &args, true); err != nil {
+			return nil, err
+		}
+		notifications := []spdk.Notification{}
+		for id := args.ID; id < uint64(len(s.notifications)); id++ {
+			if args.Max > 0 && uint64(len(notifications)) >= args.Max {
+				break
+			}
+			notifications = append(notifications, s.notifications[id])
+		}
+		return notifications, nil
+	case "get_subsystems":
+		return []spdk.Subsystem{
+			{Subsystem: spdk.SubsystemBDev, DependsOn: []string{}},
+			{Subsystem: spdk.SubsystemVHost, DependsOn: []string{spdk.SubsystemBDev}},
+		}, nil
+	case "get_subsystem_config":
+		var args spdk.GetSubsystemConfigArgs
+		if err := decode(req, &args, false); err != nil {
+			return nil, err
+		}
+		switch args.Name {
+		case spdk.SubsystemBDev:
+			return s.bdevConfig(), nil
+		case spdk.SubsystemVHost:
+			return s.vhostConfig(), nil
+		}
+		return nil, errInvalidParams
+	}
+	return nil, errMethodNotFound
+}
+
+// addBDev must be called with the mutex locked.
+func (s *Server) addBDev(name, productName string, blockSize, numBlocks int64) *spdk.BDev {
+	s.counter++
+	bdev := &spdk.BDev{
+		Name:        name,
+		Aliases:     []string{},
+		ProductName: productName,
+		UUID:        fmt.Sprintf("00000000-0000-0000-0000-%012d", s.counter),
+		BlockSize:   blockSize,
+		NumBlocks:   numBlocks,
+		SupportedIOTypes: spdk.SupportedIOTypes{
+			Read:       true,
+			Write:      true,
+			Unmap:      true,
+			WriteZeros: true,
+			Flush:      true,
+			Reset:      true,
+		},
+	}
+	s.bdevs[name] = bdev
+	s.order = append(s.order, name)
+	s.notify(spdk.NotificationBDevRegister, name)
+	return bdev
+}
+
+// deleteBDev removes the BDev and, like SPDK's hot-remove, the
+// VHost SCSI targets and NBD disks that use it. Must be called with
+// the mutex locked.
+func (s *Server) deleteBDev(name string) {
+	if s.bdevs[name] != nil {
+		s.notify(spdk.NotificationBDevUnregister, name)
+	}
+	delete(s.bdevs, name)
+	delete(s.rbd, name)
+	delete(s.crypto, name)
+	delete(s.iostat, name)
+	for i, bdev := range s.order {
+		if bdev == name {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	for _, targets := range s.controllers {
+		for num, bdev := range targets {
+			if bdev == name {
+				delete(targets, num)
+			}
+		}
+	}
+	for device, bdev := range s.nbd {
+		if bdev == name {
+			delete(s.nbd, device)
+		}
+	}
+}
+
+// bdevConfig returns the calls which recreate the BDevs, each one
+// after the BDevs that it is built on. Must be called with the mutex
+// locked.
+func (s *Server) bdevConfig() []spdk.ConfigCall {
+	calls := []spdk.ConfigCall{}
+	for _, name := range s.order {
+		bdev := s.bdevs[name]
+		switch {
+		case bdev.DriverSpecific.Crypto != nil:
+			calls = s.addCall(calls, "construct_crypto_bdev", s.crypto[name])
+		case bdev.DriverSpecific.RAID != nil:
+			raid := bdev.DriverSpecific.RAID
+			calls = s.addCall(calls, "construct_raid_bdev", spdk.ConstructRAIDBDevArgs{
+				Name:        name,
+				StripSizeKB: raid.StripSizeKB,
+				RAIDLevel:   raid.RAIDLevel,
+				BaseBDevs:   raid.BaseBDevsList,
+			})
+		case bdev.ProductName == RBDProductName:
+			calls = s.addCall(calls, "construct_rbd_bdev", s.rbd[name])
+		default:
+			calls = s.addCall(calls, "construct_malloc_bdev", spdk.ConstructMallocBDevArgs{
+				ConstructBDevArgs: spdk.ConstructBDevArgs{
+					NumBlocks: bdev.NumBlocks,
+					BlockSize: bdev.BlockSize,
+					Name:      name,
+					UUID:      bdev.UUID,
+				},
+			})
+		}
+		if bdev.RateLimits != (spdk.QoSLimits{}) {
+			calls = s.addCall(calls, "set_bdev_qos_limit", spdk.SetBDevQoSLimitArgs{
+				Name:      name,
+				QoSLimits: bdev.RateLimits,
+			})
+		}
+	}
+	return calls
+}
+
+// vhostConfig returns the calls which recreate the VHost SCSI
+// controllers and their targets. Must be called with the mutex
+// locked.
+func (s *Server) vhostConfig() []spdk.ConfigCall {
+	calls := []spdk.ConfigCall{}
+	var names []string
+	for name := range s.controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		calls = s.addCall(calls, "construct_vhost_scsi_controller", spdk.ConstructVHostSCSIControllerArgs{
+			CPUMask:    "0x1",
+			Controller: name,
+		})
+		var nums []int
+		for num := range s.controllers[name] {
+			nums = append(nums, int(num))
+		}
+		sort.Ints(nums)
+		for _, num := range nums {
+			calls = s.addCall(calls, "add_vhost_scsi_lun", spdk.AddVHostSCSILUNArgs{
+				Controller:    name,
+				SCSITargetNum: uint32(num),
+				BDevName:      s.controllers[name][uint32(num)],
+			})
+		}
+	}
+	return calls
+}
+
+// addCall appends a call under the method name of the simulated
+// SPDK release.
+func (s *Server) addCall(calls []spdk.ConfigCall, method string, params interface{}) []spdk.ConfigCall {
+	if s.currentNames {
+		method = spdk.CurrentMethodName(method)
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		panic(err)
+	}
+	return append(calls, spdk.ConfigCall{Method: method, Params: data})
+}
+
+// notify records an event for get_notifications. Must be called
+// with the mutex locked.
+func (s *Server) notify(notificationType spdk.NotificationType, bdev string) {
+	s.notifications = append(s.notifications, spdk.Notification{
+		Type: notificationType,
+		Ctx:  bdev,
+		ID:   uint64(len(s.notifications)),
+	})
+}
+
+// implementedName maps the method name sent by the client to the
+// name used by handle. Names which do not exist in the simulated SPDK
+// release are rejected.
+func (s *Server) implementedName(method string) (string, bool) {
+	legacy, renamed := legacyMethods[method]
+	renamed = renamed && legacy != method
+	if s.currentNames {
+		if renamed {
+			return legacy, true
+		}
+		return method, spdk.CurrentMethodName(method) == method && !removedMethods[method]
+	}
+	return method, !renamed
+}
+
+// controllerName turns a path to a controller socket into the
+// controller name, like spdk_vhost_dev_find does for paths inside
+// the SPDK socket directory.
+func (s *Server) controllerName(name string) string {
+	return strings.TrimPrefix(name, s.dir)
+}
+
+// bdev returns a copy of the BDev with the current claim state.
+// Must be called with the mutex locked.
+func (s *Server) bdev(name string) spdk.BDev {
+	bdev := *s.bdevs[name]
+	bdev.Claimed = s.claimed(name)
+	return bdev
+}
+
+// claimed checks whether some other BDev is built on top of the
+// BDev. Must be called with the mutex locked.
+func (s *Server) claimed(name string) bool {
+	for _, bdev := range s.bdevs {
+		if crypto := bdev.DriverSpecific.Crypto; crypto != nil && crypto.BaseBDevName == name {
+			return true
+		}
+		if raid := bdev.DriverSpecific.RAID; raid != nil && contains(raid.BaseBDevsList, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedBDevs must be called with the mutex locked.
+func (s *Server) sortedBDevs() []string {
+	var names []string
+	for name := range s.bdevs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdktest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intel/oim/pkg/log/testlog"
+	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spdk/spdktest"
+)
+
+func start(t *testing.T, options ...spdktest.Option) (*spdktest.Server, *spdk.Client, string, func()) {
+	tmp, err := ioutil.TempDir("", "spdktest")
+	require.NoError(t, err)
+	path := filepath.Join(tmp, "spdk.sock")
+	server, err := spdktest.New(path, options...)
+	require.NoError(t, err)
+	client, err := spdk.New(path)
+	require.NoError(t, err)
+	return server, client, path, func() {
+		client.Close()
+		server.Close()
+		os.RemoveAll(tmp)
+	}
+}
+
+func TestBDevs(t *testing.T) {
+	t.Run("legacy", func(t *testing.T) {
+		testBDevs(t)
+	})
+	t.Run("current", func(t *testing.T) {
+		testBDevs(t, spdktest.WithCurrentMethodNames())
+	})
+}
+
+func testBDevs(t *testing.T, options ...spdktest.Option) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	server, client, _, cleanup := start(t, append(options, spdktest.WithVHostSCSIControllers("vhost.0"))...)
+	defer cleanup()
+
+	name, err := spdk.ConstructMallocBDev(ctx, client, spdk.ConstructMallocBDevArgs{
+		ConstructBDevArgs: spdk.ConstructBDevArgs{NumBlocks: 2048, BlockSize: 512, Name: "malloc-0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, spdk.ConstructBDevResponse("malloc-0"), name)
+	_, err = spdk.ConstructMallocBDev(ctx, client, spdk.ConstructMallocBDevArgs{
+		ConstructBDevArgs: spdk.ConstructBDevArgs{NumBlocks: 2048, BlockSize: 512, Name: "malloc-0"},
+	})
+	assert.True(t, spdk.IsAlreadyExists(err), "construct again: %v", err)
+	_, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{Name: "no-such-bdev"})
+	assert.True(t, spdk.IsNotFound(err), "unknown BDev: %v", err)
+
+	_, err = spdk.ConstructCryptoBDev(ctx, client, spdk.ConstructCryptoBDevArgs{
+		BaseBDevName: "malloc-0",
+		Name:         "crypto-0",
+		CryptoPMD:    spdk.CryptoAESNIMB,
+		Key:          "0123456789abcdef",
+	})
+	require.NoError(t, err)
+	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{})
+	require.NoError(t, err)
+	require.Len(t, bdevs, 2)
+	assert.Equal(t, spdktest.CryptoProductName, bdevs[0].ProductName)
+	assert.Equal(t, "malloc-0", bdevs[0].DriverSpecific.Crypto.BaseBDevName)
+	assert.Equal(t, spdktest.MallocProductName, bdevs[1].ProductName)
+	assert.True(t, bdevs[1].Claimed, "base BDev claimed")
+	assert.Error(t, spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: "malloc-0"}), "delete claimed BDev")
+
+	// Deleting a mapped BDev hot-removes it from the target.
+	err = spdk.AddVHostSCSILUN(ctx, client, spdk.AddVHostSCSILUNArgs{Controller: "vhost.0", SCSITargetNum: 1, BDevName: "crypto-0"})
+	require.NoError(t, err)
+	controllers, err := spdk.GetVHostControllers(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, controllers, 1)
+	scsi := controllers[0].BackendSpecific["scsi"].(spdk.SCSIControllerSpecific)
+	require.Len(t, scsi, 1)
+	assert.Equal(t, uint32(1), scsi[0].SCSIDevNum)
+	assert.Equal(t, "crypto-0", scsi[0].LUNs[0].BDevName)
+	err = spdk.DeleteCryptoBDev(ctx, client, spdk.DeleteCryptoBDevArgs{Name: "crypto-0"})
+	require.NoError(t, err)
+	controllers, err = spdk.GetVHostControllers(ctx, client)
+	require.NoError(t, err)
+	assert.Empty(t, controllers[0].BackendSpecific["scsi"])
+	assert.Equal(t, []string{"malloc-0"}, server.BDevNames())
+
+	err = spdk.StartNBDDisk(ctx, client, spdk.StartNBDDiskArgs{BDevName: "malloc-0", NBDDevice: "/dev/nbd0"})
+	require.NoError(t, err)
+	disks, err := spdk.GetNBDDisks(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, spdk.GetNBDDisksResponse{{BDevName: "malloc-0", NBDDevice: "/dev/nbd0"}}, disks)
+	err = spdk.StopNBDDisk(ctx, client, spdk.StopNBDDiskArgs{NBDDevice: "/dev/nbd0"})
+	require.NoError(t, err)
+
+	server.AddIO("malloc-0", 1, 2)
+	stats, err := spdk.GetBDevsIOStat(ctx, client, spdk.GetBDevsIOStatArgs{Name: "malloc-0"})
+	require.NoError(t, err)
+	assert.Equal(t, []spdk.BDevIOStat{{Name: "malloc-0", NumReadOps: 1, NumWriteOps: 2}}, stats.BDevs)
+}
+
+func TestMethodNames(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	server, client, path, cleanup := start(t, spdktest.WithCurrentMethodNames())
+	defer cleanup()
+	var sent []string
+	server.SetHook(func(method string, params json.RawMessage) error {
+		sent = append(sent, method)
+		return nil
+	})
+
+	var names []string
+	err := client.Invoke(ctx, "rpc_get_methods", nil, &names)
+	require.NoError(t, err)
+	assert.Contains(t, names, "bdev_get_bdevs")
+	assert.NotContains(t, names, "delete_bdev")
+	assert.NotContains(t, names, "get_bdevs")
+
+	// The client translates the old name...
+	_, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{})
+	assert.NoError(t, err, "translated")
+	assert.Equal(t, []string{"rpc_get_methods", "rpc_get_methods", "bdev_get_bdevs"}, sent)
+
+	// ... which the fake does not accept.
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","method":"get_bdevs","id":1}` + "\n"))
+	require.NoError(t, err)
+	var response struct {
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	err = json.NewDecoder(conn).Decode(&response)
+	require.NoError(t, err)
+	assert.Equal(t, spdk.ERROR_METHOD_NOT_FOUND, response.Error.Code)
+}
+
+func TestDeleteBDev(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	server, client, _, cleanup := start(t, spdktest.WithCurrentMethodNames())
+	defer cleanup()
+	var sent []string
+	server.SetHook(func(method string, params json.RawMessage) error {
+		sent = append(sent, method)
+		return nil
+	})
+
+	server.AddMallocBDev("malloc-0")
+	server.AddMallocBDev("malloc-1")
+	_, err := spdk.ConstructRBDBDev(ctx, client, spdk.ConstructRBDBDevArgs{
+		Name:      "rbd-0",
+		PoolName:  "rbd",
+		RBDName:   "image",
+		BlockSize: 512,
+	})
+	require.NoError(t, err)
+	_, err = spdk.ConstructCryptoBDev(ctx, client, spdk.ConstructCryptoBDevArgs{
+		BaseBDevName: "malloc-0",
+		Name:         "crypto-0",
+		CryptoPMD:    spdk.CryptoAESNIMB,
+		Key:          "0123456789abcdef",
+	})
+	require.NoError(t, err)
+	err = spdk.ConstructRAIDBDev(ctx, client, spdk.ConstructRAIDBDevArgs{
+		Name:        "raid-0",
+		StripSizeKB: 64,
+		RAIDLevel:   spdk.RAID0,
+		BaseBDevs:   []string{"malloc-1", "rbd-0"},
+	})
+	require.NoError(t, err)
+
+	// Users of BDevs must be deleted first.
+	sent = nil
+	for _, name := range []string{"raid-0", "crypto-0", "malloc-0", "malloc-1", "rbd-0"} {
+		err := spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: name})
+		assert.NoError(t, err, "delete %s", name)
+	}
+	assert.Empty(t, server.BDevNames())
+	assert.Equal(t, []string{
+		"bdev_get_bdevs", "bdev_raid_delete",
+		"bdev_get_bdevs", "bdev_crypto_delete",
+		"bdev_get_bdevs", "bdev_malloc_delete",
+		"bdev_get_bdevs", "bdev_malloc_delete",
+		"bdev_get_bdevs", "bdev_rbd_delete",
+	}, sent)
+
+	err = spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: "malloc-0"})
+	assert.True(t, spdk.IsNotFound(err), "delete again: %v", err)
+}
+
+func TestHook(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	server, client, _, cleanup := start(t)
+	defer cleanup()
+	server.AddMallocBDev("malloc-0")
+
+	injected := &spdk.Error{Code: spdk.ERROR_INTERNAL_ERROR, Message: "injected"}
+	server.SetHook(func(method string, params json.RawMessage) error {
+		if method == "delete_malloc_bdev" {
+			return injected
+		}
+		return nil
+	})
+	err := spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: "malloc-0"})
+	var spdkErr *spdk.Error
+	require.True(t, errors.As(err, &spdkErr), "injected error: %v", err)
+	assert.Equal(t, "injected", spdkErr.Message)
+	assert.NotNil(t, server.BDev("malloc-0"), "BDev not deleted")
+
+	server.SetHook(func(method string, params json.RawMessage) error {
+		return errors.New("crash")
+	})
+	_, err = spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{})
+	assert.Error(t, err, "dropped connection")
+	assert.False(t, spdk.IsJSONError(err, 0), "connection error instead of SPDK error: %v", err)
+}
+
+func TestNotifications(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	server, client, _, cleanup := start(t, spdktest.WithCurrentMethodNames())
+	defer cleanup()
+	server.AddMallocBDev("malloc-0")
+	server.AddMallocBDev("malloc-1")
+	server.DeleteBDev("malloc-0")
+
+	notifications, err := spdk.GetNotifications(ctx, client, spdk.GetNotificationsArgs{})
+	require.NoError(t, err)
+	assert.Equal(t, []spdk.Notification{
+		{Type: spdk.NotificationBDevRegister, Ctx: "malloc-0", ID: 0},
+		{Type: spdk.NotificationBDevRegister, Ctx: "malloc-1", ID: 1},
+		{Type: spdk.NotificationBDevUnregister, Ctx: "malloc-0", ID: 2},
+	}, notifications)
+	notifications, err = spdk.GetNotifications(ctx, client, spdk.GetNotificationsArgs{ID: 1, Max: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []spdk.Notification{
+		{Type: spdk.NotificationBDevRegister, Ctx: "malloc-1", ID: 1},
+	}, notifications)
+	notifications, err = spdk.GetNotifications(ctx, client, spdk.GetNotificationsArgs{ID: 3})
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+}
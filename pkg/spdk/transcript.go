@@ -0,0 +1,296 @@
+/*
+Copyright (C) 2018 Intel Corporation
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// A transcript contains one message per line, in the order in which
+// they were sent or received. Requests start with "> ", responses
+// with "< ". Responses are matched with requests by their JSON-RPC
+// "id", so concurrent calls may overlap. Secrets are stripped.
+// Empty lines and lines starting with # are ignored and can be used
+// to document where the transcript came from:
+//
+//	# SPDK 19.04, get_bdevs with an unknown name
+//	> {"jsonrpc":"2.0","method":"get_bdevs","params":{"name":"foo"},"id":0}
+//	< {"jsonrpc":"2.0","id":0,"error":{"code":-32602,"message":"Invalid parameters"}}
+const (
+	requestPrefix  = "> "
+	responsePrefix = "< "
+)
+
+// recorder writes a transcript of all connections of a Client.
+type recorder struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+// record must be called with complete messages with secrets already
+// stripped.
+func (r *recorder) record(prefix string, msg []byte) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var buffer bytes.Buffer
+	buffer.WriteString(prefix)
+	buffer.Write(bytes.TrimSpace(msg))
+	buffer.WriteByte('\n')
+	if _, err := r.w.Write(buffer.Bytes()); err != nil {
+		log.L().Errorw("recording SPDK transcript", "error", err)
+	}
+}
+
+// WithRecorder writes a transcript of all messages exchanged with
+// SPDK, for example into a golden file that Replay can serve back
+// later. Concurrent calls are recorded in the order in which they
+// went over the connection, therefore responses are not necessarily
+// right after their request.
+func WithRecorder(w io.Writer) Option {
+	return func(c *Client) {
+		c.recorder = &recorder{w: w}
+	}
+}
+
+// WithDialer replaces connecting to the Unix domain socket given to
+// New, for example with Replay.Dial. The path then only shows up in
+// log messages.
+func WithDialer(dial func() (net.Conn, error)) Option {
+	return func(c *Client) {
+		c.dialer = dial
+	}
+}
+
+type transcriptEntry struct {
+	line     int
+	id       string
+	request  []byte
+	response []byte
+	replayed bool
+}
+
+// Replay serves a transcript back to a Client, which makes it
+// possible to reproduce the behavior of a certain SPDK release
+// without running it. Each request must be identical to a recorded
+// one, except for the "id", but may arrive in a different order, as
+// concurrent calls do. It is matched with the first recorded request
+// that was not replayed yet and immediately gets the response that
+// was recorded for that. A request without match closes the
+// connection and is reported by Verify.
+type Replay struct {
+	mutex   sync.Mutex
+	entries []transcriptEntry
+	err     error
+}
+
+// NewReplay parses a transcript as written by WithRecorder.
+func NewReplay(transcript io.Reader) (*Replay, error) {
+	r := &Replay{}
+	scanner := bufio.NewScanner(transcript)
+	// Responses to get_bdevs can be long.
+	scanner.Buffer(nil, 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Bytes()
+		switch {
+		case len(bytes.TrimSpace(text)) == 0 || text[0] == '#':
+			continue
+		case bytes.HasPrefix(text, []byte(requestPrefix)):
+			request := append([]byte(nil), text[len(requestPrefix):]...)
+			id, err := messageID(request)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+			r.entries = append(r.entries, transcriptEntry{
+				line:    line,
+				id:      id,
+				request: request,
+			})
+		case bytes.HasPrefix(text, []byte(responsePrefix)):
+			response := append([]byte(nil), text[len(responsePrefix):]...)
+			id, err := messageID(response)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+			entry := r.pendingRequest(id)
+			if entry == nil {
+				return nil, errors.Errorf("line %d: response without request", line)
+			}
+			entry.response = response
+		default:
+			return nil, errors.Errorf("line %d: neither request nor response", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read transcript")
+	}
+	return r, nil
+}
+
+// pendingRequest returns the most recent request with the given id
+// if it has no response yet. Older requests with the same id were
+// sent over a previous connection, because each connection starts
+// counting at zero.
+func (r *Replay) pendingRequest(id string) *transcriptEntry {
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := &r.entries[i]
+		if entry.id == id {
+			if entry.response != nil {
+				return nil
+			}
+			return entry
+		}
+	}
+	return nil
+}
+
+// messageID returns the "id" of a JSON-RPC message in a normalized
+// form.
+func messageID(msg []byte) (string, error) {
+	var m struct {
+		ID interface{} `json:"id"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil {
+		return "", errors.Wrap(err, "parse message")
+	}
+	id, err := json.Marshal(m.ID)
+	if err != nil {
+		return "", errors.Errorf("invalid id %v", m.ID)
+	}
+	return string(id), nil
+}
+
+// Dial returns a new connection to the replayed SPDK. It can be
+// passed to WithDialer.
+func (r *Replay) Dial() (net.Conn, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	client, server := net.Pipe()
+	go r.serve(server)
+	return client, nil
+}
+
+// Verify returns the first mismatch between transcript and actual
+// requests, or an error if not all recorded requests were sent.
+func (r *Replay) Verify() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	line, remaining := 0, 0
+	for _, entry := range r.entries {
+		if !entry.replayed {
+			if remaining == 0 {
+				line = entry.line
+			}
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		return errors.Errorf("line %d: %d recorded request(s) not replayed", line, remaining)
+	}
+	return nil
+}
+
+func (r *Replay) serve(conn net.Conn) {
+	defer conn.Close()
+	dec := json.NewDecoder(conn)
+	for {
+		var request json.RawMessage
+		if err := dec.Decode(&request); err != nil {
+			return
+		}
+		response, err := r.respond(request)
+		if err != nil {
+			return
+		}
+		if response == nil {
+			// SPDK did not respond while recording.
+			continue
+		}
+		if _, err := conn.Write(append(response, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+// respond finds the recorded response for the request.
+func (r *Replay) respond(request []byte) ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	request = stripSecrets(request)
+	var entry *transcriptEntry
+	var id json.RawMessage
+	for i := range r.entries {
+		if r.entries[i].replayed {
+			continue
+		}
+		var equal bool
+		var err error
+		id, equal, err = compareRequests(request, r.entries[i].request)
+		if err != nil {
+			r.err = errors.Wrapf(err, "line %d", r.entries[i].line)
+			return nil, r.err
+		}
+		if equal {
+			entry = &r.entries[i]
+			break
+		}
+	}
+	if entry == nil {
+		r.err = errors.Errorf("unexpected request, not in the remaining transcript: %s", request)
+		return nil, r.err
+	}
+	entry.replayed = true
+	if entry.response == nil {
+		return nil, nil
+	}
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(entry.response, &response); err != nil {
+		r.err = errors.Wrapf(err, "line %d: parse response", entry.line)
+		return nil, r.err
+	}
+	response["id"] = id
+	return json.Marshal(response)
+}
+
+// compareRequests ignores the "id" and returns the one of the actual
+// request.
+func compareRequests(actual, recorded []byte) (json.RawMessage, bool, error) {
+	var a, b map[string]interface{}
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return nil, false, errors.Wrap(err, "parse request")
+	}
+	if err := json.Unmarshal(recorded, &b); err != nil {
+		return nil, false, errors.Wrap(err, "parse recorded request")
+	}
+	id, err := json.Marshal(a["id"])
+	if err != nil {
+		return nil, false, errors.Errorf("invalid id %v", a["id"])
+	}
+	delete(a, "id")
+	delete(b, "id")
+	return id, reflect.DeepEqual(a, b), nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spdk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/intel/oim/pkg/log/testlog"
+)
+
+func TestRecordReplay(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	tmp, err := ioutil.TempDir("", "spdk-transcript")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "vhost.sock")
+	ctx := context.Background()
+
+	// calls returns the results in a form that can be compared.
+	calls := func(client *Client) []interface{} {
+		var results []interface{}
+		var reply []interface{}
+		err := client.Invoke(ctx, "get_bdevs", nil, &reply)
+		results = append(results, reply, err)
+		err = client.Invoke(ctx, "delete_bdev", DeleteBDevArgs{Name: "foo"}, nil)
+		results = append(results, err)
+		err = client.Invoke(ctx, "construct_crypto_bdev", ConstructCryptoBDevArgs{Name: "crypto-foo", Key: "0123456789abcdef"}, &reply)
+		results = append(results, reply, err)
+		return results
+	}
+
+	f := startFakeSPDK(t, path)
+	defer f.stop()
+	var transcript bytes.Buffer
+	client, err := New(path, WithRecorder(&transcript))
+	require.NoError(t, err)
+	recorded := calls(client)
+	client.Close()
+	assert.Equal(t, `> {"jsonrpc":"2.0","method":"rpc_get_methods","id":0}
+< {"id":0,"jsonrpc":"2.0","result":[]}
+> {"jsonrpc":"2.0","method":"get_bdevs","id":1}
+< {"id":1,"jsonrpc":"2.0","result":[]}
+> {"jsonrpc":"2.0","method":"delete_bdev","params":{"name":"foo"},"id":2}
+< {"error":{"code":-32602,"message":"No such device"},"id":2,"jsonrpc":"2.0"}
+> {"jsonrpc":"2.0","method":"construct_crypto_bdev","params":{"base_bdev_name":"","name":"crypto-foo","crypto_pmd":"","key":"***stripped***"},"id":3}
+< {"id":3,"jsonrpc":"2.0","result":[]}
+`, transcript.String())
+
+	replay, err := NewReplay(strings.NewReader("# comment\n\n" + transcript.String()))
+	require.NoError(t, err)
+	client, err = New("replay", WithDialer(replay.Dial))
+	require.NoError(t, err)
+	defer client.Close()
+	assert.Equal(t, recorded, calls(client), "replayed results")
+	assert.NoError(t, replay.Verify(), "complete replay")
+
+	err = client.Invoke(ctx, "get_bdevs", nil, nil)
+	assert.Error(t, err, "call after end of transcript")
+	assert.Error(t, replay.Verify(), "unexpected request")
+}
+
+func TestReplayMismatch(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	replay, err := NewReplay(strings.NewReader(`> {"jsonrpc":"2.0","method":"rpc_get_methods","id":0}
+< {"jsonrpc":"2.0","id":0,"result":["get_bdevs"]}
+> {"jsonrpc":"2.0","method":"get_bdevs","id":1}
+< {"jsonrpc":"2.0","id":1,"result":[]}
+`))
+	require.NoError(t, err)
+	client, err := New("replay", WithDialer(replay.Dial))
+	require.NoError(t, err)
+	defer client.Close()
+	err = client.Invoke(ctx, "get_bdevs", GetBDevsArgs{Name: "foo"}, nil)
+	assert.Error(t, err, "different parameters")
+	err = replay.Verify()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not in the remaining transcript")
+	}
+
+	_, err = NewReplay(strings.NewReader("< {}\n"))
+	assert.EqualError(t, err, "line 1: response without request")
+	_, err = NewReplay(strings.NewReader(`> {"method":"get_bdevs","id":0}
+< {"id":1,"result":[]}
+`))
+	assert.EqualError(t, err, "line 2: response without request")
+	_, err = NewReplay(strings.NewReader(`> {"method":"get_bdevs","id":0}
+< {"id":0,"result":[]}
+< {"id":0,"result":[]}
+`))
+	assert.EqualError(t, err, "line 3: response without request")
+	_, err = NewReplay(strings.NewReader("get_bdevs\n"))
+	assert.EqualError(t, err, "line 1: neither request nor response")
+}
+
+func TestReplayOverlapping(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+
+	// SPDK answered the second call first. Responses must be
+	// matched by id, not by position. The calls are replayed in
+	// a different order than they were recorded.
+	replay, err := NewReplay(strings.NewReader(`> {"jsonrpc":"2.0","method":"rpc_get_methods","id":0}
+< {"jsonrpc":"2.0","id":0,"result":["get_bdevs","delete_bdev"]}
+> {"jsonrpc":"2.0","method":"get_bdevs","params":{},"id":1}
+> {"jsonrpc":"2.0","method":"delete_bdev","params":{"name":"foo"},"id":2}
+< {"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"No such device"}}
+< {"jsonrpc":"2.0","id":1,"result":[]}
+`))
+	require.NoError(t, err)
+	client, err := New("replay", WithDialer(replay.Dial))
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.Invoke(ctx, "delete_bdev", DeleteBDevArgs{Name: "foo"}, nil)
+	assert.True(t, IsNotFound(err), "delete_bdev: %v", err)
+	assert.Error(t, replay.Verify(), "get_bdevs not replayed yet")
+	bdevs, err := GetBDevs(ctx, client, GetBDevsArgs{})
+	assert.NoError(t, err, "get_bdevs")
+	assert.Empty(t, bdevs, "get_bdevs")
+	assert.NoError(t, replay.Verify())
+}
+
+func TestReplayGolden(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+	ctx := context.Background()
+	file, err := os.Open("testdata/not-found-19.04.txt")
+	require.NoError(t, err)
+	defer file.Close()
+	replay, err := NewReplay(file)
+	require.NoError(t, err)
+	client, err := New("replay", WithDialer(replay.Dial))
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = GetBDevs(ctx, client, GetBDevsArgs{Name: "no-such-bdev"})
+	assert.True(t, IsNotFound(err), "get_bdevs: %v", err)
+	err = client.Invoke(ctx, "delete_bdev", DeleteBDevArgs{Name: "no-such-bdev"}, nil)
+	assert.True(t, IsNotFound(err), "delete_bdev: %v", err)
+	assert.NoError(t, replay.Verify())
+}
@@ -13,18 +13,61 @@
 		SetValueReply
 		GetValuesRequest
 		GetValuesReply
+		AcquireLeaseRequest
+		AcquireLeaseReply
+		ReleaseLeaseRequest
+		ReleaseLeaseReply
 		MapVolumeRequest
+		QoS
 		MallocParams
+		LVolParams
+		RaidParams
+		RaidMember
+		CryptoParams
 		CephParams
 		MapVolumeReply
 		PCIAddress
 		SCSIDisk
 		UnmapVolumeRequest
 		UnmapVolumeReply
+		QuiesceVolumeRequest
+		QuiesceVolumeReply
+		ResumeVolumeRequest
+		ResumeVolumeReply
 		ProvisionMallocBDevRequest
 		ProvisionMallocBDevReply
 		CheckMallocBDevRequest
 		CheckMallocBDevReply
+		ProvisionLVolRequest
+		ProvisionLVolReply
+		Snapshot
+		CreateSnapshotRequest
+		CreateSnapshotReply
+		DeleteSnapshotRequest
+		DeleteSnapshotReply
+		ListSnapshotsRequest
+		ListSnapshotsReply
+		CloneVolumeRequest
+		CloneVolumeReply
+		ResizeVolumeRequest
+		ResizeVolumeReply
+		SetVolumeQoSRequest
+		SetVolumeQoSReply
+		Orphan
+		ListOrphansRequest
+		ListOrphansReply
+		CollectGarbageRequest
+		CollectGarbageReply
+		Volume
+		ListVolumesRequest
+		ListVolumesReply
+		GetVolumeRequest
+		GetVolumeReply
+		GetVolumeStatsRequest
+		GetVolumeStatsReply
+		GetOperationRequest
+		GetOperationReply
+		Operation
 */
 package oim
 
@@ -49,6 +92,54 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type RaidParams_Level int32
+
+const (
+	// Striping, the only level implemented by the
+	// SPDK version used by OIM.
+	RaidParams_RAID0 RaidParams_Level = 0
+)
+
+var RaidParams_Level_name = map[int32]string{
+	0: "RAID0",
+}
+var RaidParams_Level_value = map[string]int32{
+	"RAID0": 0,
+}
+
+func (x RaidParams_Level) String() string {
+	return proto.EnumName(RaidParams_Level_name, int32(x))
+}
+func (RaidParams_Level) EnumDescriptor() ([]byte, []int) { return fileDescriptorOim, []int{13, 0} }
+
+type Orphan_Kind int32
+
+const (
+	Orphan_UNKNOWN Orphan_Kind = 0
+	// A RBD, crypto or RAID BDev not attached to a SCSI
+	// target.
+	Orphan_BDEV Orphan_Kind = 1
+	// A SCSI target without LUN or with a LUN whose
+	// BDev is gone.
+	Orphan_SCSI_TARGET Orphan_Kind = 2
+)
+
+var Orphan_Kind_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "BDEV",
+	2: "SCSI_TARGET",
+}
+var Orphan_Kind_value = map[string]int32{
+	"UNKNOWN":     0,
+	"BDEV":        1,
+	"SCSI_TARGET": 2,
+}
+
+func (x Orphan_Kind) String() string {
+	return proto.EnumName(Orphan_Kind_name, int32(x))
+}
+func (Orphan_Kind) EnumDescriptor() ([]byte, []int) { return fileDescriptorOim, []int{45, 0} }
+
 type SetValueRequest struct {
 	Value *Value `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
 }
@@ -138,6 +229,96 @@ func (m *GetValuesReply) GetValues() []*Value {
 	return nil
 }
 
+type AcquireLeaseRequest struct {
+	// The volume that the caller wants to map.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Take over the lease from other controllers. Only
+	// safe when those controllers no longer use the volume.
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// Request a shared lease. Any number of controllers may
+	// hold it at the same time, but not while some other
+	// controller holds the exclusive lease. A controller
+	// which holds the exclusive lease keeps it.
+	Shared bool `protobuf:"varint,3,opt,name=shared,proto3" json:"shared,omitempty"`
+}
+
+func (m *AcquireLeaseRequest) Reset()                    { *m = AcquireLeaseRequest{} }
+func (m *AcquireLeaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*AcquireLeaseRequest) ProtoMessage()               {}
+func (*AcquireLeaseRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{5} }
+
+func (m *AcquireLeaseRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *AcquireLeaseRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *AcquireLeaseRequest) GetShared() bool {
+	if m != nil {
+		return m.Shared
+	}
+	return false
+}
+
+type AcquireLeaseReply struct {
+	// The controller ID which held the exclusive lease
+	// before, empty if none.
+	PreviousHolder string `protobuf:"bytes,1,opt,name=previous_holder,json=previousHolder,proto3" json:"previous_holder,omitempty"`
+	// The controller IDs which held the shared lease before.
+	PreviousReaders []string `protobuf:"bytes,2,rep,name=previous_readers,json=previousReaders" json:"previous_readers,omitempty"`
+}
+
+func (m *AcquireLeaseReply) Reset()                    { *m = AcquireLeaseReply{} }
+func (m *AcquireLeaseReply) String() string            { return proto.CompactTextString(m) }
+func (*AcquireLeaseReply) ProtoMessage()               {}
+func (*AcquireLeaseReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{6} }
+
+func (m *AcquireLeaseReply) GetPreviousHolder() string {
+	if m != nil {
+		return m.PreviousHolder
+	}
+	return ""
+}
+
+func (m *AcquireLeaseReply) GetPreviousReaders() []string {
+	if m != nil {
+		return m.PreviousReaders
+	}
+	return nil
+}
+
+type ReleaseLeaseRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+}
+
+func (m *ReleaseLeaseRequest) Reset()                    { *m = ReleaseLeaseRequest{} }
+func (m *ReleaseLeaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseLeaseRequest) ProtoMessage()               {}
+func (*ReleaseLeaseRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{7} }
+
+func (m *ReleaseLeaseRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+type ReleaseLeaseReply struct {
+}
+
+func (m *ReleaseLeaseReply) Reset()                    { *m = ReleaseLeaseReply{} }
+func (m *ReleaseLeaseReply) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseLeaseReply) ProtoMessage()               {}
+func (*ReleaseLeaseReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{8} }
+
 type MapVolumeRequest struct {
 	// An identifier for the volume that must be unique
 	// among all volumes mapped by the OIM controller.
@@ -149,13 +330,33 @@ type MapVolumeRequest struct {
 	// Types that are valid to be assigned to Params:
 	//	*MapVolumeRequest_Malloc
 	//	*MapVolumeRequest_Ceph
+	//	*MapVolumeRequest_Lvol
+	//	*MapVolumeRequest_Raid
 	Params isMapVolumeRequest_Params `protobuf_oneof:"params"`
+	// Optional rate limits for the volume.
+	Qos *QoS `protobuf:"bytes,5,opt,name=qos" json:"qos,omitempty"`
+	// Optional encryption of the volume.
+	Crypto *CryptoParams `protobuf:"bytes,6,opt,name=crypto" json:"crypto,omitempty"`
+	// A controller with self-registration acquires a lease
+	// for the volume in the OIM registry and fails
+	// with FAILED_PRECONDITION if another controller holds
+	// it. Force takes over the lease.
+	Force bool `protobuf:"varint,8,opt,name=force,proto3" json:"force,omitempty"`
+	// Return without waiting for the operation.
+	Async bool `protobuf:"varint,9,opt,name=async,proto3" json:"async,omitempty"`
+	// The host only reads from the volume, for example
+	// because it was staged with a READER_ONLY access
+	// mode. The controller then acquires a shared lease,
+	// which other readers may hold at the same time. The
+	// LUN remains writable, the host has to prevent
+	// writes.
+	Reader bool `protobuf:"varint,11,opt,name=reader,proto3" json:"reader,omitempty"`
 }
 
 func (m *MapVolumeRequest) Reset()                    { *m = MapVolumeRequest{} }
 func (m *MapVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*MapVolumeRequest) ProtoMessage()               {}
-func (*MapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{5} }
+func (*MapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{9} }
 
 type isMapVolumeRequest_Params interface {
 	isMapVolumeRequest_Params()
@@ -169,9 +370,17 @@ type MapVolumeRequest_Malloc struct {
 type MapVolumeRequest_Ceph struct {
 	Ceph *CephParams `protobuf:"bytes,3,opt,name=ceph,oneof"`
 }
+type MapVolumeRequest_Lvol struct {
+	Lvol *LVolParams `protobuf:"bytes,4,opt,name=lvol,oneof"`
+}
+type MapVolumeRequest_Raid struct {
+	Raid *RaidParams `protobuf:"bytes,10,opt,name=raid,oneof"`
+}
 
 func (*MapVolumeRequest_Malloc) isMapVolumeRequest_Params() {}
 func (*MapVolumeRequest_Ceph) isMapVolumeRequest_Params()   {}
+func (*MapVolumeRequest_Lvol) isMapVolumeRequest_Params()   {}
+func (*MapVolumeRequest_Raid) isMapVolumeRequest_Params()   {}
 
 func (m *MapVolumeRequest) GetParams() isMapVolumeRequest_Params {
 	if m != nil {
@@ -201,11 +410,62 @@ func (m *MapVolumeRequest) GetCeph() *CephParams {
 	return nil
 }
 
+func (m *MapVolumeRequest) GetLvol() *LVolParams {
+	if x, ok := m.GetParams().(*MapVolumeRequest_Lvol); ok {
+		return x.Lvol
+	}
+	return nil
+}
+
+func (m *MapVolumeRequest) GetRaid() *RaidParams {
+	if x, ok := m.GetParams().(*MapVolumeRequest_Raid); ok {
+		return x.Raid
+	}
+	return nil
+}
+
+func (m *MapVolumeRequest) GetQos() *QoS {
+	if m != nil {
+		return m.Qos
+	}
+	return nil
+}
+
+func (m *MapVolumeRequest) GetCrypto() *CryptoParams {
+	if m != nil {
+		return m.Crypto
+	}
+	return nil
+}
+
+func (m *MapVolumeRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *MapVolumeRequest) GetAsync() bool {
+	if m != nil {
+		return m.Async
+	}
+	return false
+}
+
+func (m *MapVolumeRequest) GetReader() bool {
+	if m != nil {
+		return m.Reader
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*MapVolumeRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _MapVolumeRequest_OneofMarshaler, _MapVolumeRequest_OneofUnmarshaler, _MapVolumeRequest_OneofSizer, []interface{}{
 		(*MapVolumeRequest_Malloc)(nil),
 		(*MapVolumeRequest_Ceph)(nil),
+		(*MapVolumeRequest_Lvol)(nil),
+		(*MapVolumeRequest_Raid)(nil),
 	}
 }
 
@@ -223,6 +483,16 @@ func _MapVolumeRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.Ceph); err != nil {
 			return err
 		}
+	case *MapVolumeRequest_Lvol:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Lvol); err != nil {
+			return err
+		}
+	case *MapVolumeRequest_Raid:
+		_ = b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Raid); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("MapVolumeRequest.Params has unexpected type %T", x)
@@ -249,6 +519,22 @@ func _MapVolumeRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Params = &MapVolumeRequest_Ceph{msg}
 		return true, err
+	case 4: // params.lvol
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LVolParams)
+		err := b.DecodeMessage(msg)
+		m.Params = &MapVolumeRequest_Lvol{msg}
+		return true, err
+	case 10: // params.raid
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RaidParams)
+		err := b.DecodeMessage(msg)
+		m.Params = &MapVolumeRequest_Raid{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -268,6 +554,16 @@ func _MapVolumeRequest_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *MapVolumeRequest_Lvol:
+		s := proto.Size(x.Lvol)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *MapVolumeRequest_Raid:
+		s := proto.Size(x.Raid)
+		n += proto.SizeVarint(10<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -275,6 +571,53 @@ func _MapVolumeRequest_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// Rate limits for a volume, enforced by SPDK. Zero means
+// unlimited. SPDK rounds up to the next multiple of 10000
+// IOPS resp. 10 MB/s.
+type QoS struct {
+	// Read and write I/O operations per second.
+	RwIosPerSec uint64 `protobuf:"varint,1,opt,name=rw_ios_per_sec,json=rwIosPerSec,proto3" json:"rw_ios_per_sec,omitempty"`
+	// Read and write bandwidth in MB/s.
+	RwMbytesPerSec uint64 `protobuf:"varint,2,opt,name=rw_mbytes_per_sec,json=rwMbytesPerSec,proto3" json:"rw_mbytes_per_sec,omitempty"`
+	// Read bandwidth in MB/s.
+	RMbytesPerSec uint64 `protobuf:"varint,3,opt,name=r_mbytes_per_sec,json=rMbytesPerSec,proto3" json:"r_mbytes_per_sec,omitempty"`
+	// Write bandwidth in MB/s.
+	WMbytesPerSec uint64 `protobuf:"varint,4,opt,name=w_mbytes_per_sec,json=wMbytesPerSec,proto3" json:"w_mbytes_per_sec,omitempty"`
+}
+
+func (m *QoS) Reset()                    { *m = QoS{} }
+func (m *QoS) String() string            { return proto.CompactTextString(m) }
+func (*QoS) ProtoMessage()               {}
+func (*QoS) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{10} }
+
+func (m *QoS) GetRwIosPerSec() uint64 {
+	if m != nil {
+		return m.RwIosPerSec
+	}
+	return 0
+}
+
+func (m *QoS) GetRwMbytesPerSec() uint64 {
+	if m != nil {
+		return m.RwMbytesPerSec
+	}
+	return 0
+}
+
+func (m *QoS) GetRMbytesPerSec() uint64 {
+	if m != nil {
+		return m.RMbytesPerSec
+	}
+	return 0
+}
+
+func (m *QoS) GetWMbytesPerSec() uint64 {
+	if m != nil {
+		return m.WMbytesPerSec
+	}
+	return 0
+}
+
 // For testing purposes, an existing Malloc BDev can be used.
 // It needs to be provisioned separately to ensure that its
 // data survives multiple Map/Unmap operations. It's name
@@ -285,1286 +628,10240 @@ type MallocParams struct {
 func (m *MallocParams) Reset()                    { *m = MallocParams{} }
 func (m *MallocParams) String() string            { return proto.CompactTextString(m) }
 func (*MallocParams) ProtoMessage()               {}
-func (*MallocParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{6} }
+func (*MallocParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{11} }
 
-// Defines a Ceph block device.
-type CephParams struct {
-	// The user id (like "admin", but not "client.admin").
-	// Can be left out, the default in Ceph is "admin".
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	// The "key" value from a Ceph keyring for the user.
-	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
-	// Comma-separated list of addr:port values.
-	Monitors string `protobuf:"bytes,3,opt,name=monitors,proto3" json:"monitors,omitempty"`
-	// Pool name
-	Pool string `protobuf:"bytes,4,opt,name=pool,proto3" json:"pool,omitempty"`
-	// Image name
-	Image string `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+// A logical volume created earlier with ProvisionLVol
+// or CloneVolume. Its name inside the lvol store
+// is <volume_id>.
+type LVolParams struct {
+	// The name of the lvol store.
+	LvolStore string `protobuf:"bytes,1,opt,name=lvol_store,json=lvolStore,proto3" json:"lvol_store,omitempty"`
 }
 
-func (m *CephParams) Reset()                    { *m = CephParams{} }
-func (m *CephParams) String() string            { return proto.CompactTextString(m) }
-func (*CephParams) ProtoMessage()               {}
-func (*CephParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{7} }
+func (m *LVolParams) Reset()                    { *m = LVolParams{} }
+func (m *LVolParams) String() string            { return proto.CompactTextString(m) }
+func (*LVolParams) ProtoMessage()               {}
+func (*LVolParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{12} }
 
-func (m *CephParams) GetUserId() string {
+func (m *LVolParams) GetLvolStore() string {
 	if m != nil {
-		return m.UserId
+		return m.LvolStore
 	}
 	return ""
 }
 
-func (m *CephParams) GetSecret() string {
-	if m != nil {
-		return m.Secret
-	}
-	return ""
+// Combines several volumes into one SPDK RAID BDev. The
+// controller creates the member BDevs the same way as for
+// MapVolume. UnmapVolume removes the Ceph members again,
+// Malloc BDevs and logical volumes are kept. Members must
+// not be mapped as volumes of their own.
+type RaidParams struct {
+	Level RaidParams_Level `protobuf:"varint,1,opt,name=level,proto3,enum=oim.v0.RaidParams_Level" json:"level,omitempty"`
+	// Strip size in KiB, must be a power of two. The
+	// default is 64.
+	StripSizeKb uint32 `protobuf:"varint,2,opt,name=strip_size_kb,json=stripSizeKb,proto3" json:"strip_size_kb,omitempty"`
+	// At least two members.
+	Members []*RaidMember `protobuf:"bytes,3,rep,name=members" json:"members,omitempty"`
 }
 
-func (m *CephParams) GetMonitors() string {
+func (m *RaidParams) Reset()                    { *m = RaidParams{} }
+func (m *RaidParams) String() string            { return proto.CompactTextString(m) }
+func (*RaidParams) ProtoMessage()               {}
+func (*RaidParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{13} }
+
+func (m *RaidParams) GetLevel() RaidParams_Level {
 	if m != nil {
-		return m.Monitors
+		return m.Level
 	}
-	return ""
+	return RaidParams_RAID0
 }
 
-func (m *CephParams) GetPool() string {
+func (m *RaidParams) GetStripSizeKb() uint32 {
 	if m != nil {
-		return m.Pool
+		return m.StripSizeKb
 	}
-	return ""
+	return 0
 }
 
-func (m *CephParams) GetImage() string {
+func (m *RaidParams) GetMembers() []*RaidMember {
 	if m != nil {
-		return m.Image
+		return m.Members
 	}
-	return ""
+	return nil
 }
 
-// The reply must tell the caller enough about the mapped volume
-// to find it in /sys/dev/block.
-type MapVolumeReply struct {
-	// The PCI address (domain/bus/device/function, extended BDF).
-	// A controller which does not know its own PCI address can
-	// return a an address with all fields set to 0xFFFF.
-	PciAddress *PCIAddress `protobuf:"bytes,1,opt,name=pci_address,json=pciAddress" json:"pci_address,omitempty"`
-	// The SCSI target and LUN. Only present for disks attached
-	// via a SCSI controller.
-	ScsiDisk *SCSIDisk `protobuf:"bytes,2,opt,name=scsi_disk,json=scsiDisk" json:"scsi_disk,omitempty"`
+// One volume inside a RaidParams.
+type RaidMember struct {
+	// Identifies the member like the volume_id in a
+	// MapVolumeRequest and must be different from that
+	// and the other members. Ceph members are leased
+	// under this ID like a mapped volume.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Types that are valid to be assigned to Params:
+	//	*RaidMember_Malloc
+	//	*RaidMember_Ceph
+	//	*RaidMember_Lvol
+	Params isRaidMember_Params `protobuf_oneof:"params"`
 }
 
-func (m *MapVolumeReply) Reset()                    { *m = MapVolumeReply{} }
-func (m *MapVolumeReply) String() string            { return proto.CompactTextString(m) }
-func (*MapVolumeReply) ProtoMessage()               {}
-func (*MapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{8} }
+func (m *RaidMember) Reset()                    { *m = RaidMember{} }
+func (m *RaidMember) String() string            { return proto.CompactTextString(m) }
+func (*RaidMember) ProtoMessage()               {}
+func (*RaidMember) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{14} }
 
-func (m *MapVolumeReply) GetPciAddress() *PCIAddress {
-	if m != nil {
-		return m.PciAddress
-	}
-	return nil
+type isRaidMember_Params interface {
+	isRaidMember_Params()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (m *MapVolumeReply) GetScsiDisk() *SCSIDisk {
-	if m != nil {
-		return m.ScsiDisk
-	}
-	return nil
+type RaidMember_Malloc struct {
+	Malloc *MallocParams `protobuf:"bytes,2,opt,name=malloc,oneof"`
 }
-
-// Each field can be marked as unknown or unset with 0xFFFF.
-// This leads to nicer code than the other workarounds for missing
-// optional scalars (.google.protobuf.UInt32Value or oneof).
-type PCIAddress struct {
-	// Domain number.
-	Domain uint32 `protobuf:"varint,1,opt,name=domain,proto3" json:"domain,omitempty"`
-	// Bus number.
-	Bus uint32 `protobuf:"varint,2,opt,name=bus,proto3" json:"bus,omitempty"`
-	// Device number.
-	Device uint32 `protobuf:"varint,3,opt,name=device,proto3" json:"device,omitempty"`
-	// Function number.
-	Function uint32 `protobuf:"varint,4,opt,name=function,proto3" json:"function,omitempty"`
+type RaidMember_Ceph struct {
+	Ceph *CephParams `protobuf:"bytes,3,opt,name=ceph,oneof"`
+}
+type RaidMember_Lvol struct {
+	Lvol *LVolParams `protobuf:"bytes,4,opt,name=lvol,oneof"`
 }
 
-func (m *PCIAddress) Reset()                    { *m = PCIAddress{} }
-func (m *PCIAddress) String() string            { return proto.CompactTextString(m) }
-func (*PCIAddress) ProtoMessage()               {}
-func (*PCIAddress) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{9} }
+func (*RaidMember_Malloc) isRaidMember_Params() {}
+func (*RaidMember_Ceph) isRaidMember_Params()   {}
+func (*RaidMember_Lvol) isRaidMember_Params()   {}
 
-func (m *PCIAddress) GetDomain() uint32 {
+func (m *RaidMember) GetParams() isRaidMember_Params {
 	if m != nil {
-		return m.Domain
+		return m.Params
 	}
-	return 0
+	return nil
 }
 
-func (m *PCIAddress) GetBus() uint32 {
+func (m *RaidMember) GetVolumeId() string {
 	if m != nil {
-		return m.Bus
+		return m.VolumeId
 	}
-	return 0
+	return ""
 }
 
-func (m *PCIAddress) GetDevice() uint32 {
-	if m != nil {
-		return m.Device
+func (m *RaidMember) GetMalloc() *MallocParams {
+	if x, ok := m.GetParams().(*RaidMember_Malloc); ok {
+		return x.Malloc
 	}
-	return 0
+	return nil
 }
 
-func (m *PCIAddress) GetFunction() uint32 {
-	if m != nil {
-		return m.Function
+func (m *RaidMember) GetCeph() *CephParams {
+	if x, ok := m.GetParams().(*RaidMember_Ceph); ok {
+		return x.Ceph
 	}
-	return 0
-}
-
-type SCSIDisk struct {
-	Target uint32 `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
-	Lun    uint32 `protobuf:"varint,2,opt,name=lun,proto3" json:"lun,omitempty"`
+	return nil
 }
 
-func (m *SCSIDisk) Reset()                    { *m = SCSIDisk{} }
-func (m *SCSIDisk) String() string            { return proto.CompactTextString(m) }
-func (*SCSIDisk) ProtoMessage()               {}
-func (*SCSIDisk) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{10} }
-
-func (m *SCSIDisk) GetTarget() uint32 {
-	if m != nil {
-		return m.Target
+func (m *RaidMember) GetLvol() *LVolParams {
+	if x, ok := m.GetParams().(*RaidMember_Lvol); ok {
+		return x.Lvol
 	}
-	return 0
+	return nil
 }
 
-func (m *SCSIDisk) GetLun() uint32 {
-	if m != nil {
-		return m.Lun
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RaidMember) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RaidMember_OneofMarshaler, _RaidMember_OneofUnmarshaler, _RaidMember_OneofSizer, []interface{}{
+		(*RaidMember_Malloc)(nil),
+		(*RaidMember_Ceph)(nil),
+		(*RaidMember_Lvol)(nil),
 	}
-	return 0
-}
-
-type UnmapVolumeRequest struct {
-	// The volume ID that was used when mapping the volume.
-	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
 }
 
-func (m *UnmapVolumeRequest) Reset()                    { *m = UnmapVolumeRequest{} }
-func (m *UnmapVolumeRequest) String() string            { return proto.CompactTextString(m) }
-func (*UnmapVolumeRequest) ProtoMessage()               {}
-func (*UnmapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{11} }
-
-func (m *UnmapVolumeRequest) GetVolumeId() string {
-	if m != nil {
-		return m.VolumeId
+func _RaidMember_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RaidMember)
+	// params
+	switch x := m.Params.(type) {
+	case *RaidMember_Malloc:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Malloc); err != nil {
+			return err
+		}
+	case *RaidMember_Ceph:
+		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ceph); err != nil {
+			return err
+		}
+	case *RaidMember_Lvol:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Lvol); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("RaidMember.Params has unexpected type %T", x)
 	}
-	return ""
+	return nil
 }
 
-type UnmapVolumeReply struct {
+func _RaidMember_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RaidMember)
+	switch tag {
+	case 2: // params.malloc
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MallocParams)
+		err := b.DecodeMessage(msg)
+		m.Params = &RaidMember_Malloc{msg}
+		return true, err
+	case 3: // params.ceph
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CephParams)
+		err := b.DecodeMessage(msg)
+		m.Params = &RaidMember_Ceph{msg}
+		return true, err
+	case 4: // params.lvol
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LVolParams)
+		err := b.DecodeMessage(msg)
+		m.Params = &RaidMember_Lvol{msg}
+		return true, err
+	default:
+		return false, nil
+	}
 }
 
-func (m *UnmapVolumeReply) Reset()                    { *m = UnmapVolumeReply{} }
-func (m *UnmapVolumeReply) String() string            { return proto.CompactTextString(m) }
-func (*UnmapVolumeReply) ProtoMessage()               {}
-func (*UnmapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{12} }
+func _RaidMember_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RaidMember)
+	// params
+	switch x := m.Params.(type) {
+	case *RaidMember_Malloc:
+		s := proto.Size(x.Malloc)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaidMember_Ceph:
+		s := proto.Size(x.Ceph)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RaidMember_Lvol:
+		s := proto.Size(x.Lvol)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
 
-type ProvisionMallocBDevRequest struct {
-	// The desired name of the new BDev.
-	BdevName string `protobuf:"bytes,1,opt,name=bdev_name,json=bdevName,proto3" json:"bdev_name,omitempty"`
-	// The desired size in bytes. Must be a multiple of 512.
-	Size_ int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+// Encrypts all data with AES-CBC in an SPDK crypto BDev which
+// is layered on top of the volume.
+type CryptoParams struct {
+	// The DPDK crypto driver, "crypto_aesni_mb" (the
+	// default) or "crypto_qat".
+	CryptoPmd string `protobuf:"bytes,1,opt,name=crypto_pmd,json=cryptoPmd,proto3" json:"crypto_pmd,omitempty"`
+	// A key with exactly 16 bytes. Never logged.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (m *ProvisionMallocBDevRequest) Reset()                    { *m = ProvisionMallocBDevRequest{} }
-func (m *ProvisionMallocBDevRequest) String() string            { return proto.CompactTextString(m) }
-func (*ProvisionMallocBDevRequest) ProtoMessage()               {}
-func (*ProvisionMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{13} }
+func (m *CryptoParams) Reset()                    { *m = CryptoParams{} }
+func (m *CryptoParams) String() string            { return proto.CompactTextString(m) }
+func (*CryptoParams) ProtoMessage()               {}
+func (*CryptoParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{15} }
 
-func (m *ProvisionMallocBDevRequest) GetBdevName() string {
+func (m *CryptoParams) GetCryptoPmd() string {
 	if m != nil {
-		return m.BdevName
+		return m.CryptoPmd
 	}
 	return ""
 }
 
-func (m *ProvisionMallocBDevRequest) GetSize_() int64 {
+func (m *CryptoParams) GetKey() string {
 	if m != nil {
-		return m.Size_
+		return m.Key
 	}
-	return 0
+	return ""
 }
 
-type ProvisionMallocBDevReply struct {
+// Defines a Ceph block device. When monitors, secret,
+// keyring and config are all empty, SPDK uses the default
+// ceph.conf of the controller host instead.
+type CephParams struct {
+	// The user id (like "admin", but not "client.admin").
+	// Can be left out, the default in Ceph is "admin".
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The "key" value from a Ceph keyring for the user.
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Comma-separated list of addr:port values.
+	Monitors string `protobuf:"bytes,3,opt,name=monitors,proto3" json:"monitors,omitempty"`
+	// Pool name
+	Pool string `protobuf:"bytes,4,opt,name=pool,proto3" json:"pool,omitempty"`
+	// Image name
+	Image string `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	// Additional Ceph configuration options, for example
+	// "rbd_cache". Only options starting with rbd_, rados_
+	// or objecter_ are allowed, except for those that name
+	// files or directories. Anything else, in particular
+	// mon_host, key and keyring, fails with INVALID_ARGUMENT.
+	Config map[string]string `protobuf:"bytes,7,rep,name=config" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Block size of the BDev, must be a multiple of 512.
+	// The default is 512.
+	BlockSize uint32 `protobuf:"varint,8,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	// Name of a keyring file in the directory configured
+	// for the controller, used instead of secret. The key
+	// then never has to leave the controller host.
+	Keyring string `protobuf:"bytes,9,opt,name=keyring,proto3" json:"keyring,omitempty"`
 }
 
-func (m *ProvisionMallocBDevReply) Reset()                    { *m = ProvisionMallocBDevReply{} }
-func (m *ProvisionMallocBDevReply) String() string            { return proto.CompactTextString(m) }
-func (*ProvisionMallocBDevReply) ProtoMessage()               {}
-func (*ProvisionMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{14} }
+func (m *CephParams) Reset()                    { *m = CephParams{} }
+func (m *CephParams) String() string            { return proto.CompactTextString(m) }
+func (*CephParams) ProtoMessage()               {}
+func (*CephParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{16} }
 
-type CheckMallocBDevRequest struct {
-	// The name of an existing BDev.
-	BdevName string `protobuf:"bytes,1,opt,name=bdev_name,json=bdevName,proto3" json:"bdev_name,omitempty"`
+func (m *CephParams) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
 }
 
-func (m *CheckMallocBDevRequest) Reset()                    { *m = CheckMallocBDevRequest{} }
-func (m *CheckMallocBDevRequest) String() string            { return proto.CompactTextString(m) }
-func (*CheckMallocBDevRequest) ProtoMessage()               {}
-func (*CheckMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{15} }
-
-func (m *CheckMallocBDevRequest) GetBdevName() string {
+func (m *CephParams) GetSecret() string {
 	if m != nil {
-		return m.BdevName
+		return m.Secret
 	}
 	return ""
 }
 
-type CheckMallocBDevReply struct {
+func (m *CephParams) GetMonitors() string {
+	if m != nil {
+		return m.Monitors
+	}
+	return ""
 }
 
-func (m *CheckMallocBDevReply) Reset()                    { *m = CheckMallocBDevReply{} }
-func (m *CheckMallocBDevReply) String() string            { return proto.CompactTextString(m) }
-func (*CheckMallocBDevReply) ProtoMessage()               {}
-func (*CheckMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{16} }
-
-func init() {
-	proto.RegisterType((*SetValueRequest)(nil), "oim.v0.SetValueRequest")
-	proto.RegisterType((*Value)(nil), "oim.v0.Value")
-	proto.RegisterType((*SetValueReply)(nil), "oim.v0.SetValueReply")
-	proto.RegisterType((*GetValuesRequest)(nil), "oim.v0.GetValuesRequest")
-	proto.RegisterType((*GetValuesReply)(nil), "oim.v0.GetValuesReply")
-	proto.RegisterType((*MapVolumeRequest)(nil), "oim.v0.MapVolumeRequest")
-	proto.RegisterType((*MallocParams)(nil), "oim.v0.MallocParams")
-	proto.RegisterType((*CephParams)(nil), "oim.v0.CephParams")
-	proto.RegisterType((*MapVolumeReply)(nil), "oim.v0.MapVolumeReply")
-	proto.RegisterType((*PCIAddress)(nil), "oim.v0.PCIAddress")
-	proto.RegisterType((*SCSIDisk)(nil), "oim.v0.SCSIDisk")
-	proto.RegisterType((*UnmapVolumeRequest)(nil), "oim.v0.UnmapVolumeRequest")
-	proto.RegisterType((*UnmapVolumeReply)(nil), "oim.v0.UnmapVolumeReply")
-	proto.RegisterType((*ProvisionMallocBDevRequest)(nil), "oim.v0.ProvisionMallocBDevRequest")
-	proto.RegisterType((*ProvisionMallocBDevReply)(nil), "oim.v0.ProvisionMallocBDevReply")
-	proto.RegisterType((*CheckMallocBDevRequest)(nil), "oim.v0.CheckMallocBDevRequest")
-	proto.RegisterType((*CheckMallocBDevReply)(nil), "oim.v0.CheckMallocBDevReply")
+func (m *CephParams) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *CephParams) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *CephParams) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
 
-// Client API for Registry service
+func (m *CephParams) GetBlockSize() uint32 {
+	if m != nil {
+		return m.BlockSize
+	}
+	return 0
+}
 
-type RegistryClient interface {
-	// Set or overwrite a registry DB entry.
-	SetValue(ctx context.Context, in *SetValueRequest, opts ...grpc.CallOption) (*SetValueReply, error)
-	// Retrieves registry DB entries.
-	GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesReply, error)
+func (m *CephParams) GetKeyring() string {
+	if m != nil {
+		return m.Keyring
+	}
+	return ""
 }
 
-type registryClient struct {
-	cc *grpc.ClientConn
+// The reply must tell the caller enough about the mapped volume
+// to find it in /sys/dev/block.
+type MapVolumeReply struct {
+	// The PCI address (domain/bus/device/function, extended BDF).
+	// A controller which does not know its own PCI address can
+	// return a an address with all fields set to 0xFFFF.
+	PciAddress *PCIAddress `protobuf:"bytes,1,opt,name=pci_address,json=pciAddress" json:"pci_address,omitempty"`
+	// The SCSI target and LUN. Only present for disks attached
+	// via a SCSI controller.
+	ScsiDisk *SCSIDisk `protobuf:"bytes,2,opt,name=scsi_disk,json=scsiDisk" json:"scsi_disk,omitempty"`
+	// Set instead of the other fields for async calls.
+	OperationId string `protobuf:"bytes,3,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 }
 
-func NewRegistryClient(cc *grpc.ClientConn) RegistryClient {
-	return &registryClient{cc}
+func (m *MapVolumeReply) Reset()                    { *m = MapVolumeReply{} }
+func (m *MapVolumeReply) String() string            { return proto.CompactTextString(m) }
+func (*MapVolumeReply) ProtoMessage()               {}
+func (*MapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{17} }
+
+func (m *MapVolumeReply) GetPciAddress() *PCIAddress {
+	if m != nil {
+		return m.PciAddress
+	}
+	return nil
 }
 
-func (c *registryClient) SetValue(ctx context.Context, in *SetValueRequest, opts ...grpc.CallOption) (*SetValueReply, error) {
-	out := new(SetValueReply)
-	err := grpc.Invoke(ctx, "/oim.v0.Registry/SetValue", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *MapVolumeReply) GetScsiDisk() *SCSIDisk {
+	if m != nil {
+		return m.ScsiDisk
 	}
-	return out, nil
+	return nil
 }
 
-func (c *registryClient) GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesReply, error) {
-	out := new(GetValuesReply)
-	err := grpc.Invoke(ctx, "/oim.v0.Registry/GetValues", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *MapVolumeReply) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
 	}
-	return out, nil
+	return ""
 }
 
-// Server API for Registry service
+// Each field can be marked as unknown or unset with 0xFFFF.
+// This leads to nicer code than the other workarounds for missing
+// optional scalars (.google.protobuf.UInt32Value or oneof).
+type PCIAddress struct {
+	// Domain number.
+	Domain uint32 `protobuf:"varint,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// Bus number.
+	Bus uint32 `protobuf:"varint,2,opt,name=bus,proto3" json:"bus,omitempty"`
+	// Device number.
+	Device uint32 `protobuf:"varint,3,opt,name=device,proto3" json:"device,omitempty"`
+	// Function number.
+	Function uint32 `protobuf:"varint,4,opt,name=function,proto3" json:"function,omitempty"`
+}
 
-type RegistryServer interface {
-	// Set or overwrite a registry DB entry.
-	SetValue(context.Context, *SetValueRequest) (*SetValueReply, error)
-	// Retrieves registry DB entries.
-	GetValues(context.Context, *GetValuesRequest) (*GetValuesReply, error)
+func (m *PCIAddress) Reset()                    { *m = PCIAddress{} }
+func (m *PCIAddress) String() string            { return proto.CompactTextString(m) }
+func (*PCIAddress) ProtoMessage()               {}
+func (*PCIAddress) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{18} }
+
+func (m *PCIAddress) GetDomain() uint32 {
+	if m != nil {
+		return m.Domain
+	}
+	return 0
 }
 
-func RegisterRegistryServer(s *grpc.Server, srv RegistryServer) {
-	s.RegisterService(&_Registry_serviceDesc, srv)
+func (m *PCIAddress) GetBus() uint32 {
+	if m != nil {
+		return m.Bus
+	}
+	return 0
 }
 
-func _Registry_SetValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetValueRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(RegistryServer).SetValue(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/oim.v0.Registry/SetValue",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).SetValue(ctx, req.(*SetValueRequest))
+func (m *PCIAddress) GetDevice() uint32 {
+	if m != nil {
+		return m.Device
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Registry_GetValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetValuesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(RegistryServer).GetValues(ctx, in)
+func (m *PCIAddress) GetFunction() uint32 {
+	if m != nil {
+		return m.Function
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/oim.v0.Registry/GetValues",
+	return 0
+}
+
+type SCSIDisk struct {
+	Target uint32 `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
+	Lun    uint32 `protobuf:"varint,2,opt,name=lun,proto3" json:"lun,omitempty"`
+}
+
+func (m *SCSIDisk) Reset()                    { *m = SCSIDisk{} }
+func (m *SCSIDisk) String() string            { return proto.CompactTextString(m) }
+func (*SCSIDisk) ProtoMessage()               {}
+func (*SCSIDisk) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{19} }
+
+func (m *SCSIDisk) GetTarget() uint32 {
+	if m != nil {
+		return m.Target
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).GetValues(ctx, req.(*GetValuesRequest))
+	return 0
+}
+
+func (m *SCSIDisk) GetLun() uint32 {
+	if m != nil {
+		return m.Lun
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _Registry_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "oim.v0.Registry",
-	HandlerType: (*RegistryServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "SetValue",
-			Handler:    _Registry_SetValue_Handler,
-		},
-		{
-			MethodName: "GetValues",
-			Handler:    _Registry_GetValues_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "oim.proto",
+type UnmapVolumeRequest struct {
+	// The volume ID that was used when mapping the volume.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Return without waiting for the operation.
+	Async bool `protobuf:"varint,2,opt,name=async,proto3" json:"async,omitempty"`
 }
 
-// Client API for Controller service
+func (m *UnmapVolumeRequest) Reset()                    { *m = UnmapVolumeRequest{} }
+func (m *UnmapVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnmapVolumeRequest) ProtoMessage()               {}
+func (*UnmapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{20} }
 
-type ControllerClient interface {
-	// Makes a volume available via the accelerator hardware.
-	// The call must be idempotent: when a caller is unsure whether
-	// a call was executed or what the result was, MapVolume
-	// can be called again and will succeed without changing
-	// anything.
-	MapVolume(ctx context.Context, in *MapVolumeRequest, opts ...grpc.CallOption) (*MapVolumeReply, error)
-	// Removes access to the volume.
-	// Also idempotent.
-	UnmapVolume(ctx context.Context, in *UnmapVolumeRequest, opts ...grpc.CallOption) (*UnmapVolumeReply, error)
-	// Creates or deletes (when size is zero) an
-	// in-memory BDev for testing.
-	ProvisionMallocBDev(ctx context.Context, in *ProvisionMallocBDevRequest, opts ...grpc.CallOption) (*ProvisionMallocBDevReply, error)
-	// Checks that the BDev exists. Returns
-	// gRPC NOT_FOUND status if not.
-	CheckMallocBDev(ctx context.Context, in *CheckMallocBDevRequest, opts ...grpc.CallOption) (*CheckMallocBDevReply, error)
+func (m *UnmapVolumeRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
 }
 
-type controllerClient struct {
-	cc *grpc.ClientConn
+func (m *UnmapVolumeRequest) GetAsync() bool {
+	if m != nil {
+		return m.Async
+	}
+	return false
 }
 
-func NewControllerClient(cc *grpc.ClientConn) ControllerClient {
-	return &controllerClient{cc}
+type UnmapVolumeReply struct {
+	// Set for async calls.
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 }
 
-func (c *controllerClient) MapVolume(ctx context.Context, in *MapVolumeRequest, opts ...grpc.CallOption) (*MapVolumeReply, error) {
-	out := new(MapVolumeReply)
-	err := grpc.Invoke(ctx, "/oim.v0.Controller/MapVolume", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *UnmapVolumeReply) Reset()                    { *m = UnmapVolumeReply{} }
+func (m *UnmapVolumeReply) String() string            { return proto.CompactTextString(m) }
+func (*UnmapVolumeReply) ProtoMessage()               {}
+func (*UnmapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{21} }
+
+func (m *UnmapVolumeReply) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *controllerClient) UnmapVolume(ctx context.Context, in *UnmapVolumeRequest, opts ...grpc.CallOption) (*UnmapVolumeReply, error) {
-	out := new(UnmapVolumeReply)
-	err := grpc.Invoke(ctx, "/oim.v0.Controller/UnmapVolume", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type QuiesceVolumeRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// The controller which takes over the volume. The
+	// quiesced controller maps the volume there itself,
+	// through the OIM registry, so secrets never leave
+	// the controllers. Needs self-registration.
+	TargetControllerId string `protobuf:"bytes,2,opt,name=target_controller_id,json=targetControllerId,proto3" json:"target_controller_id,omitempty"`
 }
 
-func (c *controllerClient) ProvisionMallocBDev(ctx context.Context, in *ProvisionMallocBDevRequest, opts ...grpc.CallOption) (*ProvisionMallocBDevReply, error) {
-	out := new(ProvisionMallocBDevReply)
-	err := grpc.Invoke(ctx, "/oim.v0.Controller/ProvisionMallocBDev", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *QuiesceVolumeRequest) Reset()                    { *m = QuiesceVolumeRequest{} }
+func (m *QuiesceVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*QuiesceVolumeRequest) ProtoMessage()               {}
+func (*QuiesceVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{22} }
+
+func (m *QuiesceVolumeRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *controllerClient) CheckMallocBDev(ctx context.Context, in *CheckMallocBDevRequest, opts ...grpc.CallOption) (*CheckMallocBDevReply, error) {
-	out := new(CheckMallocBDevReply)
-	err := grpc.Invoke(ctx, "/oim.v0.Controller/CheckMallocBDev", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *QuiesceVolumeRequest) GetTargetControllerId() string {
+	if m != nil {
+		return m.TargetControllerId
 	}
-	return out, nil
+	return ""
 }
 
-// Server API for Controller service
+type QuiesceVolumeReply struct {
+	// The request which mapped the volume, without Ceph
+	// secrets and crypto keys.
+	MapVolume *MapVolumeRequest `protobuf:"bytes,1,opt,name=map_volume,json=mapVolume" json:"map_volume,omitempty"`
+}
 
-type ControllerServer interface {
-	// Makes a volume available via the accelerator hardware.
-	// The call must be idempotent: when a caller is unsure whether
-	// a call was executed or what the result was, MapVolume
-	// can be called again and will succeed without changing
-	// anything.
-	MapVolume(context.Context, *MapVolumeRequest) (*MapVolumeReply, error)
-	// Removes access to the volume.
-	// Also idempotent.
-	UnmapVolume(context.Context, *UnmapVolumeRequest) (*UnmapVolumeReply, error)
-	// Creates or deletes (when size is zero) an
-	// in-memory BDev for testing.
-	ProvisionMallocBDev(context.Context, *ProvisionMallocBDevRequest) (*ProvisionMallocBDevReply, error)
-	// Checks that the BDev exists. Returns
-	// gRPC NOT_FOUND status if not.
-	CheckMallocBDev(context.Context, *CheckMallocBDevRequest) (*CheckMallocBDevReply, error)
+func (m *QuiesceVolumeReply) Reset()                    { *m = QuiesceVolumeReply{} }
+func (m *QuiesceVolumeReply) String() string            { return proto.CompactTextString(m) }
+func (*QuiesceVolumeReply) ProtoMessage()               {}
+func (*QuiesceVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{23} }
+
+func (m *QuiesceVolumeReply) GetMapVolume() *MapVolumeRequest {
+	if m != nil {
+		return m.MapVolume
+	}
+	return nil
 }
 
-func RegisterControllerServer(s *grpc.Server, srv ControllerServer) {
-	s.RegisterService(&_Controller_serviceDesc, srv)
+type ResumeVolumeRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
 }
 
-func _Controller_MapVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MapVolumeRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControllerServer).MapVolume(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/oim.v0.Controller/MapVolume",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServer).MapVolume(ctx, req.(*MapVolumeRequest))
+func (m *ResumeVolumeRequest) Reset()                    { *m = ResumeVolumeRequest{} }
+func (m *ResumeVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResumeVolumeRequest) ProtoMessage()               {}
+func (*ResumeVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{24} }
+
+func (m *ResumeVolumeRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Controller_UnmapVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnmapVolumeRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControllerServer).UnmapVolume(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/oim.v0.Controller/UnmapVolume",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServer).UnmapVolume(ctx, req.(*UnmapVolumeRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type ResumeVolumeReply struct {
 }
 
-func _Controller_ProvisionMallocBDev_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProvisionMallocBDevRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControllerServer).ProvisionMallocBDev(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/oim.v0.Controller/ProvisionMallocBDev",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServer).ProvisionMallocBDev(ctx, req.(*ProvisionMallocBDevRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *ResumeVolumeReply) Reset()                    { *m = ResumeVolumeReply{} }
+func (m *ResumeVolumeReply) String() string            { return proto.CompactTextString(m) }
+func (*ResumeVolumeReply) ProtoMessage()               {}
+func (*ResumeVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{25} }
+
+type ProvisionMallocBDevRequest struct {
+	// The desired name of the new BDev.
+	BdevName string `protobuf:"bytes,1,opt,name=bdev_name,json=bdevName,proto3" json:"bdev_name,omitempty"`
+	// The desired size in bytes. Must be a multiple of 512.
+	Size_ int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func _Controller_CheckMallocBDev_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckMallocBDevRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ControllerServer).CheckMallocBDev(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/oim.v0.Controller/CheckMallocBDev",
+func (m *ProvisionMallocBDevRequest) Reset()                    { *m = ProvisionMallocBDevRequest{} }
+func (m *ProvisionMallocBDevRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProvisionMallocBDevRequest) ProtoMessage()               {}
+func (*ProvisionMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{26} }
+
+func (m *ProvisionMallocBDevRequest) GetBdevName() string {
+	if m != nil {
+		return m.BdevName
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControllerServer).CheckMallocBDev(ctx, req.(*CheckMallocBDevRequest))
+	return ""
+}
+
+func (m *ProvisionMallocBDevRequest) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _Controller_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "oim.v0.Controller",
-	HandlerType: (*ControllerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "MapVolume",
-			Handler:    _Controller_MapVolume_Handler,
-		},
-		{
-			MethodName: "UnmapVolume",
-			Handler:    _Controller_UnmapVolume_Handler,
-		},
-		{
-			MethodName: "ProvisionMallocBDev",
-			Handler:    _Controller_ProvisionMallocBDev_Handler,
-		},
-		{
-			MethodName: "CheckMallocBDev",
-			Handler:    _Controller_CheckMallocBDev_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "oim.proto",
+type ProvisionMallocBDevReply struct {
 }
 
-func (m *SetValueRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ProvisionMallocBDevReply) Reset()                    { *m = ProvisionMallocBDevReply{} }
+func (m *ProvisionMallocBDevReply) String() string            { return proto.CompactTextString(m) }
+func (*ProvisionMallocBDevReply) ProtoMessage()               {}
+func (*ProvisionMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{27} }
+
+type CheckMallocBDevRequest struct {
+	// The name of an existing BDev.
+	BdevName string `protobuf:"bytes,1,opt,name=bdev_name,json=bdevName,proto3" json:"bdev_name,omitempty"`
 }
 
-func (m *SetValueRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Value != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Value.Size()))
-		n1, err := m.Value.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
+func (m *CheckMallocBDevRequest) Reset()                    { *m = CheckMallocBDevRequest{} }
+func (m *CheckMallocBDevRequest) String() string            { return proto.CompactTextString(m) }
+func (*CheckMallocBDevRequest) ProtoMessage()               {}
+func (*CheckMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{28} }
+
+func (m *CheckMallocBDevRequest) GetBdevName() string {
+	if m != nil {
+		return m.BdevName
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Value) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type CheckMallocBDevReply struct {
 }
 
-func (m *Value) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Path) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
+func (m *CheckMallocBDevReply) Reset()                    { *m = CheckMallocBDevReply{} }
+func (m *CheckMallocBDevReply) String() string            { return proto.CompactTextString(m) }
+func (*CheckMallocBDevReply) ProtoMessage()               {}
+func (*CheckMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{29} }
+
+type ProvisionLVolRequest struct {
+	// The name of an existing lvol store.
+	LvolStore string `protobuf:"bytes,1,opt,name=lvol_store,json=lvolStore,proto3" json:"lvol_store,omitempty"`
+	// The name of the logical volume inside that store.
+	VolumeId string `protobuf:"bytes,2,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// The minimum size in bytes. The actual size is rounded
+	// up to a multiple of the lvol store cluster size.
+	Size_ int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (m *ProvisionLVolRequest) Reset()                    { *m = ProvisionLVolRequest{} }
+func (m *ProvisionLVolRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProvisionLVolRequest) ProtoMessage()               {}
+func (*ProvisionLVolRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{30} }
+
+func (m *ProvisionLVolRequest) GetLvolStore() string {
+	if m != nil {
+		return m.LvolStore
 	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
+	return ""
+}
+
+func (m *ProvisionLVolRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *SetValueReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ProvisionLVolRequest) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SetValueReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type ProvisionLVolReply struct {
+	// The actual size in bytes.
+	Size_ int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (m *GetValuesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ProvisionLVolReply) Reset()                    { *m = ProvisionLVolReply{} }
+func (m *ProvisionLVolReply) String() string            { return proto.CompactTextString(m) }
+func (*ProvisionLVolReply) ProtoMessage()               {}
+func (*ProvisionLVolReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{31} }
+
+func (m *ProvisionLVolReply) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GetValuesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Path) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
-	}
-	return i, nil
+type Snapshot struct {
+	// The name of the snapshot inside the lvol store.
+	SnapshotId string `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// The volume that the snapshot was created from.
+	// Empty if that volume no longer exists.
+	SourceVolumeId string `protobuf:"bytes,2,opt,name=source_volume_id,json=sourceVolumeId,proto3" json:"source_volume_id,omitempty"`
+	// Size in bytes.
+	Size_ int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Nanoseconds since the Unix epoch. SPDK does not
+	// record this, so the controller reports when it
+	// first saw the snapshot if it did not create it
+	// itself.
+	CreationTime int64 `protobuf:"varint,4,opt,name=creation_time,json=creationTime,proto3" json:"creation_time,omitempty"`
 }
 
-func (m *GetValuesReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Snapshot) Reset()                    { *m = Snapshot{} }
+func (m *Snapshot) String() string            { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()               {}
+func (*Snapshot) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{32} }
+
+func (m *Snapshot) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *GetValuesReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Values) > 0 {
-		for _, msg := range m.Values {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+func (m *Snapshot) GetSourceVolumeId() string {
+	if m != nil {
+		return m.SourceVolumeId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *MapVolumeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Snapshot) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *MapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.VolumeId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
-		i += copy(dAtA[i:], m.VolumeId)
-	}
-	if m.Params != nil {
-		nn2, err := m.Params.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += nn2
+func (m *Snapshot) GetCreationTime() int64 {
+	if m != nil {
+		return m.CreationTime
 	}
-	return i, nil
+	return 0
 }
 
-func (m *MapVolumeRequest_Malloc) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Malloc != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Malloc.Size()))
-		n3, err := m.Malloc.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
+type CreateSnapshotRequest struct {
+	// The name of an existing lvol store.
+	LvolStore string `protobuf:"bytes,1,opt,name=lvol_store,json=lvolStore,proto3" json:"lvol_store,omitempty"`
+	// The name of an existing logical volume.
+	SourceVolumeId string `protobuf:"bytes,2,opt,name=source_volume_id,json=sourceVolumeId,proto3" json:"source_volume_id,omitempty"`
+	// The name of the new snapshot.
+	SnapshotId string `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+}
+
+func (m *CreateSnapshotRequest) Reset()                    { *m = CreateSnapshotRequest{} }
+func (m *CreateSnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateSnapshotRequest) ProtoMessage()               {}
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{33} }
+
+func (m *CreateSnapshotRequest) GetLvolStore() string {
+	if m != nil {
+		return m.LvolStore
 	}
-	return i, nil
+	return ""
 }
-func (m *MapVolumeRequest_Ceph) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Ceph != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Ceph.Size()))
-		n4, err := m.Ceph.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
+
+func (m *CreateSnapshotRequest) GetSourceVolumeId() string {
+	if m != nil {
+		return m.SourceVolumeId
 	}
-	return i, nil
+	return ""
 }
-func (m *MallocParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+
+func (m *CreateSnapshotRequest) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *MallocParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type CreateSnapshotReply struct {
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot" json:"snapshot,omitempty"`
 }
 
-func (m *CephParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CreateSnapshotReply) Reset()                    { *m = CreateSnapshotReply{} }
+func (m *CreateSnapshotReply) String() string            { return proto.CompactTextString(m) }
+func (*CreateSnapshotReply) ProtoMessage()               {}
+func (*CreateSnapshotReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{34} }
+
+func (m *CreateSnapshotReply) GetSnapshot() *Snapshot {
+	if m != nil {
+		return m.Snapshot
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *CephParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.UserId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.UserId)))
-		i += copy(dAtA[i:], m.UserId)
-	}
-	if len(m.Secret) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Secret)))
-		i += copy(dAtA[i:], m.Secret)
-	}
-	if len(m.Monitors) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Monitors)))
-		i += copy(dAtA[i:], m.Monitors)
-	}
-	if len(m.Pool) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Pool)))
-		i += copy(dAtA[i:], m.Pool)
-	}
-	if len(m.Image) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Image)))
-		i += copy(dAtA[i:], m.Image)
-	}
-	return i, nil
+type DeleteSnapshotRequest struct {
+	// The name of an existing lvol store.
+	LvolStore string `protobuf:"bytes,1,opt,name=lvol_store,json=lvolStore,proto3" json:"lvol_store,omitempty"`
+	// The snapshot which is to be deleted.
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
 }
 
-func (m *MapVolumeReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DeleteSnapshotRequest) Reset()                    { *m = DeleteSnapshotRequest{} }
+func (m *DeleteSnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSnapshotRequest) ProtoMessage()               {}
+func (*DeleteSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{35} }
+
+func (m *DeleteSnapshotRequest) GetLvolStore() string {
+	if m != nil {
+		return m.LvolStore
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *MapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.PciAddress != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.PciAddress.Size()))
-		n5, err := m.PciAddress.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
-	}
-	if m.ScsiDisk != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.ScsiDisk.Size()))
-		n6, err := m.ScsiDisk.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+func (m *DeleteSnapshotRequest) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *PCIAddress) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type DeleteSnapshotReply struct {
 }
 
-func (m *PCIAddress) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Domain != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Domain))
-	}
-	if m.Bus != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Bus))
-	}
-	if m.Device != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Device))
-	}
-	if m.Function != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Function))
-	}
-	return i, nil
+func (m *DeleteSnapshotReply) Reset()                    { *m = DeleteSnapshotReply{} }
+func (m *DeleteSnapshotReply) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSnapshotReply) ProtoMessage()               {}
+func (*DeleteSnapshotReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{36} }
+
+type ListSnapshotsRequest struct {
+	// The name of an existing lvol store.
+	LvolStore string `protobuf:"bytes,1,opt,name=lvol_store,json=lvolStore,proto3" json:"lvol_store,omitempty"`
+	// Only return the snapshot with this name, if set.
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// Only return snapshots of this volume, if set.
+	SourceVolumeId string `protobuf:"bytes,3,opt,name=source_volume_id,json=sourceVolumeId,proto3" json:"source_volume_id,omitempty"`
 }
 
-func (m *SCSIDisk) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ListSnapshotsRequest) Reset()                    { *m = ListSnapshotsRequest{} }
+func (m *ListSnapshotsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSnapshotsRequest) ProtoMessage()               {}
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{37} }
+
+func (m *ListSnapshotsRequest) GetLvolStore() string {
+	if m != nil {
+		return m.LvolStore
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SCSIDisk) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Target != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Target))
-	}
-	if m.Lun != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Lun))
+func (m *ListSnapshotsRequest) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *UnmapVolumeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ListSnapshotsRequest) GetSourceVolumeId() string {
+	if m != nil {
+		return m.SourceVolumeId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *UnmapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.VolumeId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
-		i += copy(dAtA[i:], m.VolumeId)
-	}
-	return i, nil
+type ListSnapshotsReply struct {
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
 }
 
-func (m *UnmapVolumeReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ListSnapshotsReply) Reset()                    { *m = ListSnapshotsReply{} }
+func (m *ListSnapshotsReply) String() string            { return proto.CompactTextString(m) }
+func (*ListSnapshotsReply) ProtoMessage()               {}
+func (*ListSnapshotsReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{38} }
+
+func (m *ListSnapshotsReply) GetSnapshots() []*Snapshot {
+	if m != nil {
+		return m.Snapshots
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *UnmapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type CloneVolumeRequest struct {
+	// The name of an existing lvol store.
+	LvolStore string `protobuf:"bytes,1,opt,name=lvol_store,json=lvolStore,proto3" json:"lvol_store,omitempty"`
+	// The name of the new logical volume.
+	VolumeId string `protobuf:"bytes,2,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Exactly one of these two must be set.
+	SnapshotId     string `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	SourceVolumeId string `protobuf:"bytes,4,opt,name=source_volume_id,json=sourceVolumeId,proto3" json:"source_volume_id,omitempty"`
+	// The minimum size in bytes. The new volume is grown
+	// if its source is smaller.
+	Size_ int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (m *ProvisionMallocBDevRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CloneVolumeRequest) Reset()                    { *m = CloneVolumeRequest{} }
+func (m *CloneVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloneVolumeRequest) ProtoMessage()               {}
+func (*CloneVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{39} }
+
+func (m *CloneVolumeRequest) GetLvolStore() string {
+	if m != nil {
+		return m.LvolStore
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ProvisionMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.BdevName) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
-		i += copy(dAtA[i:], m.BdevName)
-	}
-	if m.Size_ != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+func (m *CloneVolumeRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *ProvisionMallocBDevReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CloneVolumeRequest) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ProvisionMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+func (m *CloneVolumeRequest) GetSourceVolumeId() string {
+	if m != nil {
+		return m.SourceVolumeId
+	}
+	return ""
 }
 
-func (m *CheckMallocBDevRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CloneVolumeRequest) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *CheckMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.BdevName) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
-		i += copy(dAtA[i:], m.BdevName)
-	}
-	return i, nil
+type CloneVolumeReply struct {
+	// The size of the new volume in bytes.
+	Size_ int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (m *CheckMallocBDevReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CloneVolumeReply) Reset()                    { *m = CloneVolumeReply{} }
+func (m *CloneVolumeReply) String() string            { return proto.CompactTextString(m) }
+func (*CloneVolumeReply) ProtoMessage()               {}
+func (*CloneVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{40} }
+
+func (m *CloneVolumeReply) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *CheckMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type ResizeVolumeRequest struct {
+	// The name of an existing lvol store.
+	LvolStore string `protobuf:"bytes,1,opt,name=lvol_store,json=lvolStore,proto3" json:"lvol_store,omitempty"`
+	// The name of the logical volume.
+	VolumeId string `protobuf:"bytes,2,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// The new minimum size in bytes.
+	Size_ int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func encodeVarintOim(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ResizeVolumeRequest) Reset()                    { *m = ResizeVolumeRequest{} }
+func (m *ResizeVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResizeVolumeRequest) ProtoMessage()               {}
+func (*ResizeVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{41} }
+
+func (m *ResizeVolumeRequest) GetLvolStore() string {
+	if m != nil {
+		return m.LvolStore
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return ""
 }
-func (m *SetValueRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Value != nil {
-		l = m.Value.Size()
-		n += 1 + l + sovOim(uint64(l))
+
+func (m *ResizeVolumeRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return n
+	return ""
 }
 
-func (m *Value) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *ResizeVolumeRequest) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return n
+	return 0
 }
 
-func (m *SetValueReply) Size() (n int) {
-	var l int
-	_ = l
-	return n
+type ResizeVolumeReply struct {
+	// The actual size of the volume in bytes.
+	Size_ int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (m *GetValuesRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *ResizeVolumeReply) Reset()                    { *m = ResizeVolumeReply{} }
+func (m *ResizeVolumeReply) String() string            { return proto.CompactTextString(m) }
+func (*ResizeVolumeReply) ProtoMessage()               {}
+func (*ResizeVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{42} }
+
+func (m *ResizeVolumeReply) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	return n
+	return 0
 }
 
-func (m *GetValuesReply) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Values) > 0 {
-		for _, e := range m.Values {
-			l = e.Size()
-			n += 1 + l + sovOim(uint64(l))
-		}
-	}
-	return n
+type SetVolumeQoSRequest struct {
+	// The volume ID that was used for MapVolume.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// The new limits.
+	Qos *QoS `protobuf:"bytes,2,opt,name=qos" json:"qos,omitempty"`
 }
 
-func (m *MapVolumeRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.VolumeId)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
-	}
-	if m.Params != nil {
-		n += m.Params.Size()
+func (m *SetVolumeQoSRequest) Reset()                    { *m = SetVolumeQoSRequest{} }
+func (m *SetVolumeQoSRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetVolumeQoSRequest) ProtoMessage()               {}
+func (*SetVolumeQoSRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{43} }
+
+func (m *SetVolumeQoSRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return n
+	return ""
 }
 
-func (m *MapVolumeRequest_Malloc) Size() (n int) {
-	var l int
-	_ = l
-	if m.Malloc != nil {
-		l = m.Malloc.Size()
-		n += 1 + l + sovOim(uint64(l))
+func (m *SetVolumeQoSRequest) GetQos() *QoS {
+	if m != nil {
+		return m.Qos
 	}
-	return n
+	return nil
 }
-func (m *MapVolumeRequest_Ceph) Size() (n int) {
-	var l int
-	_ = l
-	if m.Ceph != nil {
-		l = m.Ceph.Size()
-		n += 1 + l + sovOim(uint64(l))
+
+type SetVolumeQoSReply struct {
+}
+
+func (m *SetVolumeQoSReply) Reset()                    { *m = SetVolumeQoSReply{} }
+func (m *SetVolumeQoSReply) String() string            { return proto.CompactTextString(m) }
+func (*SetVolumeQoSReply) ProtoMessage()               {}
+func (*SetVolumeQoSReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{44} }
+
+// Something in SPDK which was created by the OIM controller
+// but is no longer needed.
+type Orphan struct {
+	Kind Orphan_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=oim.v0.Orphan_Kind" json:"kind,omitempty"`
+	// BDev name resp. VHost SCSI controller name.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The SCSI target number, only set for targets.
+	ScsiTarget uint32 `protobuf:"varint,3,opt,name=scsi_target,json=scsiTarget,proto3" json:"scsi_target,omitempty"`
+	// A human-readable explanation.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// When the orphan was found for the first time,
+	// in nanoseconds since the Unix epoch.
+	FirstSeen int64 `protobuf:"varint,5,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+}
+
+func (m *Orphan) Reset()                    { *m = Orphan{} }
+func (m *Orphan) String() string            { return proto.CompactTextString(m) }
+func (*Orphan) ProtoMessage()               {}
+func (*Orphan) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{45} }
+
+func (m *Orphan) GetKind() Orphan_Kind {
+	if m != nil {
+		return m.Kind
 	}
-	return n
+	return Orphan_UNKNOWN
 }
-func (m *MallocParams) Size() (n int) {
-	var l int
-	_ = l
-	return n
+
+func (m *Orphan) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func (m *CephParams) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *Orphan) GetScsiTarget() uint32 {
+	if m != nil {
+		return m.ScsiTarget
 	}
-	l = len(m.Secret)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	return 0
+}
+
+func (m *Orphan) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	l = len(m.Monitors)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	return ""
+}
+
+func (m *Orphan) GetFirstSeen() int64 {
+	if m != nil {
+		return m.FirstSeen
 	}
-	l = len(m.Pool)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	return 0
+}
+
+type ListOrphansRequest struct {
+}
+
+func (m *ListOrphansRequest) Reset()                    { *m = ListOrphansRequest{} }
+func (m *ListOrphansRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListOrphansRequest) ProtoMessage()               {}
+func (*ListOrphansRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{46} }
+
+type ListOrphansReply struct {
+	Orphans []*Orphan `protobuf:"bytes,1,rep,name=orphans" json:"orphans,omitempty"`
+}
+
+func (m *ListOrphansReply) Reset()                    { *m = ListOrphansReply{} }
+func (m *ListOrphansReply) String() string            { return proto.CompactTextString(m) }
+func (*ListOrphansReply) ProtoMessage()               {}
+func (*ListOrphansReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{47} }
+
+func (m *ListOrphansReply) GetOrphans() []*Orphan {
+	if m != nil {
+		return m.Orphans
 	}
-	l = len(m.Image)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	return nil
+}
+
+type CollectGarbageRequest struct {
+	// Also remove orphans which were found only recently.
+	Force bool `protobuf:"varint,1,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (m *CollectGarbageRequest) Reset()                    { *m = CollectGarbageRequest{} }
+func (m *CollectGarbageRequest) String() string            { return proto.CompactTextString(m) }
+func (*CollectGarbageRequest) ProtoMessage()               {}
+func (*CollectGarbageRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{48} }
+
+func (m *CollectGarbageRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
 	}
-	return n
+	return false
 }
 
-func (m *MapVolumeReply) Size() (n int) {
-	var l int
-	_ = l
-	if m.PciAddress != nil {
-		l = m.PciAddress.Size()
-		n += 1 + l + sovOim(uint64(l))
+type CollectGarbageReply struct {
+	// The orphans that were removed.
+	Removed []*Orphan `protobuf:"bytes,1,rep,name=removed" json:"removed,omitempty"`
+}
+
+func (m *CollectGarbageReply) Reset()                    { *m = CollectGarbageReply{} }
+func (m *CollectGarbageReply) String() string            { return proto.CompactTextString(m) }
+func (*CollectGarbageReply) ProtoMessage()               {}
+func (*CollectGarbageReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{49} }
+
+func (m *CollectGarbageReply) GetRemoved() []*Orphan {
+	if m != nil {
+		return m.Removed
 	}
-	if m.ScsiDisk != nil {
-		l = m.ScsiDisk.Size()
-		n += 1 + l + sovOim(uint64(l))
+	return nil
+}
+
+// A volume as seen by SPDK.
+type Volume struct {
+	// The volume ID as used in MapVolume.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// The name of the BDev which stores the data. Same as
+	// the volume ID except for logical volumes.
+	BdevName string `protobuf:"bytes,2,opt,name=bdev_name,json=bdevName,proto3" json:"bdev_name,omitempty"`
+	// The SPDK product name of that BDev, for example
+	// "Malloc disk", "Ceph Rbd Disk" or "Logical Volume".
+	BackingType string `protobuf:"bytes,3,opt,name=backing_type,json=backingType,proto3" json:"backing_type,omitempty"`
+	// Size in bytes.
+	Size_ int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// True if the volume is mapped through a crypto BDev.
+	Encrypted bool `protobuf:"varint,5,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	// True if the BDev is in use by some other BDev,
+	// for example as base of an lvol store.
+	Claimed bool `protobuf:"varint,6,opt,name=claimed,proto3" json:"claimed,omitempty"`
+	// PCI address and SCSI disk, only set while the
+	// volume is mapped.
+	PciAddress *PCIAddress `protobuf:"bytes,7,opt,name=pci_address,json=pciAddress" json:"pci_address,omitempty"`
+	ScsiDisk   *SCSIDisk   `protobuf:"bytes,8,opt,name=scsi_disk,json=scsiDisk" json:"scsi_disk,omitempty"`
+}
+
+func (m *Volume) Reset()                    { *m = Volume{} }
+func (m *Volume) String() string            { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()               {}
+func (*Volume) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{50} }
+
+func (m *Volume) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return n
+	return ""
 }
 
-func (m *PCIAddress) Size() (n int) {
-	var l int
-	_ = l
-	if m.Domain != 0 {
-		n += 1 + sovOim(uint64(m.Domain))
+func (m *Volume) GetBdevName() string {
+	if m != nil {
+		return m.BdevName
 	}
-	if m.Bus != 0 {
-		n += 1 + sovOim(uint64(m.Bus))
+	return ""
+}
+
+func (m *Volume) GetBackingType() string {
+	if m != nil {
+		return m.BackingType
 	}
-	if m.Device != 0 {
-		n += 1 + sovOim(uint64(m.Device))
+	return ""
+}
+
+func (m *Volume) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
 	}
-	if m.Function != 0 {
-		n += 1 + sovOim(uint64(m.Function))
+	return 0
+}
+
+func (m *Volume) GetEncrypted() bool {
+	if m != nil {
+		return m.Encrypted
 	}
-	return n
+	return false
 }
 
-func (m *SCSIDisk) Size() (n int) {
-	var l int
-	_ = l
-	if m.Target != 0 {
-		n += 1 + sovOim(uint64(m.Target))
+func (m *Volume) GetClaimed() bool {
+	if m != nil {
+		return m.Claimed
 	}
-	if m.Lun != 0 {
-		n += 1 + sovOim(uint64(m.Lun))
+	return false
+}
+
+func (m *Volume) GetPciAddress() *PCIAddress {
+	if m != nil {
+		return m.PciAddress
 	}
-	return n
+	return nil
 }
 
-func (m *UnmapVolumeRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.VolumeId)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *Volume) GetScsiDisk() *SCSIDisk {
+	if m != nil {
+		return m.ScsiDisk
 	}
-	return n
+	return nil
 }
 
-func (m *UnmapVolumeReply) Size() (n int) {
-	var l int
-	_ = l
-	return n
+type ListVolumesRequest struct {
 }
 
-func (m *ProvisionMallocBDevRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.BdevName)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *ListVolumesRequest) Reset()                    { *m = ListVolumesRequest{} }
+func (m *ListVolumesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListVolumesRequest) ProtoMessage()               {}
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{51} }
+
+type ListVolumesReply struct {
+	// Sorted by volume ID.
+	Volumes []*Volume `protobuf:"bytes,1,rep,name=volumes" json:"volumes,omitempty"`
+}
+
+func (m *ListVolumesReply) Reset()                    { *m = ListVolumesReply{} }
+func (m *ListVolumesReply) String() string            { return proto.CompactTextString(m) }
+func (*ListVolumesReply) ProtoMessage()               {}
+func (*ListVolumesReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{52} }
+
+func (m *ListVolumesReply) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
 	}
-	if m.Size_ != 0 {
-		n += 1 + sovOim(uint64(m.Size_))
+	return nil
+}
+
+type GetVolumeRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+}
+
+func (m *GetVolumeRequest) Reset()                    { *m = GetVolumeRequest{} }
+func (m *GetVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetVolumeRequest) ProtoMessage()               {}
+func (*GetVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{53} }
+
+func (m *GetVolumeRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
 	}
-	return n
+	return ""
 }
 
-func (m *ProvisionMallocBDevReply) Size() (n int) {
-	var l int
-	_ = l
-	return n
+type GetVolumeReply struct {
+	Volume *Volume `protobuf:"bytes,1,opt,name=volume" json:"volume,omitempty"`
 }
 
-func (m *CheckMallocBDevRequest) Size() (n int) {
-	var l int
-	_ = l
+func (m *GetVolumeReply) Reset()                    { *m = GetVolumeReply{} }
+func (m *GetVolumeReply) String() string            { return proto.CompactTextString(m) }
+func (*GetVolumeReply) ProtoMessage()               {}
+func (*GetVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{54} }
+
+func (m *GetVolumeReply) GetVolume() *Volume {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type GetVolumeStatsRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+}
+
+func (m *GetVolumeStatsRequest) Reset()                    { *m = GetVolumeStatsRequest{} }
+func (m *GetVolumeStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetVolumeStatsRequest) ProtoMessage()               {}
+func (*GetVolumeStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{55} }
+
+func (m *GetVolumeStatsRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+// Counters since the BDev was created. For encrypted volumes,
+// these are the counters of the crypto BDev.
+type GetVolumeStatsReply struct {
+	ReadOps    uint64 `protobuf:"varint,1,opt,name=read_ops,json=readOps,proto3" json:"read_ops,omitempty"`
+	ReadBytes  uint64 `protobuf:"varint,2,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteOps   uint64 `protobuf:"varint,3,opt,name=write_ops,json=writeOps,proto3" json:"write_ops,omitempty"`
+	WriteBytes uint64 `protobuf:"varint,4,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	UnmapOps   uint64 `protobuf:"varint,5,opt,name=unmap_ops,json=unmapOps,proto3" json:"unmap_ops,omitempty"`
+	UnmapBytes uint64 `protobuf:"varint,6,opt,name=unmap_bytes,json=unmapBytes,proto3" json:"unmap_bytes,omitempty"`
+	// Total time spent in operations of each kind,
+	// in nanoseconds. Divide by the number of operations
+	// to get the average latency.
+	ReadLatency  uint64 `protobuf:"varint,7,opt,name=read_latency,json=readLatency,proto3" json:"read_latency,omitempty"`
+	WriteLatency uint64 `protobuf:"varint,8,opt,name=write_latency,json=writeLatency,proto3" json:"write_latency,omitempty"`
+	UnmapLatency uint64 `protobuf:"varint,9,opt,name=unmap_latency,json=unmapLatency,proto3" json:"unmap_latency,omitempty"`
+}
+
+func (m *GetVolumeStatsReply) Reset()                    { *m = GetVolumeStatsReply{} }
+func (m *GetVolumeStatsReply) String() string            { return proto.CompactTextString(m) }
+func (*GetVolumeStatsReply) ProtoMessage()               {}
+func (*GetVolumeStatsReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{56} }
+
+func (m *GetVolumeStatsReply) GetReadOps() uint64 {
+	if m != nil {
+		return m.ReadOps
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetReadBytes() uint64 {
+	if m != nil {
+		return m.ReadBytes
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetWriteOps() uint64 {
+	if m != nil {
+		return m.WriteOps
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetWriteBytes() uint64 {
+	if m != nil {
+		return m.WriteBytes
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetUnmapOps() uint64 {
+	if m != nil {
+		return m.UnmapOps
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetUnmapBytes() uint64 {
+	if m != nil {
+		return m.UnmapBytes
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetReadLatency() uint64 {
+	if m != nil {
+		return m.ReadLatency
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetWriteLatency() uint64 {
+	if m != nil {
+		return m.WriteLatency
+	}
+	return 0
+}
+
+func (m *GetVolumeStatsReply) GetUnmapLatency() uint64 {
+	if m != nil {
+		return m.UnmapLatency
+	}
+	return 0
+}
+
+type GetOperationRequest struct {
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Wait until the operation is done or the call's
+	// deadline is reached, whatever comes first.
+	Wait bool `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (m *GetOperationRequest) Reset()                    { *m = GetOperationRequest{} }
+func (m *GetOperationRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetOperationRequest) ProtoMessage()               {}
+func (*GetOperationRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{57} }
+
+func (m *GetOperationRequest) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func (m *GetOperationRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+type GetOperationReply struct {
+	Operation *Operation `protobuf:"bytes,1,opt,name=operation" json:"operation,omitempty"`
+}
+
+func (m *GetOperationReply) Reset()                    { *m = GetOperationReply{} }
+func (m *GetOperationReply) String() string            { return proto.CompactTextString(m) }
+func (*GetOperationReply) ProtoMessage()               {}
+func (*GetOperationReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{58} }
+
+func (m *GetOperationReply) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+// A MapVolume or UnmapVolume call.
+type Operation struct {
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// "MapVolume" or "UnmapVolume".
+	Method   string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	VolumeId string `protobuf:"bytes,3,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	Done     bool   `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	// The gRPC status code and message of a failed
+	// operation, zero and empty otherwise.
+	ErrorCode    int32  `protobuf:"varint,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage string `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// The result of a successful operation.
+	//
+	// Types that are valid to be assigned to Result:
+	//	*Operation_MapVolume
+	//	*Operation_UnmapVolume
+	Result isOperation_Result `protobuf_oneof:"result"`
+}
+
+func (m *Operation) Reset()                    { *m = Operation{} }
+func (m *Operation) String() string            { return proto.CompactTextString(m) }
+func (*Operation) ProtoMessage()               {}
+func (*Operation) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{59} }
+
+type isOperation_Result interface {
+	isOperation_Result()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Operation_MapVolume struct {
+	MapVolume *MapVolumeReply `protobuf:"bytes,7,opt,name=map_volume,json=mapVolume,oneof"`
+}
+type Operation_UnmapVolume struct {
+	UnmapVolume *UnmapVolumeReply `protobuf:"bytes,8,opt,name=unmap_volume,json=unmapVolume,oneof"`
+}
+
+func (*Operation_MapVolume) isOperation_Result()   {}
+func (*Operation_UnmapVolume) isOperation_Result() {}
+
+func (m *Operation) GetResult() isOperation_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *Operation) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func (m *Operation) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *Operation) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *Operation) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+func (m *Operation) GetErrorCode() int32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *Operation) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *Operation) GetMapVolume() *MapVolumeReply {
+	if x, ok := m.GetResult().(*Operation_MapVolume); ok {
+		return x.MapVolume
+	}
+	return nil
+}
+
+func (m *Operation) GetUnmapVolume() *UnmapVolumeReply {
+	if x, ok := m.GetResult().(*Operation_UnmapVolume); ok {
+		return x.UnmapVolume
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Operation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Operation_OneofMarshaler, _Operation_OneofUnmarshaler, _Operation_OneofSizer, []interface{}{
+		(*Operation_MapVolume)(nil),
+		(*Operation_UnmapVolume)(nil),
+	}
+}
+
+func _Operation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Operation)
+	// result
+	switch x := m.Result.(type) {
+	case *Operation_MapVolume:
+		_ = b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MapVolume); err != nil {
+			return err
+		}
+	case *Operation_UnmapVolume:
+		_ = b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.UnmapVolume); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Operation.Result has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Operation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Operation)
+	switch tag {
+	case 7: // result.map_volume
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MapVolumeReply)
+		err := b.DecodeMessage(msg)
+		m.Result = &Operation_MapVolume{msg}
+		return true, err
+	case 8: // result.unmap_volume
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(UnmapVolumeReply)
+		err := b.DecodeMessage(msg)
+		m.Result = &Operation_UnmapVolume{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Operation_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Operation)
+	// result
+	switch x := m.Result.(type) {
+	case *Operation_MapVolume:
+		s := proto.Size(x.MapVolume)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Operation_UnmapVolume:
+		s := proto.Size(x.UnmapVolume)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+func init() {
+	proto.RegisterType((*SetValueRequest)(nil), "oim.v0.SetValueRequest")
+	proto.RegisterType((*Value)(nil), "oim.v0.Value")
+	proto.RegisterType((*SetValueReply)(nil), "oim.v0.SetValueReply")
+	proto.RegisterType((*GetValuesRequest)(nil), "oim.v0.GetValuesRequest")
+	proto.RegisterType((*GetValuesReply)(nil), "oim.v0.GetValuesReply")
+	proto.RegisterType((*AcquireLeaseRequest)(nil), "oim.v0.AcquireLeaseRequest")
+	proto.RegisterType((*AcquireLeaseReply)(nil), "oim.v0.AcquireLeaseReply")
+	proto.RegisterType((*ReleaseLeaseRequest)(nil), "oim.v0.ReleaseLeaseRequest")
+	proto.RegisterType((*ReleaseLeaseReply)(nil), "oim.v0.ReleaseLeaseReply")
+	proto.RegisterType((*MapVolumeRequest)(nil), "oim.v0.MapVolumeRequest")
+	proto.RegisterType((*QoS)(nil), "oim.v0.QoS")
+	proto.RegisterType((*MallocParams)(nil), "oim.v0.MallocParams")
+	proto.RegisterType((*LVolParams)(nil), "oim.v0.LVolParams")
+	proto.RegisterType((*RaidParams)(nil), "oim.v0.RaidParams")
+	proto.RegisterType((*RaidMember)(nil), "oim.v0.RaidMember")
+	proto.RegisterType((*CryptoParams)(nil), "oim.v0.CryptoParams")
+	proto.RegisterType((*CephParams)(nil), "oim.v0.CephParams")
+	proto.RegisterType((*MapVolumeReply)(nil), "oim.v0.MapVolumeReply")
+	proto.RegisterType((*PCIAddress)(nil), "oim.v0.PCIAddress")
+	proto.RegisterType((*SCSIDisk)(nil), "oim.v0.SCSIDisk")
+	proto.RegisterType((*UnmapVolumeRequest)(nil), "oim.v0.UnmapVolumeRequest")
+	proto.RegisterType((*UnmapVolumeReply)(nil), "oim.v0.UnmapVolumeReply")
+	proto.RegisterType((*QuiesceVolumeRequest)(nil), "oim.v0.QuiesceVolumeRequest")
+	proto.RegisterType((*QuiesceVolumeReply)(nil), "oim.v0.QuiesceVolumeReply")
+	proto.RegisterType((*ResumeVolumeRequest)(nil), "oim.v0.ResumeVolumeRequest")
+	proto.RegisterType((*ResumeVolumeReply)(nil), "oim.v0.ResumeVolumeReply")
+	proto.RegisterType((*ProvisionMallocBDevRequest)(nil), "oim.v0.ProvisionMallocBDevRequest")
+	proto.RegisterType((*ProvisionMallocBDevReply)(nil), "oim.v0.ProvisionMallocBDevReply")
+	proto.RegisterType((*CheckMallocBDevRequest)(nil), "oim.v0.CheckMallocBDevRequest")
+	proto.RegisterType((*CheckMallocBDevReply)(nil), "oim.v0.CheckMallocBDevReply")
+	proto.RegisterType((*ProvisionLVolRequest)(nil), "oim.v0.ProvisionLVolRequest")
+	proto.RegisterType((*ProvisionLVolReply)(nil), "oim.v0.ProvisionLVolReply")
+	proto.RegisterType((*Snapshot)(nil), "oim.v0.Snapshot")
+	proto.RegisterType((*CreateSnapshotRequest)(nil), "oim.v0.CreateSnapshotRequest")
+	proto.RegisterType((*CreateSnapshotReply)(nil), "oim.v0.CreateSnapshotReply")
+	proto.RegisterType((*DeleteSnapshotRequest)(nil), "oim.v0.DeleteSnapshotRequest")
+	proto.RegisterType((*DeleteSnapshotReply)(nil), "oim.v0.DeleteSnapshotReply")
+	proto.RegisterType((*ListSnapshotsRequest)(nil), "oim.v0.ListSnapshotsRequest")
+	proto.RegisterType((*ListSnapshotsReply)(nil), "oim.v0.ListSnapshotsReply")
+	proto.RegisterType((*CloneVolumeRequest)(nil), "oim.v0.CloneVolumeRequest")
+	proto.RegisterType((*CloneVolumeReply)(nil), "oim.v0.CloneVolumeReply")
+	proto.RegisterType((*ResizeVolumeRequest)(nil), "oim.v0.ResizeVolumeRequest")
+	proto.RegisterType((*ResizeVolumeReply)(nil), "oim.v0.ResizeVolumeReply")
+	proto.RegisterType((*SetVolumeQoSRequest)(nil), "oim.v0.SetVolumeQoSRequest")
+	proto.RegisterType((*SetVolumeQoSReply)(nil), "oim.v0.SetVolumeQoSReply")
+	proto.RegisterType((*Orphan)(nil), "oim.v0.Orphan")
+	proto.RegisterType((*ListOrphansRequest)(nil), "oim.v0.ListOrphansRequest")
+	proto.RegisterType((*ListOrphansReply)(nil), "oim.v0.ListOrphansReply")
+	proto.RegisterType((*CollectGarbageRequest)(nil), "oim.v0.CollectGarbageRequest")
+	proto.RegisterType((*CollectGarbageReply)(nil), "oim.v0.CollectGarbageReply")
+	proto.RegisterType((*Volume)(nil), "oim.v0.Volume")
+	proto.RegisterType((*ListVolumesRequest)(nil), "oim.v0.ListVolumesRequest")
+	proto.RegisterType((*ListVolumesReply)(nil), "oim.v0.ListVolumesReply")
+	proto.RegisterType((*GetVolumeRequest)(nil), "oim.v0.GetVolumeRequest")
+	proto.RegisterType((*GetVolumeReply)(nil), "oim.v0.GetVolumeReply")
+	proto.RegisterType((*GetVolumeStatsRequest)(nil), "oim.v0.GetVolumeStatsRequest")
+	proto.RegisterType((*GetVolumeStatsReply)(nil), "oim.v0.GetVolumeStatsReply")
+	proto.RegisterType((*GetOperationRequest)(nil), "oim.v0.GetOperationRequest")
+	proto.RegisterType((*GetOperationReply)(nil), "oim.v0.GetOperationReply")
+	proto.RegisterType((*Operation)(nil), "oim.v0.Operation")
+	proto.RegisterEnum("oim.v0.RaidParams_Level", RaidParams_Level_name, RaidParams_Level_value)
+	proto.RegisterEnum("oim.v0.Orphan_Kind", Orphan_Kind_name, Orphan_Kind_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Registry service
+
+type RegistryClient interface {
+	// Set or overwrite a registry DB entry.
+	SetValue(ctx context.Context, in *SetValueRequest, opts ...grpc.CallOption) (*SetValueReply, error)
+	// Retrieves registry DB entries.
+	GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesReply, error)
+	// Grants the calling OIM controller exclusive or, for
+	// hosts which only read, shared access to a volume. Fails
+	// with FAILED_PRECONDITION while another controller holds
+	// a conflicting lease, unless forced. Acquiring a lease
+	// again is not an error.
+	AcquireLease(ctx context.Context, in *AcquireLeaseRequest, opts ...grpc.CallOption) (*AcquireLeaseReply, error)
+	// Gives up a lease held by the calling controller. The
+	// admin may release any lease, including all shared ones.
+	// A lease held by some other controller or by nobody is
+	// left unchanged, which is not an error.
+	ReleaseLease(ctx context.Context, in *ReleaseLeaseRequest, opts ...grpc.CallOption) (*ReleaseLeaseReply, error)
+}
+
+type registryClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRegistryClient(cc *grpc.ClientConn) RegistryClient {
+	return &registryClient{cc}
+}
+
+func (c *registryClient) SetValue(ctx context.Context, in *SetValueRequest, opts ...grpc.CallOption) (*SetValueReply, error) {
+	out := new(SetValueReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/SetValue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesReply, error) {
+	out := new(GetValuesReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/GetValues", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) AcquireLease(ctx context.Context, in *AcquireLeaseRequest, opts ...grpc.CallOption) (*AcquireLeaseReply, error) {
+	out := new(AcquireLeaseReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/AcquireLease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ReleaseLease(ctx context.Context, in *ReleaseLeaseRequest, opts ...grpc.CallOption) (*ReleaseLeaseReply, error) {
+	out := new(ReleaseLeaseReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/ReleaseLease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Registry service
+
+type RegistryServer interface {
+	// Set or overwrite a registry DB entry.
+	SetValue(context.Context, *SetValueRequest) (*SetValueReply, error)
+	// Retrieves registry DB entries.
+	GetValues(context.Context, *GetValuesRequest) (*GetValuesReply, error)
+	// Grants the calling OIM controller exclusive or, for
+	// hosts which only read, shared access to a volume. Fails
+	// with FAILED_PRECONDITION while another controller holds
+	// a conflicting lease, unless forced. Acquiring a lease
+	// again is not an error.
+	AcquireLease(context.Context, *AcquireLeaseRequest) (*AcquireLeaseReply, error)
+	// Gives up a lease held by the calling controller. The
+	// admin may release any lease, including all shared ones.
+	// A lease held by some other controller or by nobody is
+	// left unchanged, which is not an error.
+	ReleaseLease(context.Context, *ReleaseLeaseRequest) (*ReleaseLeaseReply, error)
+}
+
+func RegisterRegistryServer(s *grpc.Server, srv RegistryServer) {
+	s.RegisterService(&_Registry_serviceDesc, srv)
+}
+
+func _Registry_SetValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).SetValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/SetValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).SetValue(ctx, req.(*SetValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_GetValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).GetValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/GetValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).GetValues(ctx, req.(*GetValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_AcquireLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).AcquireLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/AcquireLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).AcquireLease(ctx, req.(*AcquireLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ReleaseLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ReleaseLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/ReleaseLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ReleaseLease(ctx, req.(*ReleaseLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Registry_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "oim.v0.Registry",
+	HandlerType: (*RegistryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetValue",
+			Handler:    _Registry_SetValue_Handler,
+		},
+		{
+			MethodName: "GetValues",
+			Handler:    _Registry_GetValues_Handler,
+		},
+		{
+			MethodName: "AcquireLease",
+			Handler:    _Registry_AcquireLease_Handler,
+		},
+		{
+			MethodName: "ReleaseLease",
+			Handler:    _Registry_ReleaseLease_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "oim.proto",
+}
+
+// Client API for Controller service
+
+type ControllerClient interface {
+	// Makes a volume available via the accelerator hardware.
+	// The call must be idempotent: when a caller is unsure whether
+	// a call was executed or what the result was, MapVolume
+	// can be called again and will succeed without changing
+	// anything.
+	//
+	// MapVolume and UnmapVolume run as operations which
+	// continue when the caller gives up waiting. Calling
+	// again for the same volume with the same parameters
+	// while the operation is still running waits for that
+	// operation instead of starting a new one. Different
+	// parameters fail with ABORTED. With async set, the
+	// reply only contains the operation ID.
+	MapVolume(ctx context.Context, in *MapVolumeRequest, opts ...grpc.CallOption) (*MapVolumeReply, error)
+	// Removes access to the volume.
+	// Also idempotent.
+	UnmapVolume(ctx context.Context, in *UnmapVolumeRequest, opts ...grpc.CallOption) (*UnmapVolumeReply, error)
+	// Returns the state of an operation started by
+	// MapVolume or UnmapVolume. Returns gRPC NOT_FOUND
+	// status for unknown operations. Completed operations
+	// are forgotten after a while.
+	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationReply, error)
+	// Hands over a mapped volume to a different
+	// controller: waits for pending operations, rejects
+	// further MapVolume calls for the volume with gRPC
+	// FAILED_PRECONDITION and then removes it like
+	// UnmapVolume, which also releases its lease. Returns
+	// gRPC NOT_FOUND status if the controller has no
+	// record of mapping the volume.
+	//
+	// Removing the volume does not flush anything on the
+	// host, so the host must have stopped using the
+	// volume, for example by unmounting the file system.
+	// The controller checks that there is no I/O for a
+	// while and otherwise fails with FAILED_PRECONDITION
+	// without changing anything.
+	//
+	// With a target controller, the volume then gets
+	// mapped there with the original request. If that
+	// fails, the volume is mapped here again and no longer
+	// quiesced.
+	QuiesceVolume(ctx context.Context, in *QuiesceVolumeRequest, opts ...grpc.CallOption) (*QuiesceVolumeReply, error)
+	// Accepts MapVolume calls for a quiesced volume again,
+	// for example after a failed handover. Idempotent.
+	ResumeVolume(ctx context.Context, in *ResumeVolumeRequest, opts ...grpc.CallOption) (*ResumeVolumeReply, error)
+	// Creates or deletes (when size is zero) an
+	// in-memory BDev for testing.
+	ProvisionMallocBDev(ctx context.Context, in *ProvisionMallocBDevRequest, opts ...grpc.CallOption) (*ProvisionMallocBDevReply, error)
+	// Checks that the BDev exists. Returns
+	// gRPC NOT_FOUND status if not.
+	CheckMallocBDev(ctx context.Context, in *CheckMallocBDevRequest, opts ...grpc.CallOption) (*CheckMallocBDevReply, error)
+	// Creates or deletes (when size is zero) a logical
+	// volume inside an existing lvol store. Idempotent.
+	ProvisionLVol(ctx context.Context, in *ProvisionLVolRequest, opts ...grpc.CallOption) (*ProvisionLVolReply, error)
+	// Creates a read-only snapshot of a logical volume.
+	// Idempotent as long as the snapshot is of the same
+	// volume.
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotReply, error)
+	// Deletes a snapshot. Succeeds when the snapshot
+	// does not exist (anymore).
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotReply, error)
+	// Lists snapshots in an lvol store, optionally
+	// filtered by snapshot or source volume.
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsReply, error)
+	// Creates a new logical volume with the content of
+	// a snapshot or of another logical volume. The new
+	// volume is independent of its source.
+	CloneVolume(ctx context.Context, in *CloneVolumeRequest, opts ...grpc.CallOption) (*CloneVolumeReply, error)
+	// Grows a logical volume, also while it is mapped.
+	// Succeeds without changes when the volume already
+	// has at least the requested size. Shrinking is not
+	// supported.
+	ResizeVolume(ctx context.Context, in *ResizeVolumeRequest, opts ...grpc.CallOption) (*ResizeVolumeReply, error)
+	// Changes the QoS limits of a mapped volume. Replaces
+	// all limits set earlier.
+	SetVolumeQoS(ctx context.Context, in *SetVolumeQoSRequest, opts ...grpc.CallOption) (*SetVolumeQoSReply, error)
+	// Lists BDevs and SCSI targets which were left
+	// behind by incomplete MapVolume or UnmapVolume calls.
+	ListOrphans(ctx context.Context, in *ListOrphansRequest, opts ...grpc.CallOption) (*ListOrphansReply, error)
+	// Removes orphans which were found at least one grace
+	// period ago.
+	CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*CollectGarbageReply, error)
+	// Lists all volumes known to SPDK, mapped or not.
+	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesReply, error)
+	// Returns information about one volume. Returns
+	// gRPC NOT_FOUND status if there is no such volume.
+	GetVolume(ctx context.Context, in *GetVolumeRequest, opts ...grpc.CallOption) (*GetVolumeReply, error)
+	// Returns I/O statistics of a volume as seen by SPDK.
+	// Returns gRPC NOT_FOUND status if there is no such
+	// volume.
+	GetVolumeStats(ctx context.Context, in *GetVolumeStatsRequest, opts ...grpc.CallOption) (*GetVolumeStatsReply, error)
+}
+
+type controllerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewControllerClient(cc *grpc.ClientConn) ControllerClient {
+	return &controllerClient{cc}
+}
+
+func (c *controllerClient) MapVolume(ctx context.Context, in *MapVolumeRequest, opts ...grpc.CallOption) (*MapVolumeReply, error) {
+	out := new(MapVolumeReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/MapVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) UnmapVolume(ctx context.Context, in *UnmapVolumeRequest, opts ...grpc.CallOption) (*UnmapVolumeReply, error) {
+	out := new(UnmapVolumeReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/UnmapVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationReply, error) {
+	out := new(GetOperationReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/GetOperation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) QuiesceVolume(ctx context.Context, in *QuiesceVolumeRequest, opts ...grpc.CallOption) (*QuiesceVolumeReply, error) {
+	out := new(QuiesceVolumeReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/QuiesceVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ResumeVolume(ctx context.Context, in *ResumeVolumeRequest, opts ...grpc.CallOption) (*ResumeVolumeReply, error) {
+	out := new(ResumeVolumeReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ResumeVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ProvisionMallocBDev(ctx context.Context, in *ProvisionMallocBDevRequest, opts ...grpc.CallOption) (*ProvisionMallocBDevReply, error) {
+	out := new(ProvisionMallocBDevReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ProvisionMallocBDev", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) CheckMallocBDev(ctx context.Context, in *CheckMallocBDevRequest, opts ...grpc.CallOption) (*CheckMallocBDevReply, error) {
+	out := new(CheckMallocBDevReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/CheckMallocBDev", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ProvisionLVol(ctx context.Context, in *ProvisionLVolRequest, opts ...grpc.CallOption) (*ProvisionLVolReply, error) {
+	out := new(ProvisionLVolReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ProvisionLVol", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotReply, error) {
+	out := new(CreateSnapshotReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/CreateSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotReply, error) {
+	out := new(DeleteSnapshotReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/DeleteSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsReply, error) {
+	out := new(ListSnapshotsReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ListSnapshots", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) CloneVolume(ctx context.Context, in *CloneVolumeRequest, opts ...grpc.CallOption) (*CloneVolumeReply, error) {
+	out := new(CloneVolumeReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/CloneVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ResizeVolume(ctx context.Context, in *ResizeVolumeRequest, opts ...grpc.CallOption) (*ResizeVolumeReply, error) {
+	out := new(ResizeVolumeReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ResizeVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) SetVolumeQoS(ctx context.Context, in *SetVolumeQoSRequest, opts ...grpc.CallOption) (*SetVolumeQoSReply, error) {
+	out := new(SetVolumeQoSReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/SetVolumeQoS", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ListOrphans(ctx context.Context, in *ListOrphansRequest, opts ...grpc.CallOption) (*ListOrphansReply, error) {
+	out := new(ListOrphansReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ListOrphans", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*CollectGarbageReply, error) {
+	out := new(CollectGarbageReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/CollectGarbage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesReply, error) {
+	out := new(ListVolumesReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ListVolumes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) GetVolume(ctx context.Context, in *GetVolumeRequest, opts ...grpc.CallOption) (*GetVolumeReply, error) {
+	out := new(GetVolumeReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/GetVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) GetVolumeStats(ctx context.Context, in *GetVolumeStatsRequest, opts ...grpc.CallOption) (*GetVolumeStatsReply, error) {
+	out := new(GetVolumeStatsReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/GetVolumeStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Controller service
+
+type ControllerServer interface {
+	// Makes a volume available via the accelerator hardware.
+	// The call must be idempotent: when a caller is unsure whether
+	// a call was executed or what the result was, MapVolume
+	// can be called again and will succeed without changing
+	// anything.
+	//
+	// MapVolume and UnmapVolume run as operations which
+	// continue when the caller gives up waiting. Calling
+	// again for the same volume with the same parameters
+	// while the operation is still running waits for that
+	// operation instead of starting a new one. Different
+	// parameters fail with ABORTED. With async set, the
+	// reply only contains the operation ID.
+	MapVolume(context.Context, *MapVolumeRequest) (*MapVolumeReply, error)
+	// Removes access to the volume.
+	// Also idempotent.
+	UnmapVolume(context.Context, *UnmapVolumeRequest) (*UnmapVolumeReply, error)
+	// Returns the state of an operation started by
+	// MapVolume or UnmapVolume. Returns gRPC NOT_FOUND
+	// status for unknown operations. Completed operations
+	// are forgotten after a while.
+	GetOperation(context.Context, *GetOperationRequest) (*GetOperationReply, error)
+	// Hands over a mapped volume to a different
+	// controller: waits for pending operations, rejects
+	// further MapVolume calls for the volume with gRPC
+	// FAILED_PRECONDITION and then removes it like
+	// UnmapVolume, which also releases its lease. Returns
+	// gRPC NOT_FOUND status if the controller has no
+	// record of mapping the volume.
+	//
+	// Removing the volume does not flush anything on the
+	// host, so the host must have stopped using the
+	// volume, for example by unmounting the file system.
+	// The controller checks that there is no I/O for a
+	// while and otherwise fails with FAILED_PRECONDITION
+	// without changing anything.
+	//
+	// With a target controller, the volume then gets
+	// mapped there with the original request. If that
+	// fails, the volume is mapped here again and no longer
+	// quiesced.
+	QuiesceVolume(context.Context, *QuiesceVolumeRequest) (*QuiesceVolumeReply, error)
+	// Accepts MapVolume calls for a quiesced volume again,
+	// for example after a failed handover. Idempotent.
+	ResumeVolume(context.Context, *ResumeVolumeRequest) (*ResumeVolumeReply, error)
+	// Creates or deletes (when size is zero) an
+	// in-memory BDev for testing.
+	ProvisionMallocBDev(context.Context, *ProvisionMallocBDevRequest) (*ProvisionMallocBDevReply, error)
+	// Checks that the BDev exists. Returns
+	// gRPC NOT_FOUND status if not.
+	CheckMallocBDev(context.Context, *CheckMallocBDevRequest) (*CheckMallocBDevReply, error)
+	// Creates or deletes (when size is zero) a logical
+	// volume inside an existing lvol store. Idempotent.
+	ProvisionLVol(context.Context, *ProvisionLVolRequest) (*ProvisionLVolReply, error)
+	// Creates a read-only snapshot of a logical volume.
+	// Idempotent as long as the snapshot is of the same
+	// volume.
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotReply, error)
+	// Deletes a snapshot. Succeeds when the snapshot
+	// does not exist (anymore).
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotReply, error)
+	// Lists snapshots in an lvol store, optionally
+	// filtered by snapshot or source volume.
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsReply, error)
+	// Creates a new logical volume with the content of
+	// a snapshot or of another logical volume. The new
+	// volume is independent of its source.
+	CloneVolume(context.Context, *CloneVolumeRequest) (*CloneVolumeReply, error)
+	// Grows a logical volume, also while it is mapped.
+	// Succeeds without changes when the volume already
+	// has at least the requested size. Shrinking is not
+	// supported.
+	ResizeVolume(context.Context, *ResizeVolumeRequest) (*ResizeVolumeReply, error)
+	// Changes the QoS limits of a mapped volume. Replaces
+	// all limits set earlier.
+	SetVolumeQoS(context.Context, *SetVolumeQoSRequest) (*SetVolumeQoSReply, error)
+	// Lists BDevs and SCSI targets which were left
+	// behind by incomplete MapVolume or UnmapVolume calls.
+	ListOrphans(context.Context, *ListOrphansRequest) (*ListOrphansReply, error)
+	// Removes orphans which were found at least one grace
+	// period ago.
+	CollectGarbage(context.Context, *CollectGarbageRequest) (*CollectGarbageReply, error)
+	// Lists all volumes known to SPDK, mapped or not.
+	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesReply, error)
+	// Returns information about one volume. Returns
+	// gRPC NOT_FOUND status if there is no such volume.
+	GetVolume(context.Context, *GetVolumeRequest) (*GetVolumeReply, error)
+	// Returns I/O statistics of a volume as seen by SPDK.
+	// Returns gRPC NOT_FOUND status if there is no such
+	// volume.
+	GetVolumeStats(context.Context, *GetVolumeStatsRequest) (*GetVolumeStatsReply, error)
+}
+
+func RegisterControllerServer(s *grpc.Server, srv ControllerServer) {
+	s.RegisterService(&_Controller_serviceDesc, srv)
+}
+
+func _Controller_MapVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).MapVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/MapVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).MapVolume(ctx, req.(*MapVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_UnmapVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmapVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).UnmapVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/UnmapVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).UnmapVolume(ctx, req.(*UnmapVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/GetOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).GetOperation(ctx, req.(*GetOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_QuiesceVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuiesceVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).QuiesceVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/QuiesceVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).QuiesceVolume(ctx, req.(*QuiesceVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ResumeVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ResumeVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ResumeVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ResumeVolume(ctx, req.(*ResumeVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ProvisionMallocBDev_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionMallocBDevRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ProvisionMallocBDev(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ProvisionMallocBDev",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ProvisionMallocBDev(ctx, req.(*ProvisionMallocBDevRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_CheckMallocBDev_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckMallocBDevRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).CheckMallocBDev(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/CheckMallocBDev",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).CheckMallocBDev(ctx, req.(*CheckMallocBDevRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ProvisionLVol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionLVolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ProvisionLVol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ProvisionLVol",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ProvisionLVol(ctx, req.(*ProvisionLVolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/CreateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).DeleteSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/DeleteSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).DeleteSnapshot(ctx, req.(*DeleteSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ListSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_CloneVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).CloneVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/CloneVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).CloneVolume(ctx, req.(*CloneVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ResizeVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ResizeVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ResizeVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ResizeVolume(ctx, req.(*ResizeVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_SetVolumeQoS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVolumeQoSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).SetVolumeQoS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/SetVolumeQoS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).SetVolumeQoS(ctx, req.(*SetVolumeQoSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ListOrphans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrphansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ListOrphans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ListOrphans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ListOrphans(ctx, req.(*ListOrphansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_CollectGarbage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectGarbageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).CollectGarbage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/CollectGarbage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).CollectGarbage(ctx, req.(*CollectGarbageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ListVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ListVolumes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ListVolumes(ctx, req.(*ListVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_GetVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).GetVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/GetVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).GetVolume(ctx, req.(*GetVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_GetVolumeStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).GetVolumeStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/GetVolumeStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).GetVolumeStats(ctx, req.(*GetVolumeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Controller_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "oim.v0.Controller",
+	HandlerType: (*ControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MapVolume",
+			Handler:    _Controller_MapVolume_Handler,
+		},
+		{
+			MethodName: "UnmapVolume",
+			Handler:    _Controller_UnmapVolume_Handler,
+		},
+		{
+			MethodName: "GetOperation",
+			Handler:    _Controller_GetOperation_Handler,
+		},
+		{
+			MethodName: "QuiesceVolume",
+			Handler:    _Controller_QuiesceVolume_Handler,
+		},
+		{
+			MethodName: "ResumeVolume",
+			Handler:    _Controller_ResumeVolume_Handler,
+		},
+		{
+			MethodName: "ProvisionMallocBDev",
+			Handler:    _Controller_ProvisionMallocBDev_Handler,
+		},
+		{
+			MethodName: "CheckMallocBDev",
+			Handler:    _Controller_CheckMallocBDev_Handler,
+		},
+		{
+			MethodName: "ProvisionLVol",
+			Handler:    _Controller_ProvisionLVol_Handler,
+		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _Controller_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "DeleteSnapshot",
+			Handler:    _Controller_DeleteSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _Controller_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "CloneVolume",
+			Handler:    _Controller_CloneVolume_Handler,
+		},
+		{
+			MethodName: "ResizeVolume",
+			Handler:    _Controller_ResizeVolume_Handler,
+		},
+		{
+			MethodName: "SetVolumeQoS",
+			Handler:    _Controller_SetVolumeQoS_Handler,
+		},
+		{
+			MethodName: "ListOrphans",
+			Handler:    _Controller_ListOrphans_Handler,
+		},
+		{
+			MethodName: "CollectGarbage",
+			Handler:    _Controller_CollectGarbage_Handler,
+		},
+		{
+			MethodName: "ListVolumes",
+			Handler:    _Controller_ListVolumes_Handler,
+		},
+		{
+			MethodName: "GetVolume",
+			Handler:    _Controller_GetVolume_Handler,
+		},
+		{
+			MethodName: "GetVolumeStats",
+			Handler:    _Controller_GetVolumeStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "oim.proto",
+}
+
+func (m *SetValueRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetValueRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Value.Size()))
+		n1, err := m.Value.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	return i, nil
+}
+
+func (m *Value) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Value) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *SetValueReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetValueReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetValuesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetValuesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	return i, nil
+}
+
+func (m *GetValuesReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetValuesReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, msg := range m.Values {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *AcquireLeaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AcquireLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Force {
+		dAtA[i] = 0x10
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Shared {
+		dAtA[i] = 0x18
+		i++
+		if m.Shared {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *AcquireLeaseReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AcquireLeaseReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.PreviousHolder) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.PreviousHolder)))
+		i += copy(dAtA[i:], m.PreviousHolder)
+	}
+	if len(m.PreviousReaders) > 0 {
+		for _, s := range m.PreviousReaders {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *ReleaseLeaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	return i, nil
+}
+
+func (m *ReleaseLeaseReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseLeaseReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *MapVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Params != nil {
+		nn2, err := m.Params.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn2
+	}
+	if m.Qos != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Qos.Size()))
+		n3, err := m.Qos.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Crypto != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Crypto.Size()))
+		n4, err := m.Crypto.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.Force {
+		dAtA[i] = 0x40
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Async {
+		dAtA[i] = 0x48
+		i++
+		if m.Async {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Reader {
+		dAtA[i] = 0x58
+		i++
+		if m.Reader {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *MapVolumeRequest_Malloc) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Malloc != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Malloc.Size()))
+		n5, err := m.Malloc.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	return i, nil
+}
+func (m *MapVolumeRequest_Ceph) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Ceph != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Ceph.Size()))
+		n6, err := m.Ceph.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+func (m *MapVolumeRequest_Lvol) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Lvol != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Lvol.Size()))
+		n7, err := m.Lvol.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	return i, nil
+}
+func (m *MapVolumeRequest_Raid) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Raid != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Raid.Size()))
+		n8, err := m.Raid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	return i, nil
+}
+func (m *QoS) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QoS) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RwIosPerSec != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.RwIosPerSec))
+	}
+	if m.RwMbytesPerSec != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.RwMbytesPerSec))
+	}
+	if m.RMbytesPerSec != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.RMbytesPerSec))
+	}
+	if m.WMbytesPerSec != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.WMbytesPerSec))
+	}
+	return i, nil
+}
+
+func (m *MallocParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MallocParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *LVolParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LVolParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.LvolStore) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.LvolStore)))
+		i += copy(dAtA[i:], m.LvolStore)
+	}
+	return i, nil
+}
+
+func (m *RaidParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaidParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Level != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Level))
+	}
+	if m.StripSizeKb != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.StripSizeKb))
+	}
+	if len(m.Members) > 0 {
+		for _, msg := range m.Members {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *RaidMember) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaidMember) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Params != nil {
+		nn9, err := m.Params.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn9
+	}
+	return i, nil
+}
+
+func (m *RaidMember_Malloc) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Malloc != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Malloc.Size()))
+		n10, err := m.Malloc.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	return i, nil
+}
+func (m *RaidMember_Ceph) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Ceph != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Ceph.Size()))
+		n11, err := m.Ceph.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	return i, nil
+}
+func (m *RaidMember_Lvol) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Lvol != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Lvol.Size()))
+		n12, err := m.Lvol.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	return i, nil
+}
+func (m *CryptoParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CryptoParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.CryptoPmd) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.CryptoPmd)))
+		i += copy(dAtA[i:], m.CryptoPmd)
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	return i, nil
+}
+
+func (m *CephParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CephParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UserId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.UserId)))
+		i += copy(dAtA[i:], m.UserId)
+	}
+	if len(m.Secret) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Secret)))
+		i += copy(dAtA[i:], m.Secret)
+	}
+	if len(m.Monitors) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Monitors)))
+		i += copy(dAtA[i:], m.Monitors)
+	}
+	if len(m.Pool) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Pool)))
+		i += copy(dAtA[i:], m.Pool)
+	}
+	if len(m.Image) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Image)))
+		i += copy(dAtA[i:], m.Image)
+	}
+	if len(m.Config) > 0 {
+		for k, _ := range m.Config {
+			dAtA[i] = 0x3a
+			i++
+			v := m.Config[k]
+			mapSize := 1 + len(k) + sovOim(uint64(len(k))) + 1 + len(v) + sovOim(uint64(len(v)))
+			i = encodeVarintOim(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.BlockSize != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.BlockSize))
+	}
+	if len(m.Keyring) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Keyring)))
+		i += copy(dAtA[i:], m.Keyring)
+	}
+	return i, nil
+}
+
+func (m *MapVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.PciAddress != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.PciAddress.Size()))
+		n13, err := m.PciAddress.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.ScsiDisk != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ScsiDisk.Size()))
+		n14, err := m.ScsiDisk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if len(m.OperationId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.OperationId)))
+		i += copy(dAtA[i:], m.OperationId)
+	}
+	return i, nil
+}
+
+func (m *PCIAddress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PCIAddress) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Domain != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Domain))
+	}
+	if m.Bus != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Bus))
+	}
+	if m.Device != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Device))
+	}
+	if m.Function != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Function))
+	}
+	return i, nil
+}
+
+func (m *SCSIDisk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SCSIDisk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Target != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Target))
+	}
+	if m.Lun != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Lun))
+	}
+	return i, nil
+}
+
+func (m *UnmapVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnmapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Async {
+		dAtA[i] = 0x10
+		i++
+		if m.Async {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *UnmapVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnmapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OperationId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.OperationId)))
+		i += copy(dAtA[i:], m.OperationId)
+	}
+	return i, nil
+}
+
+func (m *QuiesceVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuiesceVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if len(m.TargetControllerId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.TargetControllerId)))
+		i += copy(dAtA[i:], m.TargetControllerId)
+	}
+	return i, nil
+}
+
+func (m *QuiesceVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuiesceVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MapVolume != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.MapVolume.Size()))
+		n15, err := m.MapVolume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	return i, nil
+}
+
+func (m *ResumeVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResumeVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	return i, nil
+}
+
+func (m *ResumeVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResumeVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ProvisionMallocBDevRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvisionMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.BdevName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
+		i += copy(dAtA[i:], m.BdevName)
+	}
+	if m.Size_ != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *ProvisionMallocBDevReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvisionMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *CheckMallocBDevRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.BdevName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
+		i += copy(dAtA[i:], m.BdevName)
+	}
+	return i, nil
+}
+
+func (m *CheckMallocBDevReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ProvisionLVolRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvisionLVolRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.LvolStore) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.LvolStore)))
+		i += copy(dAtA[i:], m.LvolStore)
+	}
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Size_ != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *ProvisionLVolReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvisionLVolReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *Snapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Snapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.SnapshotId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SnapshotId)))
+		i += copy(dAtA[i:], m.SnapshotId)
+	}
+	if len(m.SourceVolumeId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SourceVolumeId)))
+		i += copy(dAtA[i:], m.SourceVolumeId)
+	}
+	if m.Size_ != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	if m.CreationTime != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.CreationTime))
+	}
+	return i, nil
+}
+
+func (m *CreateSnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateSnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.LvolStore) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.LvolStore)))
+		i += copy(dAtA[i:], m.LvolStore)
+	}
+	if len(m.SourceVolumeId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SourceVolumeId)))
+		i += copy(dAtA[i:], m.SourceVolumeId)
+	}
+	if len(m.SnapshotId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SnapshotId)))
+		i += copy(dAtA[i:], m.SnapshotId)
+	}
+	return i, nil
+}
+
+func (m *CreateSnapshotReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateSnapshotReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Snapshot.Size()))
+		n16, err := m.Snapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	return i, nil
+}
+
+func (m *DeleteSnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteSnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.LvolStore) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.LvolStore)))
+		i += copy(dAtA[i:], m.LvolStore)
+	}
+	if len(m.SnapshotId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SnapshotId)))
+		i += copy(dAtA[i:], m.SnapshotId)
+	}
+	return i, nil
+}
+
+func (m *DeleteSnapshotReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteSnapshotReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ListSnapshotsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSnapshotsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.LvolStore) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.LvolStore)))
+		i += copy(dAtA[i:], m.LvolStore)
+	}
+	if len(m.SnapshotId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SnapshotId)))
+		i += copy(dAtA[i:], m.SnapshotId)
+	}
+	if len(m.SourceVolumeId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SourceVolumeId)))
+		i += copy(dAtA[i:], m.SourceVolumeId)
+	}
+	return i, nil
+}
+
+func (m *ListSnapshotsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSnapshotsReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Snapshots) > 0 {
+		for _, msg := range m.Snapshots {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *CloneVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CloneVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.LvolStore) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.LvolStore)))
+		i += copy(dAtA[i:], m.LvolStore)
+	}
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if len(m.SnapshotId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SnapshotId)))
+		i += copy(dAtA[i:], m.SnapshotId)
+	}
+	if len(m.SourceVolumeId) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.SourceVolumeId)))
+		i += copy(dAtA[i:], m.SourceVolumeId)
+	}
+	if m.Size_ != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *CloneVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CloneVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *ResizeVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResizeVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.LvolStore) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.LvolStore)))
+		i += copy(dAtA[i:], m.LvolStore)
+	}
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Size_ != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *ResizeVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResizeVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *SetVolumeQoSRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetVolumeQoSRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Qos != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Qos.Size()))
+		n17, err := m.Qos.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	return i, nil
+}
+
+func (m *SetVolumeQoSReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetVolumeQoSReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *Orphan) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Orphan) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Kind != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Kind))
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.ScsiTarget != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ScsiTarget))
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.FirstSeen != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.FirstSeen))
+	}
+	return i, nil
+}
+
+func (m *ListOrphansRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListOrphansRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ListOrphansReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListOrphansReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Orphans) > 0 {
+		for _, msg := range m.Orphans {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *CollectGarbageRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CollectGarbageRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Force {
+		dAtA[i] = 0x8
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *CollectGarbageReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CollectGarbageReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Removed) > 0 {
+		for _, msg := range m.Removed {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Volume) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Volume) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if len(m.BdevName) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
+		i += copy(dAtA[i:], m.BdevName)
+	}
+	if len(m.BackingType) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BackingType)))
+		i += copy(dAtA[i:], m.BackingType)
+	}
+	if m.Size_ != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	if m.Encrypted {
+		dAtA[i] = 0x28
+		i++
+		if m.Encrypted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Claimed {
+		dAtA[i] = 0x30
+		i++
+		if m.Claimed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.PciAddress != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.PciAddress.Size()))
+		n18, err := m.PciAddress.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.ScsiDisk != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ScsiDisk.Size()))
+		n19, err := m.ScsiDisk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	return i, nil
+}
+
+func (m *ListVolumesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListVolumesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ListVolumesReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListVolumesReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Volumes) > 0 {
+		for _, msg := range m.Volumes {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *GetVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	return i, nil
+}
+
+func (m *GetVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Volume != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Volume.Size()))
+		n20, err := m.Volume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	return i, nil
+}
+
+func (m *GetVolumeStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVolumeStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	return i, nil
+}
+
+func (m *GetVolumeStatsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVolumeStatsReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ReadOps != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ReadOps))
+	}
+	if m.ReadBytes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ReadBytes))
+	}
+	if m.WriteOps != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.WriteOps))
+	}
+	if m.WriteBytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.WriteBytes))
+	}
+	if m.UnmapOps != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.UnmapOps))
+	}
+	if m.UnmapBytes != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.UnmapBytes))
+	}
+	if m.ReadLatency != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ReadLatency))
+	}
+	if m.WriteLatency != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.WriteLatency))
+	}
+	if m.UnmapLatency != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.UnmapLatency))
+	}
+	return i, nil
+}
+
+func (m *GetOperationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperationRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OperationId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.OperationId)))
+		i += copy(dAtA[i:], m.OperationId)
+	}
+	if m.Wait {
+		dAtA[i] = 0x10
+		i++
+		if m.Wait {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *GetOperationReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperationReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Operation != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Operation.Size()))
+		n21, err := m.Operation.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	return i, nil
+}
+
+func (m *Operation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Operation) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OperationId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.OperationId)))
+		i += copy(dAtA[i:], m.OperationId)
+	}
+	if len(m.Method) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Method)))
+		i += copy(dAtA[i:], m.Method)
+	}
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Done {
+		dAtA[i] = 0x20
+		i++
+		if m.Done {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.ErrorCode != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ErrorCode))
+	}
+	if len(m.ErrorMessage) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.ErrorMessage)))
+		i += copy(dAtA[i:], m.ErrorMessage)
+	}
+	if m.Result != nil {
+		nn22, err := m.Result.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn22
+	}
+	return i, nil
+}
+
+func (m *Operation_MapVolume) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.MapVolume != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.MapVolume.Size()))
+		n23, err := m.MapVolume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	return i, nil
+}
+func (m *Operation_UnmapVolume) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.UnmapVolume != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.UnmapVolume.Size()))
+		n24, err := m.UnmapVolume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	return i, nil
+}
+func encodeVarintOim(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *SetValueRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *Value) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *SetValueReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetValuesRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *GetValuesReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, e := range m.Values {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AcquireLeaseRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.Shared {
+		n += 2
+	}
+	return n
+}
+
+func (m *AcquireLeaseReply) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.PreviousHolder)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if len(m.PreviousReaders) > 0 {
+		for _, s := range m.PreviousReaders {
+			l = len(s)
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReleaseLeaseRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *ReleaseLeaseReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *MapVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Params != nil {
+		n += m.Params.Size()
+	}
+	if m.Qos != nil {
+		l = m.Qos.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Crypto != nil {
+		l = m.Crypto.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.Async {
+		n += 2
+	}
+	if m.Reader {
+		n += 2
+	}
+	return n
+}
+
+func (m *MapVolumeRequest_Malloc) Size() (n int) {
+	var l int
+	_ = l
+	if m.Malloc != nil {
+		l = m.Malloc.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *MapVolumeRequest_Ceph) Size() (n int) {
+	var l int
+	_ = l
+	if m.Ceph != nil {
+		l = m.Ceph.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *MapVolumeRequest_Lvol) Size() (n int) {
+	var l int
+	_ = l
+	if m.Lvol != nil {
+		l = m.Lvol.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *MapVolumeRequest_Raid) Size() (n int) {
+	var l int
+	_ = l
+	if m.Raid != nil {
+		l = m.Raid.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *QoS) Size() (n int) {
+	var l int
+	_ = l
+	if m.RwIosPerSec != 0 {
+		n += 1 + sovOim(uint64(m.RwIosPerSec))
+	}
+	if m.RwMbytesPerSec != 0 {
+		n += 1 + sovOim(uint64(m.RwMbytesPerSec))
+	}
+	if m.RMbytesPerSec != 0 {
+		n += 1 + sovOim(uint64(m.RMbytesPerSec))
+	}
+	if m.WMbytesPerSec != 0 {
+		n += 1 + sovOim(uint64(m.WMbytesPerSec))
+	}
+	return n
+}
+
+func (m *MallocParams) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *LVolParams) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.LvolStore)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *RaidParams) Size() (n int) {
+	var l int
+	_ = l
+	if m.Level != 0 {
+		n += 1 + sovOim(uint64(m.Level))
+	}
+	if m.StripSizeKb != 0 {
+		n += 1 + sovOim(uint64(m.StripSizeKb))
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RaidMember) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Params != nil {
+		n += m.Params.Size()
+	}
+	return n
+}
+
+func (m *RaidMember_Malloc) Size() (n int) {
+	var l int
+	_ = l
+	if m.Malloc != nil {
+		l = m.Malloc.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *RaidMember_Ceph) Size() (n int) {
+	var l int
+	_ = l
+	if m.Ceph != nil {
+		l = m.Ceph.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *RaidMember_Lvol) Size() (n int) {
+	var l int
+	_ = l
+	if m.Lvol != nil {
+		l = m.Lvol.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *CryptoParams) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.CryptoPmd)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *CephParams) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Monitors)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if len(m.Config) > 0 {
+		for k, v := range m.Config {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovOim(uint64(len(k))) + 1 + len(v) + sovOim(uint64(len(v)))
+			n += mapEntrySize + 1 + sovOim(uint64(mapEntrySize))
+		}
+	}
+	if m.BlockSize != 0 {
+		n += 1 + sovOim(uint64(m.BlockSize))
+	}
+	l = len(m.Keyring)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *MapVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.PciAddress != nil {
+		l = m.PciAddress.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.ScsiDisk != nil {
+		l = m.ScsiDisk.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.OperationId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *PCIAddress) Size() (n int) {
+	var l int
+	_ = l
+	if m.Domain != 0 {
+		n += 1 + sovOim(uint64(m.Domain))
+	}
+	if m.Bus != 0 {
+		n += 1 + sovOim(uint64(m.Bus))
+	}
+	if m.Device != 0 {
+		n += 1 + sovOim(uint64(m.Device))
+	}
+	if m.Function != 0 {
+		n += 1 + sovOim(uint64(m.Function))
+	}
+	return n
+}
+
+func (m *SCSIDisk) Size() (n int) {
+	var l int
+	_ = l
+	if m.Target != 0 {
+		n += 1 + sovOim(uint64(m.Target))
+	}
+	if m.Lun != 0 {
+		n += 1 + sovOim(uint64(m.Lun))
+	}
+	return n
+}
+
+func (m *UnmapVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Async {
+		n += 2
+	}
+	return n
+}
+
+func (m *UnmapVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.OperationId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *QuiesceVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.TargetControllerId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *QuiesceVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.MapVolume != nil {
+		l = m.MapVolume.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *ResumeVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *ResumeVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ProvisionMallocBDevRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.BdevName)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *ProvisionMallocBDevReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CheckMallocBDevRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.BdevName)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *CheckMallocBDevReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ProvisionLVolRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.LvolStore)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *ProvisionLVolReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *Snapshot) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.SnapshotId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SourceVolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	if m.CreationTime != 0 {
+		n += 1 + sovOim(uint64(m.CreationTime))
+	}
+	return n
+}
+
+func (m *CreateSnapshotRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.LvolStore)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SourceVolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SnapshotId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateSnapshotReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteSnapshotRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.LvolStore)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SnapshotId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteSnapshotReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListSnapshotsRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.LvolStore)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SnapshotId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SourceVolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *ListSnapshotsReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Snapshots) > 0 {
+		for _, e := range m.Snapshots {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CloneVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.LvolStore)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SnapshotId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.SourceVolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *CloneVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *ResizeVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.LvolStore)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *ResizeVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *SetVolumeQoSRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Qos != nil {
+		l = m.Qos.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *SetVolumeQoSReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *Orphan) Size() (n int) {
+	var l int
+	_ = l
+	if m.Kind != 0 {
+		n += 1 + sovOim(uint64(m.Kind))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.ScsiTarget != 0 {
+		n += 1 + sovOim(uint64(m.ScsiTarget))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.FirstSeen != 0 {
+		n += 1 + sovOim(uint64(m.FirstSeen))
+	}
+	return n
+}
+
+func (m *ListOrphansRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListOrphansReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Orphans) > 0 {
+		for _, e := range m.Orphans {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CollectGarbageRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Force {
+		n += 2
+	}
+	return n
+}
+
+func (m *CollectGarbageReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Removed) > 0 {
+		for _, e := range m.Removed {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Volume) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
 	l = len(m.BdevName)
 	if l > 0 {
 		n += 1 + l + sovOim(uint64(l))
 	}
-	return n
-}
-
-func (m *CheckMallocBDevReply) Size() (n int) {
-	var l int
-	_ = l
-	return n
-}
+	l = len(m.BackingType)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	if m.Encrypted {
+		n += 2
+	}
+	if m.Claimed {
+		n += 2
+	}
+	if m.PciAddress != nil {
+		l = m.PciAddress.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.ScsiDisk != nil {
+		l = m.ScsiDisk.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *ListVolumesRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListVolumesReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Volumes) > 0 {
+		for _, e := range m.Volumes {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *GetVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Volume != nil {
+		l = m.Volume.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *GetVolumeStatsRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *GetVolumeStatsReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.ReadOps != 0 {
+		n += 1 + sovOim(uint64(m.ReadOps))
+	}
+	if m.ReadBytes != 0 {
+		n += 1 + sovOim(uint64(m.ReadBytes))
+	}
+	if m.WriteOps != 0 {
+		n += 1 + sovOim(uint64(m.WriteOps))
+	}
+	if m.WriteBytes != 0 {
+		n += 1 + sovOim(uint64(m.WriteBytes))
+	}
+	if m.UnmapOps != 0 {
+		n += 1 + sovOim(uint64(m.UnmapOps))
+	}
+	if m.UnmapBytes != 0 {
+		n += 1 + sovOim(uint64(m.UnmapBytes))
+	}
+	if m.ReadLatency != 0 {
+		n += 1 + sovOim(uint64(m.ReadLatency))
+	}
+	if m.WriteLatency != 0 {
+		n += 1 + sovOim(uint64(m.WriteLatency))
+	}
+	if m.UnmapLatency != 0 {
+		n += 1 + sovOim(uint64(m.UnmapLatency))
+	}
+	return n
+}
+
+func (m *GetOperationRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.OperationId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Wait {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetOperationReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Operation != nil {
+		l = m.Operation.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *Operation) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.OperationId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Done {
+		n += 2
+	}
+	if m.ErrorCode != 0 {
+		n += 1 + sovOim(uint64(m.ErrorCode))
+	}
+	l = len(m.ErrorMessage)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Result != nil {
+		n += m.Result.Size()
+	}
+	return n
+}
+
+func (m *Operation_MapVolume) Size() (n int) {
+	var l int
+	_ = l
+	if m.MapVolume != nil {
+		l = m.MapVolume.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *Operation_UnmapVolume) Size() (n int) {
+	var l int
+	_ = l
+	if m.UnmapVolume != nil {
+		l = m.UnmapVolume.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func sovOim(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozOim(x uint64) (n int) {
+	return sovOim(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValueRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Value == nil {
+				m.Value = &Value{}
+			}
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Value) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Value: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Value: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetValueReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValueReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValueReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetValuesReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetValuesReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, &Value{})
+			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AcquireLeaseRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AcquireLeaseRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AcquireLeaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shared", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Shared = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AcquireLeaseReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AcquireLeaseReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AcquireLeaseReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousHolder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousHolder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousReaders", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousReaders = append(m.PreviousReaders, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseLeaseRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseLeaseRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseLeaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseLeaseReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseLeaseReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseLeaseReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MapVolumeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Malloc", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MallocParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &MapVolumeRequest_Malloc{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ceph", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CephParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &MapVolumeRequest_Ceph{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lvol", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LVolParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &MapVolumeRequest_Lvol{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Qos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Qos == nil {
+				m.Qos = &QoS{}
+			}
+			if err := m.Qos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Crypto", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Crypto == nil {
+				m.Crypto = &CryptoParams{}
+			}
+			if err := m.Crypto.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Async", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Async = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Raid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RaidParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &MapVolumeRequest_Raid{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reader", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reader = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QoS) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QoS: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QoS: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RwIosPerSec", wireType)
+			}
+			m.RwIosPerSec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RwIosPerSec |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RwMbytesPerSec", wireType)
+			}
+			m.RwMbytesPerSec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RwMbytesPerSec |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RMbytesPerSec", wireType)
+			}
+			m.RMbytesPerSec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RMbytesPerSec |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WMbytesPerSec", wireType)
+			}
+			m.WMbytesPerSec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WMbytesPerSec |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MallocParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MallocParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MallocParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LVolParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LVolParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LVolParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LvolStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LvolStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaidParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaidParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaidParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			m.Level = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Level |= (RaidParams_Level(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StripSizeKb", wireType)
+			}
+			m.StripSizeKb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StripSizeKb |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &RaidMember{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaidMember) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaidMember: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaidMember: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Malloc", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MallocParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &RaidMember_Malloc{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ceph", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CephParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &RaidMember_Ceph{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lvol", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LVolParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &RaidMember_Lvol{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CryptoParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CryptoParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CryptoParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CryptoPmd", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CryptoPmd = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CephParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CephParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CephParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Secret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Monitors", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Monitors = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Config == nil {
+				m.Config = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOim
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOim
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthOim
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOim
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthOim
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipOim(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthOim
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Config[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockSize", wireType)
+			}
+			m.BlockSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockSize |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keyring", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keyring = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MapVolumeReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PciAddress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PciAddress == nil {
+				m.PciAddress = &PCIAddress{}
+			}
+			if err := m.PciAddress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScsiDisk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ScsiDisk == nil {
+				m.ScsiDisk = &SCSIDisk{}
+			}
+			if err := m.ScsiDisk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperationId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperationId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PCIAddress) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PCIAddress: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PCIAddress: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			m.Domain = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Domain |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bus", wireType)
+			}
+			m.Bus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bus |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Device", wireType)
+			}
+			m.Device = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Device |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
+			}
+			m.Function = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Function |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SCSIDisk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SCSIDisk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			m.Target = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Target |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lun", wireType)
+			}
+			m.Lun = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lun |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnmapVolumeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnmapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Async", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Async = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnmapVolumeReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnmapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OperationId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OperationId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuiesceVolumeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuiesceVolumeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuiesceVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetControllerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetControllerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuiesceVolumeReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuiesceVolumeReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuiesceVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MapVolume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MapVolume == nil {
+				m.MapVolume = &MapVolumeRequest{}
+			}
+			if err := m.MapVolume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResumeVolumeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResumeVolumeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResumeVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResumeVolumeReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResumeVolumeReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResumeVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProvisionMallocBDevRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProvisionMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BdevName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProvisionMallocBDevReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProvisionMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckMallocBDevRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BdevName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckMallocBDevReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckMallocBDevReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProvisionLVolRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProvisionLVolRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProvisionLVolRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LvolStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LvolStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProvisionLVolReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProvisionLVolReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProvisionLVolReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Snapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SnapshotId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceVolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceVolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreationTime", wireType)
+			}
+			m.CreationTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreationTime |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateSnapshotRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateSnapshotRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateSnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LvolStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LvolStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceVolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceVolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SnapshotId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateSnapshotReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateSnapshotReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateSnapshotReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Snapshot == nil {
+				m.Snapshot = &Snapshot{}
+			}
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteSnapshotRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteSnapshotRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteSnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LvolStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LvolStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SnapshotId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteSnapshotReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteSnapshotReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteSnapshotReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListSnapshotsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListSnapshotsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListSnapshotsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LvolStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LvolStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SnapshotId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceVolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceVolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListSnapshotsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListSnapshotsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListSnapshotsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Snapshots = append(m.Snapshots, &Snapshot{})
+			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CloneVolumeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CloneVolumeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CloneVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LvolStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LvolStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SnapshotId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceVolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceVolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CloneVolumeReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CloneVolumeReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CloneVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResizeVolumeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResizeVolumeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResizeVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LvolStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LvolStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovOim(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozOim(x uint64) (n int) {
-	return sovOim(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
+func (m *ResizeVolumeReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1587,17 +10884,17 @@ func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetValueRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResizeVolumeReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResizeVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
 			}
-			var msglen int
+			m.Size_ = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -1607,25 +10904,11 @@ func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Size_ |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Value == nil {
-				m.Value = &Value{}
-			}
-			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -1647,7 +10930,7 @@ func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Value) Unmarshal(dAtA []byte) error {
+func (m *SetVolumeQoSRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1670,15 +10953,15 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Value: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetVolumeQoSRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Value: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetVolumeQoSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1703,13 +10986,13 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Qos", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -1719,20 +11002,24 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
+			if m.Qos == nil {
+				m.Qos = &QoS{}
+			}
+			if err := m.Qos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1755,7 +11042,7 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetValueReply) Unmarshal(dAtA []byte) error {
+func (m *SetVolumeQoSReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1778,10 +11065,10 @@ func (m *SetValueReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetValueReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetVolumeQoSReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetValueReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetVolumeQoSReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -1805,7 +11092,7 @@ func (m *SetValueReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
+func (m *Orphan) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1828,15 +11115,34 @@ func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetValuesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Orphan: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Orphan: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= (Orphan_Kind(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1861,8 +11167,75 @@ func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScsiTarget", wireType)
+			}
+			m.ScsiTarget = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScsiTarget |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstSeen", wireType)
+			}
+			m.FirstSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstSeen |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -1884,7 +11257,7 @@ func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
+func (m *ListOrphansRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1907,43 +11280,12 @@ func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetValuesReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListOrphansRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetValuesReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListOrphansRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Values = append(m.Values, &Value{})
-			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -1965,7 +11307,7 @@ func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
+func (m *ListOrphansReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1988,17 +11330,17 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MapVolumeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListOrphansReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListOrphansReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Orphans", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2008,58 +11350,78 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VolumeId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Malloc", wireType)
+			m.Orphans = append(m.Orphans, &Orphan{})
+			if err := m.Orphans[len(m.Orphans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CollectGarbageRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &MallocParams{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.Params = &MapVolumeRequest_Malloc{v}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ceph", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CollectGarbageRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CollectGarbageRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2069,24 +11431,12 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &CephParams{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Params = &MapVolumeRequest_Ceph{v}
-			iNdEx = postIndex
+			m.Force = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2108,7 +11458,7 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MallocParams) Unmarshal(dAtA []byte) error {
+func (m *CollectGarbageReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2131,12 +11481,43 @@ func (m *MallocParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MallocParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: CollectGarbageReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MallocParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CollectGarbageReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Removed = append(m.Removed, &Orphan{})
+			if err := m.Removed[len(m.Removed)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2158,7 +11539,7 @@ func (m *MallocParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CephParams) Unmarshal(dAtA []byte) error {
+func (m *Volume) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2181,15 +11562,15 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CephParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: Volume: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CephParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Volume: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2214,11 +11595,11 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2243,11 +11624,11 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Secret = string(dAtA[iNdEx:postIndex])
+			m.BdevName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Monitors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BackingType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2272,13 +11653,72 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Monitors = string(dAtA[iNdEx:postIndex])
+			m.BackingType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encrypted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Encrypted = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Claimed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Claimed = bool(v != 0)
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PciAddress", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2288,26 +11728,30 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pool = string(dAtA[iNdEx:postIndex])
+			if m.PciAddress == nil {
+				m.PciAddress = &PCIAddress{}
+			}
+			if err := m.PciAddress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ScsiDisk", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2317,20 +11761,24 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Image = string(dAtA[iNdEx:postIndex])
+			if m.ScsiDisk == nil {
+				m.ScsiDisk = &SCSIDisk{}
+			}
+			if err := m.ScsiDisk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2353,7 +11801,7 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
+func (m *ListVolumesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2376,48 +11824,65 @@ func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MapVolumeReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListVolumesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListVolumesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PciAddress", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.PciAddress == nil {
-				m.PciAddress = &PCIAddress{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListVolumesReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
 			}
-			if err := m.PciAddress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 2:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListVolumesReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListVolumesReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScsiDisk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Volumes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2441,10 +11906,8 @@ func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ScsiDisk == nil {
-				m.ScsiDisk = &SCSIDisk{}
-			}
-			if err := m.ScsiDisk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Volumes = append(m.Volumes, &Volume{})
+			if err := m.Volumes[len(m.Volumes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2469,7 +11932,7 @@ func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PCIAddress) Unmarshal(dAtA []byte) error {
+func (m *GetVolumeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2492,55 +11955,17 @@ func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PCIAddress: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetVolumeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PCIAddress: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
-			}
-			m.Domain = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Domain |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bus", wireType)
-			}
-			m.Bus = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Bus |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Device", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
 			}
-			m.Device = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2550,30 +11975,21 @@ func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Device |= (uint32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
 			}
-			m.Function = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Function |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2595,7 +12011,7 @@ func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
+func (m *GetVolumeReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2618,17 +12034,17 @@ func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SCSIDisk: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetVolumeReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SCSIDisk: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Volume", wireType)
 			}
-			m.Target = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2638,30 +12054,25 @@ func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Target |= (uint32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lun", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthOim
 			}
-			m.Lun = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Lun |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Volume == nil {
+				m.Volume = &Volume{}
 			}
+			if err := m.Volume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2683,7 +12094,7 @@ func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
+func (m *GetVolumeStatsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2706,10 +12117,10 @@ func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UnmapVolumeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetVolumeStatsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UnmapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetVolumeStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2762,7 +12173,7 @@ func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
+func (m *GetVolumeStatsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2785,12 +12196,183 @@ func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UnmapVolumeReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetVolumeStatsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UnmapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetVolumeStatsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOps", wireType)
+			}
+			m.ReadOps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadOps |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadBytes", wireType)
+			}
+			m.ReadBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteOps", wireType)
+			}
+			m.WriteOps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteOps |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteBytes", wireType)
+			}
+			m.WriteBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnmapOps", wireType)
+			}
+			m.UnmapOps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnmapOps |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnmapBytes", wireType)
+			}
+			m.UnmapBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnmapBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadLatency", wireType)
+			}
+			m.ReadLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadLatency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteLatency", wireType)
+			}
+			m.WriteLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteLatency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnmapLatency", wireType)
+			}
+			m.UnmapLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnmapLatency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2812,7 +12394,7 @@ func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
+func (m *GetOperationRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2835,15 +12417,15 @@ func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProvisionMallocBDevRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperationRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProvisionMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OperationId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2868,13 +12450,13 @@ func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BdevName = string(dAtA[iNdEx:postIndex])
+			m.OperationId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Wait", wireType)
 			}
-			m.Size_ = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2884,11 +12466,12 @@ func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Size_ |= (int64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Wait = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2910,7 +12493,7 @@ func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
+func (m *GetOperationReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2933,12 +12516,45 @@ func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProvisionMallocBDevReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperationReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProvisionMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperationReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Operation == nil {
+				m.Operation = &Operation{}
+			}
+			if err := m.Operation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2960,7 +12576,7 @@ func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
+func (m *Operation) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2983,15 +12599,15 @@ func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckMallocBDevRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Operation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Operation: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OperationId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3016,58 +12632,198 @@ func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BdevName = string(dAtA[iNdEx:postIndex])
+			m.OperationId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipOim(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
 			}
-			if skippy < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOim
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CheckMallocBDevReply) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowOim
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
 			}
-			if iNdEx >= l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Done", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CheckMallocBDevReply: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Done = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorCode", wireType)
+			}
+			m.ErrorCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorCode |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorMessage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorMessage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MapVolume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MapVolumeReply{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Result = &Operation_MapVolume{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnmapVolume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &UnmapVolumeReply{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Result = &Operation_UnmapVolume{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -3197,50 +12953,156 @@ var (
 func init() { proto.RegisterFile("oim.proto", fileDescriptorOim) }
 
 var fileDescriptorOim = []byte{
-	// 718 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x4b, 0x6f, 0xd3, 0x40,
-	0x10, 0x8e, 0x9b, 0xd6, 0x38, 0x13, 0xd2, 0x46, 0x4b, 0x9b, 0x5a, 0x06, 0x45, 0xd5, 0x22, 0x50,
-	0x2f, 0xa4, 0x34, 0xe5, 0x71, 0x41, 0x42, 0x34, 0x45, 0x90, 0x43, 0x51, 0x71, 0x45, 0x0f, 0x48,
-	0x28, 0x72, 0xec, 0x6d, 0xb2, 0x74, 0xed, 0x35, 0x5e, 0xdb, 0x28, 0x5c, 0x39, 0x71, 0x43, 0xe2,
-	0x3f, 0x21, 0x8e, 0xfc, 0x04, 0x54, 0xfe, 0x08, 0xda, 0xf5, 0x23, 0x69, 0x93, 0x22, 0xf5, 0x36,
-	0x8f, 0x6f, 0xbe, 0x99, 0x9d, 0x87, 0x0d, 0x35, 0x4e, 0xfd, 0x4e, 0x18, 0xf1, 0x98, 0x23, 0x5d,
-	0x8a, 0xe9, 0x43, 0xab, 0x3d, 0xe2, 0x7c, 0xc4, 0xc8, 0x8e, 0xb2, 0x0e, 0x93, 0xd3, 0x9d, 0xcf,
-	0x91, 0x13, 0x86, 0x24, 0x12, 0x19, 0x0e, 0x3f, 0x81, 0xb5, 0x63, 0x12, 0x9f, 0x38, 0x2c, 0x21,
-	0x36, 0xf9, 0x94, 0x10, 0x11, 0xa3, 0xbb, 0xb0, 0x92, 0x4a, 0xdd, 0xd4, 0xb6, 0xb4, 0xed, 0x7a,
-	0xb7, 0xd1, 0xc9, 0xa8, 0x3a, 0x19, 0x28, 0xf3, 0xe1, 0x5d, 0x58, 0x51, 0x3a, 0x42, 0xb0, 0x1c,
-	0x3a, 0xf1, 0x58, 0x81, 0x6b, 0xb6, 0x92, 0xd1, 0x7a, 0xc1, 0xb0, 0xa4, 0x8c, 0x79, 0xc8, 0x1a,
-	0x34, 0xa6, 0xa9, 0x42, 0x36, 0xc1, 0xf7, 0xa1, 0xf9, 0x2a, 0x37, 0x88, 0x22, 0xf9, 0x02, 0x3a,
-	0xfc, 0x14, 0x56, 0x67, 0x70, 0x21, 0x9b, 0xa0, 0x7b, 0xa0, 0x2b, 0x4e, 0x61, 0x6a, 0x5b, 0xd5,
-	0xf9, 0x1a, 0x73, 0x27, 0xfe, 0xa1, 0x41, 0xf3, 0xd0, 0x09, 0x4f, 0x38, 0x4b, 0xfc, 0xf2, 0x79,
-	0xb7, 0xa1, 0x96, 0x2a, 0xc3, 0x80, 0x7a, 0x79, 0x1a, 0x23, 0x33, 0xf4, 0x3d, 0xd4, 0x01, 0xdd,
-	0x77, 0x18, 0xe3, 0xae, 0x2a, 0xbd, 0xde, 0x5d, 0x2f, 0x88, 0x0f, 0x95, 0xf5, 0xc8, 0x89, 0x1c,
-	0x5f, 0xbc, 0xae, 0xd8, 0x39, 0x0a, 0x6d, 0xc3, 0xb2, 0x4b, 0xc2, 0xb1, 0x59, 0x55, 0x68, 0x54,
-	0xa0, 0x7b, 0x24, 0x1c, 0x97, 0x58, 0x85, 0xd8, 0x37, 0x40, 0x0f, 0x95, 0x05, 0xaf, 0xc2, 0xcd,
-	0x59, 0x36, 0xfc, 0x55, 0x03, 0x98, 0x06, 0xa0, 0x4d, 0xb8, 0x91, 0x08, 0x12, 0x4d, 0xab, 0xd3,
-	0xa5, 0xda, 0xf7, 0x50, 0x0b, 0x74, 0x41, 0xdc, 0x88, 0xc4, 0x79, 0x5b, 0x73, 0x0d, 0x59, 0x60,
-	0xf8, 0x3c, 0xa0, 0x31, 0x8f, 0x84, 0xaa, 0xa3, 0x66, 0x97, 0xba, 0x6a, 0x27, 0xe7, 0xcc, 0x5c,
-	0xce, 0xdb, 0xc9, 0x39, 0x93, 0xd3, 0xa1, 0xbe, 0x33, 0x22, 0xe6, 0x4a, 0x36, 0x1d, 0xa5, 0xe0,
-	0x18, 0x56, 0x67, 0x5a, 0x25, 0x9b, 0xbc, 0x07, 0xf5, 0xd0, 0xa5, 0x03, 0xc7, 0xf3, 0x22, 0x22,
-	0x44, 0xbe, 0x0d, 0xe5, 0x13, 0x8f, 0x7a, 0xfd, 0x17, 0x99, 0xc7, 0x86, 0xd0, 0xa5, 0xb9, 0x8c,
-	0x1e, 0x40, 0x4d, 0xb8, 0x82, 0x0e, 0x3c, 0x2a, 0xce, 0xf2, 0x1e, 0x36, 0x8b, 0x90, 0xe3, 0xde,
-	0x71, 0xff, 0x80, 0x8a, 0x33, 0xdb, 0x90, 0x10, 0x29, 0xe1, 0x8f, 0x00, 0x53, 0x22, 0xf9, 0x42,
-	0x8f, 0xfb, 0x0e, 0x0d, 0x54, 0xb2, 0x86, 0x9d, 0x6b, 0xa8, 0x09, 0xd5, 0x61, 0x22, 0x14, 0x5d,
-	0xc3, 0x96, 0xa2, 0x42, 0x92, 0x94, 0xba, 0x44, 0xbd, 0x58, 0x22, 0x95, 0x26, 0x7b, 0x71, 0x9a,
-	0x04, 0x6e, 0x4c, 0x79, 0xa0, 0xde, 0xdc, 0xb0, 0x4b, 0x1d, 0x3f, 0x02, 0xa3, 0xa8, 0x40, 0xc6,
-	0xc7, 0x4e, 0x34, 0x22, 0x71, 0x91, 0x29, 0xd3, 0x64, 0x26, 0x96, 0x04, 0x45, 0x26, 0x96, 0x04,
-	0x78, 0x17, 0xd0, 0xbb, 0xc0, 0xbf, 0xce, 0x12, 0x61, 0x04, 0xcd, 0x0b, 0x21, 0x72, 0xd7, 0x0f,
-	0xc1, 0x3a, 0x8a, 0x78, 0x4a, 0x05, 0xe5, 0x41, 0x36, 0xfd, 0xfd, 0x03, 0x92, 0xce, 0xd0, 0x0d,
-	0x3d, 0x92, 0x0e, 0x02, 0xc7, 0x27, 0x05, 0x9d, 0x34, 0xbc, 0x71, 0x7c, 0x75, 0x61, 0x82, 0x7e,
-	0xc9, 0x8e, 0xa9, 0x6a, 0x2b, 0x19, 0x5b, 0x60, 0x2e, 0xa4, 0x93, 0xa9, 0x1e, 0x43, 0xab, 0x37,
-	0x26, 0xee, 0xd9, 0xf5, 0xd2, 0xe0, 0x16, 0xac, 0xcf, 0x85, 0x85, 0x6c, 0xd2, 0xfd, 0xa6, 0x81,
-	0x61, 0x93, 0x11, 0x15, 0x71, 0x34, 0x41, 0xcf, 0xc0, 0x28, 0x6e, 0x18, 0x6d, 0x96, 0x73, 0xbd,
-	0xf8, 0x01, 0xb1, 0x36, 0xe6, 0x1d, 0xb2, 0xae, 0x0a, 0x7a, 0x0e, 0xb5, 0xf2, 0x90, 0x91, 0x59,
-	0xa0, 0x2e, 0x7f, 0x03, 0xac, 0xd6, 0x02, 0x8f, 0x22, 0xe8, 0xfe, 0x5c, 0x02, 0xe8, 0xf1, 0x20,
-	0x8e, 0x38, 0x63, 0x24, 0x92, 0x7c, 0xe5, 0xce, 0x4e, 0xf9, 0x2e, 0x5f, 0xfc, 0x94, 0xef, 0xe2,
-	0x82, 0xe3, 0x0a, 0x7a, 0x09, 0xf5, 0x99, 0x49, 0x21, 0xab, 0x00, 0xce, 0x4f, 0xdc, 0x32, 0x17,
-	0xfa, 0x32, 0x9a, 0x0f, 0x70, 0x6b, 0xc1, 0x34, 0x10, 0x2e, 0x6f, 0xe5, 0xca, 0xc9, 0x5b, 0x5b,
-	0xff, 0xc5, 0x64, 0xf4, 0x6f, 0x61, 0xed, 0xd2, 0x64, 0x50, 0xbb, 0xfc, 0xd2, 0x2c, 0x9c, 0xb4,
-	0x75, 0xe7, 0x4a, 0xbf, 0xa2, 0xdc, 0xdf, 0xf8, 0x75, 0xde, 0xd6, 0x7e, 0x9f, 0xb7, 0xb5, 0x3f,
-	0xe7, 0x6d, 0xed, 0xfb, 0xdf, 0x76, 0xe5, 0x7d, 0x95, 0x53, 0x7f, 0xa8, 0xab, 0x9f, 0xc2, 0xde,
-	0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x09, 0x6d, 0x9f, 0x26, 0x49, 0x06, 0x00, 0x00,
+	// 2415 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcb, 0x6f, 0xdb, 0xc8,
+	0x19, 0x37, 0x25, 0x59, 0x26, 0x3f, 0xd9, 0x8e, 0x3c, 0x7e, 0x2c, 0x4d, 0xef, 0x7a, 0x13, 0x06,
+	0x4d, 0xbc, 0x68, 0xd6, 0x49, 0xbd, 0xcf, 0x16, 0x5b, 0x04, 0x89, 0x1c, 0x24, 0xde, 0xd8, 0x79,
+	0xd0, 0xd9, 0x2c, 0x50, 0xa0, 0x50, 0x29, 0x72, 0x6c, 0xb3, 0x26, 0x39, 0x0c, 0x87, 0x92, 0x21,
+	0x9f, 0xda, 0x7f, 0xa0, 0xe8, 0xa5, 0xe8, 0xa9, 0x97, 0x5e, 0x7a, 0xe8, 0xa1, 0xa7, 0xa2, 0xbd,
+	0xf4, 0xde, 0x4b, 0x81, 0xfe, 0x09, 0x45, 0xfa, 0x7f, 0x14, 0xc5, 0x3c, 0xf8, 0x14, 0xe5, 0xd8,
+	0xe8, 0x1e, 0x7a, 0xd3, 0x7c, 0xdf, 0x6f, 0xbe, 0xf7, 0xcc, 0x7c, 0x1f, 0x05, 0x1a, 0xf1, 0x82,
+	0xed, 0x28, 0x26, 0x09, 0x41, 0x6d, 0xf6, 0x73, 0x74, 0xcf, 0xd8, 0x3c, 0x26, 0xe4, 0xd8, 0xc7,
+	0x77, 0x39, 0x75, 0x30, 0x3c, 0xba, 0x7b, 0x16, 0xdb, 0x51, 0x84, 0x63, 0x2a, 0x70, 0xe6, 0xe7,
+	0x70, 0xed, 0x10, 0x27, 0xaf, 0x6d, 0x7f, 0x88, 0x2d, 0xfc, 0x66, 0x88, 0x69, 0x82, 0x6e, 0xc2,
+	0xec, 0x88, 0xad, 0x75, 0xe5, 0xba, 0xb2, 0xd5, 0xd9, 0x59, 0xd8, 0x16, 0xa2, 0xb6, 0x05, 0x48,
+	0xf0, 0xcc, 0x1f, 0xc0, 0x2c, 0x5f, 0x23, 0x04, 0xad, 0xc8, 0x4e, 0x4e, 0x38, 0x58, 0xb3, 0xf8,
+	0x6f, 0xb4, 0x92, 0x4a, 0x68, 0x70, 0xa2, 0xdc, 0x72, 0x0d, 0x16, 0x72, 0x55, 0x91, 0x3f, 0x36,
+	0x6f, 0x41, 0xf7, 0xb1, 0x24, 0xd0, 0x54, 0x79, 0x8d, 0x38, 0xf3, 0x0b, 0x58, 0x2c, 0xe0, 0x22,
+	0x7f, 0x8c, 0xbe, 0x07, 0x6d, 0x2e, 0x93, 0xea, 0xca, 0xf5, 0xe6, 0xa4, 0x8d, 0x92, 0x69, 0xfe,
+	0x0c, 0x96, 0x1f, 0x38, 0x6f, 0x86, 0x5e, 0x8c, 0xf7, 0xb1, 0x4d, 0x33, 0x07, 0x37, 0x40, 0x1b,
+	0x11, 0x7f, 0x18, 0xe0, 0xbe, 0xe7, 0x4a, 0x45, 0xaa, 0x20, 0xec, 0xb9, 0xcc, 0xf6, 0x23, 0x12,
+	0x3b, 0xc2, 0x76, 0xd5, 0x12, 0x0b, 0xb4, 0x06, 0x6d, 0x7a, 0x62, 0xc7, 0xd8, 0xd5, 0x9b, 0x9c,
+	0x2c, 0x57, 0xe6, 0x31, 0x2c, 0x95, 0x35, 0x30, 0xeb, 0x6e, 0xc3, 0xb5, 0x28, 0xc6, 0x23, 0x8f,
+	0x0c, 0x69, 0xff, 0x84, 0xf8, 0x2e, 0x8e, 0xa5, 0x96, 0xc5, 0x94, 0xfc, 0x84, 0x53, 0xd1, 0x47,
+	0xd0, 0xcd, 0x80, 0x31, 0xb6, 0x5d, 0x1c, 0x53, 0xbd, 0x71, 0xbd, 0xb9, 0xa5, 0x59, 0x99, 0x00,
+	0x4b, 0x90, 0xcd, 0x1d, 0x58, 0xb6, 0xb0, 0xcf, 0x74, 0x5c, 0xda, 0x15, 0x73, 0x19, 0x96, 0xca,
+	0x7b, 0x58, 0xd0, 0xff, 0xd3, 0x80, 0xee, 0x81, 0x1d, 0xbd, 0xe6, 0xa0, 0x4b, 0x45, 0x64, 0x1b,
+	0xda, 0x81, 0xed, 0xfb, 0xc4, 0xe1, 0x21, 0xe9, 0xec, 0xac, 0xa4, 0xc1, 0x3e, 0xe0, 0xd4, 0x17,
+	0x76, 0x6c, 0x07, 0xf4, 0xc9, 0x8c, 0x25, 0x51, 0x68, 0x0b, 0x5a, 0x0e, 0x8e, 0x4e, 0x78, 0xa4,
+	0x3a, 0x3b, 0x28, 0x45, 0xf7, 0x70, 0x74, 0x92, 0x61, 0x39, 0x82, 0x21, 0xfd, 0x11, 0xf1, 0xf5,
+	0x56, 0x19, 0xb9, 0xff, 0x9a, 0xf8, 0x39, 0x92, 0x21, 0xd0, 0x07, 0xd0, 0x7c, 0x43, 0xa8, 0x3e,
+	0xcb, 0x81, 0x9d, 0x14, 0xf8, 0x92, 0x1c, 0x5a, 0x8c, 0x8e, 0xee, 0x40, 0xdb, 0x89, 0xc7, 0x51,
+	0x42, 0xf4, 0x76, 0xd9, 0xc4, 0x1e, 0xa7, 0x0a, 0x61, 0x96, 0xc4, 0xe4, 0x29, 0x56, 0x8b, 0x29,
+	0x5e, 0x81, 0x59, 0x9b, 0x8e, 0x43, 0x47, 0xd7, 0x04, 0x95, 0x2f, 0x98, 0x89, 0xb1, 0xed, 0xb9,
+	0x3a, 0x94, 0x4d, 0xb4, 0x6c, 0xcf, 0xcd, 0x4d, 0x64, 0x08, 0x56, 0x22, 0x22, 0x87, 0x7a, 0x47,
+	0x94, 0x88, 0x58, 0x3d, 0x54, 0xa1, 0x1d, 0x71, 0xe4, 0xd7, 0x2d, 0x75, 0xae, 0xab, 0x9a, 0x7f,
+	0x50, 0xa0, 0xf9, 0x92, 0x1c, 0xa2, 0x9b, 0xb0, 0x18, 0x9f, 0xf5, 0x3d, 0x42, 0xfb, 0x11, 0x8e,
+	0xfb, 0x14, 0x3b, 0x3c, 0xf0, 0x2d, 0xab, 0x13, 0x9f, 0xed, 0x11, 0xfa, 0x02, 0xc7, 0x87, 0xd8,
+	0x41, 0x1f, 0xc1, 0x52, 0x7c, 0xd6, 0x0f, 0x06, 0xe3, 0x04, 0xe7, 0xb8, 0x06, 0xc7, 0x2d, 0xc6,
+	0x67, 0x07, 0x9c, 0x2e, 0xa1, 0xb7, 0xa1, 0x1b, 0x57, 0x91, 0x4d, 0x8e, 0x5c, 0x88, 0xab, 0xc0,
+	0x09, 0x91, 0x2d, 0x01, 0x2c, 0x49, 0x34, 0x17, 0x61, 0xbe, 0x98, 0x62, 0xf3, 0xfb, 0x00, 0x79,
+	0x6a, 0xd0, 0x07, 0x00, 0x2c, 0x35, 0x7d, 0x9a, 0x90, 0x18, 0xcb, 0xa2, 0xd1, 0x18, 0xe5, 0x90,
+	0x11, 0xcc, 0xdf, 0x2b, 0x00, 0x79, 0x94, 0xd0, 0x36, 0xcc, 0xfa, 0x78, 0x84, 0x7d, 0x0e, 0x5c,
+	0xdc, 0xd1, 0x27, 0x03, 0xb9, 0xbd, 0xcf, 0xf8, 0x96, 0x80, 0x21, 0x13, 0x16, 0x68, 0x12, 0x7b,
+	0x51, 0x9f, 0x7a, 0xe7, 0xb8, 0x7f, 0x3a, 0xe0, 0x4e, 0x2f, 0x58, 0x1d, 0x4e, 0x3c, 0xf4, 0xce,
+	0xf1, 0xd3, 0x01, 0xba, 0x03, 0x73, 0x01, 0x0e, 0x06, 0xec, 0xd4, 0x34, 0xaf, 0x37, 0xab, 0xe9,
+	0x39, 0xe0, 0x2c, 0x2b, 0x85, 0x98, 0x08, 0x66, 0xb9, 0x06, 0xa4, 0xc1, 0xac, 0xf5, 0x60, 0x6f,
+	0xf7, 0x5e, 0x77, 0xc6, 0xfc, 0x8b, 0x34, 0x52, 0x60, 0xff, 0xef, 0x8f, 0x41, 0x5e, 0x4b, 0xe6,
+	0x7d, 0x98, 0x2f, 0xd6, 0x36, 0xcb, 0x86, 0xa8, 0xee, 0x7e, 0x14, 0xa4, 0xb6, 0x6b, 0x82, 0xf2,
+	0x22, 0x70, 0x51, 0x17, 0x9a, 0xa7, 0x78, 0x2c, 0xef, 0x63, 0xf6, 0xd3, 0xfc, 0x73, 0x03, 0x20,
+	0xb7, 0x05, 0xbd, 0x07, 0x73, 0x43, 0x8a, 0xe3, 0xdc, 0xf1, 0x36, 0x5b, 0xee, 0xf1, 0xb2, 0xa6,
+	0xd8, 0x89, 0x71, 0x22, 0x37, 0xcb, 0x15, 0x32, 0x40, 0x0d, 0x48, 0xe8, 0x25, 0x84, 0x47, 0x9f,
+	0x87, 0x2a, 0x5d, 0xf3, 0x4b, 0x9c, 0x48, 0x87, 0xd8, 0x25, 0x4e, 0x88, 0xcf, 0x8e, 0x97, 0x17,
+	0xd8, 0xc7, 0x98, 0x9f, 0x61, 0xcd, 0x12, 0x0b, 0xf4, 0x39, 0xb4, 0x1d, 0x12, 0x1e, 0x79, 0xc7,
+	0xfa, 0x1c, 0xcf, 0xe0, 0xe6, 0x64, 0x98, 0xb6, 0x7b, 0x1c, 0xf0, 0x28, 0x4c, 0xe2, 0xb1, 0x25,
+	0xd1, 0xcc, 0xdd, 0x81, 0x4f, 0x9c, 0x53, 0x5e, 0x1e, 0xfc, 0x1c, 0x2f, 0x58, 0x1a, 0xa7, 0xb0,
+	0xda, 0x40, 0x3a, 0xcc, 0x9d, 0xe2, 0x71, 0xec, 0x85, 0xc7, 0xfc, 0x34, 0x6b, 0x56, 0xba, 0x34,
+	0x7e, 0x08, 0x9d, 0x82, 0xbc, 0x34, 0x2e, 0x4a, 0x16, 0x97, 0xfa, 0xb7, 0xeb, 0x47, 0x8d, 0x2f,
+	0x95, 0xaf, 0x5b, 0x6a, 0xbb, 0x3b, 0x67, 0xfe, 0x56, 0x81, 0xc5, 0xc2, 0xfd, 0xc9, 0xee, 0xfb,
+	0x4f, 0xa0, 0x13, 0x39, 0x5e, 0xdf, 0x76, 0xdd, 0x18, 0x53, 0xaa, 0x2b, 0xe5, 0x34, 0xbe, 0xe8,
+	0xed, 0x3d, 0x10, 0x1c, 0x0b, 0x22, 0xc7, 0x93, 0xbf, 0xd1, 0xc7, 0xa0, 0x51, 0x87, 0x7a, 0x7d,
+	0xd7, 0xa3, 0xa7, 0xb2, 0xa2, 0xba, 0xe9, 0x96, 0xc3, 0xde, 0xe1, 0xde, 0xae, 0x47, 0x4f, 0x2d,
+	0x95, 0x41, 0xd8, 0x2f, 0x74, 0x03, 0xe6, 0x49, 0x84, 0x63, 0x3b, 0xf1, 0x48, 0xc8, 0x92, 0x24,
+	0x42, 0xde, 0xc9, 0x68, 0x7b, 0xae, 0xf9, 0x73, 0x80, 0x5c, 0x17, 0xcb, 0x9b, 0x4b, 0x02, 0xdb,
+	0x0b, 0xb9, 0x3d, 0x0b, 0x96, 0x5c, 0x31, 0x8f, 0x07, 0x43, 0x2a, 0x8f, 0x13, 0xfb, 0xc9, 0x91,
+	0x78, 0xe4, 0x39, 0x58, 0x6f, 0x4a, 0x24, 0x5f, 0xb1, 0x0c, 0x1f, 0x0d, 0x43, 0x87, 0x49, 0xe7,
+	0x99, 0x5c, 0xb0, 0xb2, 0xb5, 0xf9, 0x29, 0xa8, 0xa9, 0x91, 0x6c, 0x7f, 0x62, 0xc7, 0xc7, 0x38,
+	0x49, 0x35, 0x89, 0x15, 0xd3, 0xe4, 0x0f, 0xc3, 0x54, 0x93, 0x3f, 0x0c, 0xcd, 0xc7, 0x80, 0xbe,
+	0x09, 0x83, 0x2b, 0x3d, 0x3e, 0xd9, 0xad, 0xdc, 0x28, 0xdc, 0xca, 0xe6, 0x67, 0xd0, 0x2d, 0x09,
+	0x62, 0x59, 0xa8, 0x46, 0x48, 0x99, 0x8c, 0x10, 0x86, 0x95, 0x97, 0x43, 0x0f, 0x53, 0x07, 0x5f,
+	0xc1, 0x82, 0x7b, 0xb0, 0x22, 0x1c, 0xea, 0x3b, 0x24, 0x4c, 0x62, 0xe2, 0xfb, 0xe2, 0x98, 0x88,
+	0xfa, 0x40, 0x82, 0xd7, 0xcb, 0x58, 0x7b, 0xae, 0x79, 0x00, 0xa8, 0xa2, 0x86, 0xd9, 0xf7, 0x05,
+	0x40, 0x60, 0x47, 0x7d, 0x21, 0x57, 0x16, 0x89, 0x9e, 0xdf, 0x21, 0xe5, 0xa0, 0x58, 0x5a, 0xe6,
+	0x9d, 0x78, 0xfa, 0xe9, 0x30, 0xb8, 0x82, 0xd1, 0xe2, 0xe9, 0x2f, 0xee, 0x61, 0x4f, 0xff, 0x01,
+	0x18, 0x2f, 0x62, 0x32, 0xf2, 0xa8, 0x47, 0x42, 0x71, 0x69, 0x3d, 0xdc, 0xc5, 0xa3, 0x82, 0xbc,
+	0x81, 0x8b, 0x47, 0xfd, 0xd0, 0x0e, 0xd2, 0xeb, 0x5c, 0x65, 0x84, 0x67, 0x76, 0xc0, 0xbb, 0x3c,
+	0x7e, 0xd2, 0x98, 0xd3, 0x4d, 0x8b, 0xff, 0x36, 0x0d, 0xd0, 0x6b, 0xc5, 0x31, 0x55, 0x9f, 0xc1,
+	0x5a, 0xef, 0x04, 0x3b, 0xa7, 0x57, 0x53, 0x63, 0xae, 0xc1, 0xca, 0xc4, 0x36, 0x26, 0xee, 0x08,
+	0x56, 0x32, 0x55, 0xec, 0x5a, 0x4c, 0x85, 0x5d, 0xfc, 0x06, 0x95, 0x43, 0xd4, 0xa8, 0xe4, 0x35,
+	0x75, 0xa9, 0x59, 0x70, 0x69, 0x0b, 0x50, 0x45, 0x0f, 0xcb, 0x5c, 0x8a, 0x54, 0x0a, 0xc8, 0x5f,
+	0x29, 0xa0, 0x1e, 0x86, 0x76, 0x44, 0x4f, 0x48, 0x82, 0x3e, 0x84, 0x0e, 0x95, 0xbf, 0xf3, 0x64,
+	0x40, 0x4a, 0xda, 0x73, 0xd1, 0x16, 0x74, 0x29, 0x19, 0xc6, 0x0e, 0xee, 0x57, 0xed, 0x59, 0x14,
+	0xf4, 0xd7, 0x17, 0x58, 0x85, 0x6e, 0xc2, 0x82, 0x13, 0x63, 0x51, 0xd8, 0x89, 0x17, 0x60, 0x7e,
+	0x1a, 0x9b, 0xd6, 0x7c, 0x4a, 0x7c, 0xe5, 0x05, 0xd8, 0xfc, 0xa5, 0x02, 0xab, 0x3d, 0x46, 0xc0,
+	0xa9, 0x59, 0x97, 0x0c, 0xd2, 0xe5, 0x6d, 0xab, 0xb8, 0xd9, 0xac, 0xba, 0x69, 0xf6, 0x60, 0xb9,
+	0x6a, 0x02, 0x8b, 0xdf, 0x1d, 0x50, 0x53, 0x90, 0xae, 0x54, 0x6e, 0xba, 0x14, 0x98, 0x21, 0xcc,
+	0x6f, 0x61, 0x75, 0x17, 0xfb, 0xf8, 0xca, 0x7e, 0x54, 0xac, 0x6b, 0x4c, 0x58, 0xb7, 0x0a, 0xcb,
+	0x55, 0xc1, 0xac, 0xb6, 0x7e, 0xa1, 0xc0, 0xca, 0xbe, 0x47, 0x93, 0x94, 0x4a, 0xbf, 0x23, 0x7d,
+	0xb5, 0x81, 0x6d, 0xd6, 0x05, 0xd6, 0xdc, 0x05, 0x54, 0xb1, 0x80, 0x85, 0x6d, 0x1b, 0xb4, 0x54,
+	0x5a, 0x3a, 0xe7, 0x4c, 0xc6, 0x2d, 0x87, 0x98, 0x7f, 0x52, 0x00, 0xf5, 0x7c, 0x12, 0x56, 0xee,
+	0x89, 0xff, 0xe5, 0x8c, 0xbc, 0x2b, 0xe3, 0xb5, 0x3e, 0xb6, 0x2e, 0x2c, 0xec, 0xd9, 0xc2, 0x21,
+	0xba, 0x05, 0xdd, 0x92, 0xc1, 0xd3, 0x0e, 0x1b, 0xe6, 0x37, 0xa0, 0x77, 0xfe, 0x1d, 0x7a, 0x56,
+	0x77, 0xfa, 0x6f, 0xc3, 0x52, 0x59, 0xcd, 0x34, 0x7b, 0x5e, 0xc2, 0x32, 0x9b, 0x64, 0x39, 0x8a,
+	0xcd, 0x20, 0x97, 0x79, 0x46, 0xe4, 0x04, 0xd3, 0xa8, 0x9f, 0x60, 0xd8, 0x85, 0x5d, 0x16, 0xc9,
+	0x4a, 0xf3, 0x1f, 0x0a, 0xb4, 0x9f, 0xc7, 0xd1, 0x89, 0x1d, 0xa2, 0xdb, 0xd0, 0x3a, 0xf5, 0x42,
+	0x57, 0xb6, 0xcf, 0xcb, 0xe9, 0x7e, 0xc1, 0xdd, 0x7e, 0xea, 0x85, 0xae, 0xc5, 0x01, 0xcc, 0x5e,
+	0x7e, 0xb5, 0x0a, 0x87, 0xf9, 0x6f, 0x9e, 0x46, 0xd6, 0x6b, 0xc8, 0x67, 0x5a, 0x3c, 0xf3, 0xc0,
+	0x48, 0xaf, 0x38, 0x45, 0xce, 0x2e, 0x54, 0x3e, 0xf4, 0x9a, 0x25, 0x57, 0x2c, 0xc2, 0x47, 0x5e,
+	0x4c, 0x93, 0x3e, 0xc5, 0x38, 0x94, 0xa9, 0xd3, 0x38, 0xe5, 0x10, 0xe3, 0xd0, 0xdc, 0x86, 0x16,
+	0xd3, 0x8c, 0x3a, 0x30, 0xf7, 0xcd, 0xb3, 0xa7, 0xcf, 0x9e, 0x7f, 0xfb, 0xac, 0x3b, 0x83, 0x54,
+	0x68, 0x3d, 0xdc, 0x7d, 0xf4, 0xba, 0xab, 0xa0, 0x6b, 0xd0, 0x61, 0x4d, 0x42, 0xff, 0xd5, 0x03,
+	0xeb, 0xf1, 0xa3, 0x57, 0xdd, 0x86, 0xb9, 0x22, 0xea, 0x5c, 0x18, 0x9d, 0x9e, 0x33, 0xf3, 0x2b,
+	0xe8, 0x96, 0xa8, 0x2c, 0xea, 0x5b, 0x30, 0x47, 0xc4, 0x5a, 0x56, 0xfe, 0x62, 0xd9, 0x63, 0x2b,
+	0x65, 0x9b, 0x1f, 0xc3, 0x6a, 0x8f, 0x3d, 0xbc, 0x4e, 0xf2, 0xd8, 0x8e, 0x07, 0xf6, 0x71, 0x56,
+	0x1d, 0xd9, 0x94, 0xa7, 0x14, 0xa6, 0x3c, 0xf3, 0x3e, 0x2c, 0x57, 0xe1, 0x52, 0x5f, 0x8c, 0x03,
+	0x32, 0xc2, 0xee, 0x34, 0x7d, 0x92, 0x6d, 0xfe, 0xa6, 0x01, 0x6d, 0x91, 0xa6, 0x8b, 0xf3, 0x5d,
+	0x7a, 0xe7, 0x1a, 0x95, 0xe7, 0xf4, 0x06, 0xcc, 0x0f, 0x6c, 0xe7, 0xd4, 0x0b, 0x8f, 0xfb, 0xc9,
+	0x38, 0xc2, 0x69, 0x37, 0x27, 0x69, 0xaf, 0xc6, 0x51, 0xfe, 0xe2, 0xb6, 0x0a, 0x0f, 0xc1, 0xfb,
+	0xa0, 0xe1, 0x90, 0x37, 0xf5, 0xd8, 0xe5, 0xd9, 0x50, 0xad, 0x9c, 0xc0, 0x9a, 0x5e, 0xc7, 0xb7,
+	0xbd, 0x00, 0xbb, 0x7c, 0x0a, 0x56, 0xad, 0x74, 0x59, 0x6d, 0x50, 0xe7, 0xae, 0xde, 0xa0, 0xaa,
+	0xef, 0x6a, 0x50, 0xd3, 0xdc, 0x8a, 0xd0, 0x54, 0x73, 0x9b, 0x51, 0x65, 0xac, 0x45, 0x94, 0x26,
+	0x72, 0x2b, 0xcf, 0x5d, 0xca, 0x36, 0xef, 0x8a, 0x0f, 0x44, 0x97, 0x6f, 0x7b, 0xbe, 0x14, 0x5f,
+	0x8a, 0x0a, 0xc7, 0xf7, 0x16, 0xb4, 0x4b, 0x1d, 0x57, 0x55, 0x97, 0xe4, 0x9a, 0x9f, 0xc2, 0x6a,
+	0xb6, 0xf3, 0x30, 0xb1, 0x13, 0x7a, 0x29, 0x7d, 0x7f, 0x6d, 0xc0, 0x72, 0x75, 0x1b, 0xd3, 0xba,
+	0x0e, 0x2a, 0x9b, 0xfe, 0xfb, 0x24, 0xa2, 0x72, 0xaa, 0x9f, 0x63, 0xeb, 0xe7, 0x11, 0x1f, 0xd4,
+	0x38, 0x8b, 0x0f, 0xda, 0x72, 0x94, 0xd7, 0x18, 0xe5, 0x21, 0x23, 0x30, 0x75, 0x67, 0xb1, 0x97,
+	0x60, 0xbe, 0x55, 0x8c, 0xef, 0x2a, 0x27, 0xb0, 0xbd, 0x1f, 0x42, 0x47, 0x30, 0xc5, 0x66, 0x31,
+	0xb4, 0x03, 0x27, 0x65, 0xbb, 0x87, 0xac, 0x2f, 0xe6, 0xbb, 0x67, 0xc5, 0x6e, 0x4e, 0x90, 0xbb,
+	0x05, 0x53, 0xec, 0x6e, 0x8b, 0xdd, 0x9c, 0x24, 0x76, 0xdf, 0x80, 0x79, 0x6e, 0x9a, 0x6f, 0x27,
+	0x38, 0x74, 0xc6, 0xfa, 0x9c, 0xfc, 0x1e, 0x81, 0x6d, 0x77, 0x5f, 0x90, 0x58, 0x2b, 0x22, 0x2c,
+	0x48, 0x31, 0x2a, 0xc7, 0xcc, 0x73, 0x62, 0x01, 0x24, 0x14, 0xa5, 0x20, 0x4d, 0x80, 0x38, 0x51,
+	0x82, 0xcc, 0x7d, 0x1e, 0xb9, 0xe7, 0x69, 0x77, 0x9e, 0x86, 0xfb, 0xdd, 0x5d, 0x3c, 0x3b, 0x19,
+	0x67, 0xb6, 0x97, 0xc8, 0x89, 0x80, 0xff, 0x36, 0x77, 0x61, 0xa9, 0x2c, 0x8d, 0x65, 0xe1, 0x2e,
+	0x68, 0xd9, 0x3e, 0x99, 0xfe, 0xa5, 0xec, 0x58, 0x67, 0xd0, 0x1c, 0x63, 0xfe, 0xad, 0x01, 0x5a,
+	0xc6, 0xb8, 0x8c, 0x29, 0x6b, 0xd0, 0x0e, 0x70, 0x72, 0x42, 0xd2, 0xf7, 0x45, 0xae, 0xca, 0x45,
+	0xd3, 0x9c, 0x7c, 0x7a, 0x5c, 0x12, 0x8a, 0x93, 0xad, 0x5a, 0xfc, 0x37, 0xab, 0x0a, 0x1c, 0xc7,
+	0x24, 0xee, 0x3b, 0xc4, 0x15, 0x6f, 0xe4, 0xac, 0xa5, 0x71, 0x4a, 0x8f, 0xb8, 0xbc, 0x03, 0x14,
+	0xec, 0x00, 0x53, 0xca, 0x86, 0xe8, 0x36, 0x97, 0x39, 0xcf, 0x89, 0x07, 0x82, 0x56, 0x19, 0x30,
+	0xc4, 0x21, 0x5f, 0xab, 0x19, 0x30, 0x22, 0x7f, 0xfc, 0x64, 0xa6, 0x30, 0x60, 0xa0, 0x1f, 0x83,
+	0x48, 0x4d, 0xba, 0x55, 0x2d, 0xcf, 0x26, 0xd5, 0x49, 0xeb, 0xc9, 0x8c, 0xd5, 0x19, 0xe6, 0x34,
+	0xf6, 0x51, 0x22, 0xc6, 0x74, 0xe8, 0x27, 0x3b, 0xbf, 0x6b, 0x80, 0x6a, 0xe1, 0x63, 0x8f, 0xb2,
+	0xd1, 0xfa, 0x2b, 0x50, 0xd3, 0xcf, 0xbd, 0xe8, 0xbd, 0xec, 0xe2, 0x28, 0x7f, 0x6b, 0x36, 0x56,
+	0x27, 0x19, 0xec, 0xe1, 0x9b, 0x41, 0xf7, 0x41, 0xcb, 0xbe, 0xf9, 0xa2, 0xcc, 0x94, 0xea, 0xe7,
+	0x62, 0x63, 0xad, 0x86, 0x23, 0x04, 0x3c, 0x81, 0xf9, 0xe2, 0x97, 0x59, 0xb4, 0x91, 0x22, 0x6b,
+	0xbe, 0x08, 0x1b, 0xeb, 0xf5, 0xcc, 0x4c, 0x52, 0xf1, 0x33, 0x6a, 0x2e, 0xa9, 0xe6, 0x83, 0xac,
+	0xb1, 0x5e, 0xcf, 0xe4, 0x92, 0x76, 0xfe, 0xd8, 0x01, 0xc8, 0x27, 0x45, 0xe6, 0x63, 0x96, 0x16,
+	0x34, 0x75, 0x14, 0x34, 0xa6, 0xe4, 0xd0, 0x9c, 0x41, 0x8f, 0xa0, 0x53, 0x48, 0x0e, 0x32, 0x6a,
+	0x33, 0x26, 0x84, 0x4c, 0xcd, 0xa6, 0x70, 0xb0, 0x78, 0x78, 0x72, 0x07, 0x6b, 0x0e, 0xa8, 0xb1,
+	0x5e, 0xcf, 0x14, 0x92, 0x9e, 0xc2, 0x42, 0x69, 0xf2, 0x45, 0xef, 0x67, 0x8d, 0x4e, 0xcd, 0xdc,
+	0x6d, 0x18, 0x53, 0xb8, 0x85, 0xb8, 0xe7, 0x33, 0x6c, 0x31, 0xee, 0x13, 0xd3, 0xb0, 0xb1, 0x5e,
+	0xcf, 0x14, 0x92, 0x7e, 0x0a, 0xcb, 0x35, 0x93, 0x2a, 0x32, 0xb3, 0x17, 0x70, 0xea, 0x54, 0x6c,
+	0x5c, 0xbf, 0x10, 0x23, 0xc4, 0xbf, 0x84, 0x6b, 0x95, 0xa9, 0x15, 0xe5, 0xdf, 0xb1, 0x6a, 0xa7,
+	0x60, 0xe3, 0xfd, 0xa9, 0xfc, 0x2c, 0x90, 0xa5, 0x41, 0x34, 0x0f, 0x64, 0xdd, 0x1c, 0x6c, 0x18,
+	0x53, 0xb8, 0x42, 0xd8, 0x33, 0x58, 0x2c, 0x8f, 0x65, 0xe8, 0x83, 0x4c, 0x7d, 0xdd, 0xc4, 0x68,
+	0x6c, 0x4c, 0x63, 0x67, 0xf2, 0xca, 0x83, 0x54, 0x2e, 0xaf, 0x76, 0x72, 0x33, 0x36, 0xa6, 0xb1,
+	0x33, 0x67, 0x4b, 0xe3, 0x4f, 0xee, 0x6c, 0xdd, 0x5c, 0x66, 0x18, 0x53, 0xb8, 0xd9, 0x99, 0x28,
+	0xcc, 0x14, 0xf9, 0x99, 0x98, 0x9c, 0x8c, 0x0c, 0xbd, 0x96, 0x57, 0x2c, 0x3e, 0xef, 0xbc, 0xae,
+	0xf8, 0xbc, 0xf3, 0x0b, 0x8a, 0xcf, 0x3b, 0x9f, 0x94, 0x54, 0xec, 0xec, 0x73, 0x49, 0x35, 0x23,
+	0x84, 0xb1, 0x5e, 0xcf, 0xcc, 0x5c, 0x2b, 0x34, 0xca, 0xa8, 0x14, 0x87, 0x72, 0x4f, 0x6d, 0xe8,
+	0xb5, 0xbc, 0xbc, 0x1c, 0x4a, 0x2d, 0x70, 0xa1, 0x1c, 0xea, 0x3a, 0x69, 0x63, 0x63, 0x1a, 0xbb,
+	0x64, 0x96, 0x30, 0xb7, 0x62, 0x56, 0xb9, 0x1d, 0x34, 0xf4, 0x5a, 0x5e, 0xe9, 0xc6, 0xaf, 0xdc,
+	0x86, 0xd5, 0xfe, 0xcf, 0x58, 0xab, 0xe1, 0x64, 0x7e, 0x95, 0x7b, 0xb1, 0xdc, 0xaf, 0xda, 0xd6,
+	0xce, 0xd8, 0x98, 0xc6, 0xe6, 0xf2, 0x1e, 0xae, 0xfe, 0xfd, 0xed, 0xa6, 0xf2, 0xcf, 0xb7, 0x9b,
+	0xca, 0xbf, 0xde, 0x6e, 0x2a, 0xbf, 0xfe, 0xf7, 0xe6, 0xcc, 0x4f, 0x9a, 0xc4, 0x0b, 0x06, 0x6d,
+	0xfe, 0xc7, 0xe9, 0x27, 0xff, 0x1d, 0x00, 0x4e, 0x4c, 0x9a, 0xe7, 0x6d, 0x1d, 0x00, 0x00,
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/spdk"
+	"github.com/intel/oim/pkg/spdk/spdktest"
 	// . "github.com/onsi/ginkgo"
 )
 
@@ -35,6 +36,10 @@ var (
 	SPDKPath string
 	// VHostPath is the vhost socket for the SCSI VHost controller of the running SPDK.
 	VHostPath string
+	// Fake is the in-process SPDK simulation that is used instead
+	// of a real SPDK when WithFake was given and no real SPDK is
+	// configured. Nil otherwise.
+	Fake *spdktest.Server
 
 	// VHost controller name.
 	VHost = "e2e-test-vhost"
@@ -55,6 +60,7 @@ var (
 type opts struct {
 	controller bool
 	socket     string
+	fake       bool
 }
 
 // Option is the argument type for Init.
@@ -79,9 +85,20 @@ func WithSPDKSocket(path string) Option {
 	}
 }
 
+// WithFake falls back to an in-process SPDK simulation (see
+// spdktest) when neither TEST_SPDK_VHOST_SOCKET nor
+// TEST_SPDK_VHOST_BINARY are set. The simulation only handles the
+// JSON-RPC calls, it does not create vhost or NBD devices.
+func WithFake() Option {
+	return func(o *opts) {
+		o.fake = true
+	}
+}
+
 // Init connects to SPDK and creates a VHost SCSI controller.
 // Must be matched by a Finalize call, even after a failure.
 func Init(options ...Option) error {
+	o = opts{}
 	for _, op := range options {
 		op(&o)
 	}
@@ -98,14 +115,29 @@ func Init(options ...Option) error {
 	}
 
 	// Set up VHost SCSI, if we have SPDK.
-	if spdkSock == "" && spdkApp == "" {
+	if spdkSock == "" && spdkApp == "" && !o.fake {
 		return nil
 	}
 
-	if SPDK != nil || VHostPath != "" || spdkCmd != nil {
+	if SPDK != nil || VHostPath != "" || spdkCmd != nil || Fake != nil {
 		return errors.New("Finalize not called or failed")
 	}
 
+	if spdkSock == "" && spdkApp == "" {
+		t, err := ioutil.TempDir("", "spdk")
+		if err != nil {
+			return errors.Wrap(err, "SPDK temp directory")
+		}
+		tmpDir = t
+		path := filepath.Join(tmpDir, "spdk.sock")
+		f, err := spdktest.New(path)
+		if err != nil {
+			return errors.Wrap(err, "fake SPDK")
+		}
+		Fake = f
+		return connect(path)
+	}
+
 	if spdkApp != "" {
 		// TODO: suppress logging to syslog
 		t, err := ioutil.TempDir("", "spdk")
@@ -192,12 +224,18 @@ func Init(options ...Option) error {
 	}
 	lock = &l
 
-	s, err := spdk.New(spdkSock)
+	return connect(spdkSock)
+}
+
+// connect is the part of Init which is shared between a real SPDK
+// and the fake one.
+func connect(path string) error {
+	s, err := spdk.New(path)
 	if err != nil {
 		return err
 	}
 	SPDK = s
-	SPDKPath = spdkSock
+	SPDKPath = path
 
 	if o.controller {
 		args := spdk.ConstructVHostSCSIControllerArgs{
@@ -207,11 +245,12 @@ func Init(options ...Option) error {
 		if err != nil {
 			return err
 		}
-		VHostPath = filepath.Join(filepath.Dir(spdkSock), VHost)
+		VHostPath = filepath.Join(filepath.Dir(path), VHost)
 
 		// If we are not running as root, we need to
-		// change permissions on the new socket.
-		if os.Getuid() != 0 {
+		// change permissions on the new socket. The fake
+		// SPDK does not create one.
+		if os.Getuid() != 0 && Fake == nil {
 			cmd := exec.Command("sudo", "chmod", "a+rw", VHostPath) // nolint: gosec
 			out, err := cmd.CombinedOutput()
 			if err != nil {
@@ -259,6 +298,12 @@ func Finalize() error {
 		spdkCmd.Wait()                                                                                              // nolint: gosec
 		spdkCmd = nil
 	}
+	if Fake != nil {
+		if err := Fake.Close(); err != nil {
+			log.L().Errorw("close fake SPDK", "error", err)
+		}
+		Fake = nil
+	}
 	if lock != nil {
 		if err := lock.Unlock(); err != nil {
 			return err
@@ -273,6 +318,7 @@ func Finalize() error {
 		if err := os.RemoveAll(tmpDir); err != nil {
 			return err
 		}
+		tmpDir = ""
 	}
 	return nil
 }